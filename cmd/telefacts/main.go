@@ -18,6 +18,12 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "render" {
+		if err := render(os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 	var ctx = context.Background()
 	srv := setupServer()
 	go func() {
@@ -32,6 +38,20 @@ func main() {
 }
 
 func setupServer() *http.Server {
+	setupHydratables()
+	r := web.NewRouter()
+
+	fmt.Println("telefacts<-0.0.0.0:8080")
+	return &http.Server{
+		Addr:         "0.0.0.0:8080",
+		WriteTimeout: time.Second * 15,
+		ReadTimeout:  time.Second * 15,
+		IdleTimeout:  time.Second * 60,
+		Handler:      r,
+	}
+}
+
+func setupHydratables() {
 	appCache := cache.NewCache(false)
 	dir, err := os.Getwd()
 	if err != nil {
@@ -51,16 +71,6 @@ func setupServer() *http.Server {
 	hydratables.HydrateEntityNames()
 	hydratables.HydrateFundamentalSchema()
 	hydratables.HydrateUnitTypeRegistry()
-	r := web.NewRouter()
-
-	fmt.Println("telefacts<-0.0.0.0:8080")
-	return &http.Server{
-		Addr:         "0.0.0.0:8080",
-		WriteTimeout: time.Second * 15,
-		ReadTimeout:  time.Second * 15,
-		IdleTimeout:  time.Second * 60,
-		Handler:      r,
-	}
 }
 
 func listenForShutdown(ctx context.Context, grace time.Duration, srv *http.Server) {
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"ecksbee.com/telefacts/pkg/cache"
+	"ecksbee.com/telefacts/pkg/renderables"
+)
+
+// render prints a network of a folder of the working directory as text or
+// as a diagram, or lists the networks of the folder when no slug is given,
+// e.g.
+//
+//	telefacts render -id <folder> -slug <hash> -format markdown -lang de
+//	telefacts render -id <folder> -slug <hash> -format dot -network definition
+//	telefacts render -id <folder> -format mermaid -network dts
+func render(args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	id := flags.String("id", "", "the id of the folder")
+	slug := flags.String("slug", "", "the hash of the network and subject, as in the catalog")
+	format := flags.String("format", string(renderables.PlainText), "text, markdown, dot or mermaid")
+	network := flags.String("network", "", "the network of a diagram: presentation, calculation, definition or dts")
+	bcp47 := flags.String("lang", "en", "the language of the labels and facts")
+	labelRole := flags.String("labelRole", "", "the label role of the rows, e.g. Terse, or the preferred labels when empty")
+	width := flags.Int("width", 120, "the width that period columns are paged into, or 0 for no paging")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *id == "" {
+		return fmt.Errorf("invalid id '%s'", *id)
+	}
+	setupHydratables()
+	diagramFormat := renderables.DiagramFormat(*format)
+	isDiagram := diagramFormat == renderables.DOT || diagramFormat == renderables.Mermaid
+	if isDiagram && *network == "dts" {
+		data, err := cache.MarshalDTSDiagram(*id, diagramFormat)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	if *slug == "" {
+		data, err := cache.MarshalCatalog(*id)
+		if err != nil {
+			return err
+		}
+		catalog := renderables.Catalog{}
+		err = json.Unmarshal(data, &catalog)
+		if err != nil {
+			return err
+		}
+		for _, subject := range catalog.Subjects {
+			fmt.Fprintln(w, subject.Name)
+			networks := catalog.Networks[subject.Entity.Scheme+"/"+subject.Entity.CharData]
+			for _, rset := range catalog.RelationshipSets {
+				fmt.Fprintf(w, "  %s  %s\n", networks[rset.RoleURI], rset.Title)
+			}
+		}
+		return nil
+	}
+	if isDiagram {
+		data, err := cache.MarshalDiagram(*id, *slug, *network, diagramFormat, renderables.NewLang(*bcp47))
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	data, err := cache.MarshalText(*id, *slug, renderables.TextFormat(*format), renderables.NewLang(*bcp47),
+		renderables.LabelRole(*labelRole), *width)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -13,10 +13,10 @@ require (
 	github.com/klauspost/lctime v0.1.0
 	github.com/patrickmn/go-cache v2.1.0+incompatible
 	golang.org/x/net v0.21.0
+	golang.org/x/text v0.14.0
 )
 
 require (
 	github.com/antchfx/xpath v1.2.4 // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
-	golang.org/x/text v0.14.0 // indirect
 )
@@ -5,8 +5,11 @@ import (
 	neturl "net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"ecksbee.com/telefacts/pkg/cache"
+	"ecksbee.com/telefacts/pkg/renderables"
 	"github.com/gorilla/mux"
 )
 
@@ -50,6 +53,96 @@ func Renderable() func(http.ResponseWriter, *http.Request) {
 			http.Error(w, "Error: invalid hash", http.StatusBadRequest)
 			return
 		}
+		ext := filepath.Ext(hash)
+		lang := renderables.English
+		if bcp47 := r.URL.Query().Get("lang"); bcp47 != "" {
+			lang = renderables.NewLang(bcp47)
+		}
+		switch ext {
+		case ".xlsx":
+			data, err := cache.MarshalWorkbook(id, strings.TrimSuffix(hash, ext), lang)
+			if err != nil {
+				http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+			w.Header().Set("Content-Disposition", "attachment; filename=\""+hash+"\"")
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+			return
+		case ".zip":
+			data, err := cache.MarshalXBRLCSV(id, strings.TrimSuffix(hash, ext), r.URL.Query().Get("network"))
+			if err != nil {
+				http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/zip")
+			w.Header().Set("Content-Disposition", "attachment; filename=\""+hash+"\"")
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+			return
+		case ".html":
+			labelRole := renderables.LabelRole(r.URL.Query().Get("labelRole"))
+			data, err := cache.MarshalHTML(id, strings.TrimSuffix(hash, ext), lang, labelRole)
+			if err != nil {
+				http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+			return
+		case ".txt", ".md":
+			format := renderables.PlainText
+			if ext == ".md" {
+				format = renderables.Markdown
+			}
+			width, _ := strconv.Atoi(r.URL.Query().Get("width"))
+			labelRole := renderables.LabelRole(r.URL.Query().Get("labelRole"))
+			data, err := cache.MarshalText(id, strings.TrimSuffix(hash, ext), format, lang, labelRole, width)
+			if err != nil {
+				http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", contentType(ext))
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+			return
+		case ".xhtml", ".htm":
+			if r.URL.Query().Get("viewer") == "" {
+				break
+			}
+			data, err := cache.MarshalViewer(id, lang)
+			if err != nil {
+				http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+			return
+		case ".dot", ".mmd":
+			data, err := cache.MarshalDiagram(id, strings.TrimSuffix(hash, ext), r.URL.Query().Get("network"), diagramFormat(ext), lang)
+			if err != nil {
+				http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", contentType(ext))
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+			return
+		case ".csv", ".tsv":
+			data, err := cache.MarshalDelimitedGrid(id, strings.TrimSuffix(hash, ext), r.URL.Query().Get("grid"), lang, comma(ext))
+			if err != nil {
+				http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", contentType(ext))
+			w.Header().Set("Content-Disposition", "attachment; filename=\""+hash+"\"")
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+			return
+		}
 
 		data, err := cache.MarshalRenderable(id, hash)
 		if err != nil {
@@ -100,12 +193,339 @@ func Expressable() func(http.ResponseWriter, *http.Request) {
 	}
 }
 
+func XBRLJSON() func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Error: incorrect verb, "+r.Method, http.StatusInternalServerError)
+			return
+		}
+		vars := mux.Vars(r)
+		id := vars["id"]
+		if len(id) <= 0 {
+			http.Error(w, "Error: invalid id '"+id+"'", http.StatusBadRequest)
+			return
+		}
+		data, err := cache.MarshalXBRLJSON(id)
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
+func CatalogHTML() func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Error: incorrect verb, "+r.Method, http.StatusInternalServerError)
+			return
+		}
+		vars := mux.Vars(r)
+		id := vars["id"]
+		if len(id) <= 0 {
+			http.Error(w, "Error: invalid id '"+id+"'", http.StatusBadRequest)
+			return
+		}
+		data, err := cache.MarshalCatalogHTML(id)
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
+func DTSDiagram() func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Error: incorrect verb, "+r.Method, http.StatusInternalServerError)
+			return
+		}
+		vars := mux.Vars(r)
+		id := vars["id"]
+		if len(id) <= 0 {
+			http.Error(w, "Error: invalid id '"+id+"'", http.StatusBadRequest)
+			return
+		}
+		ext := "." + vars["ext"]
+		data, err := cache.MarshalDTSDiagram(id, diagramFormat(ext))
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentType(ext))
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
+func SourceElement() func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Error: incorrect verb, "+r.Method, http.StatusInternalServerError)
+			return
+		}
+		vars := mux.Vars(r)
+		id := vars["id"]
+		if len(id) <= 0 {
+			http.Error(w, "Error: invalid id '"+id+"'", http.StatusBadRequest)
+			return
+		}
+		elementID := vars["elementID"]
+		if len(elementID) <= 0 {
+			http.Error(w, "Error: invalid element id", http.StatusBadRequest)
+			return
+		}
+		data, err := cache.MarshalSourceElement(id, elementID)
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
+func ViewerIndex() func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Error: incorrect verb, "+r.Method, http.StatusInternalServerError)
+			return
+		}
+		vars := mux.Vars(r)
+		id := vars["id"]
+		if len(id) <= 0 {
+			http.Error(w, "Error: invalid id '"+id+"'", http.StatusBadRequest)
+			return
+		}
+		lang := renderables.English
+		if bcp47 := r.URL.Query().Get("lang"); bcp47 != "" {
+			lang = renderables.NewLang(bcp47)
+		}
+		data, err := cache.MarshalViewerIndex(id, lang)
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
+func Tables() func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Error: incorrect verb, "+r.Method, http.StatusInternalServerError)
+			return
+		}
+		vars := mux.Vars(r)
+		id := vars["id"]
+		if len(id) <= 0 {
+			http.Error(w, "Error: invalid id '"+id+"'", http.StatusBadRequest)
+			return
+		}
+		data, err := cache.MarshalTables(id)
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
+func TGrids() func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Error: incorrect verb, "+r.Method, http.StatusInternalServerError)
+			return
+		}
+		vars := mux.Vars(r)
+		id := vars["id"]
+		if len(id) <= 0 {
+			http.Error(w, "Error: invalid id '"+id+"'", http.StatusBadRequest)
+			return
+		}
+		hash := vars["hash"]
+		if len(hash) <= 0 {
+			http.Error(w, "Error: invalid hash '"+hash+"'", http.StatusBadRequest)
+			return
+		}
+		data, err := cache.MarshalTGrids(id, hash)
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
+func DelimitedFacts() func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Error: incorrect verb, "+r.Method, http.StatusInternalServerError)
+			return
+		}
+		vars := mux.Vars(r)
+		id := vars["id"]
+		if len(id) <= 0 {
+			http.Error(w, "Error: invalid id '"+id+"'", http.StatusBadRequest)
+			return
+		}
+		ext := "." + vars["ext"]
+		lang := renderables.English
+		if bcp47 := r.URL.Query().Get("lang"); bcp47 != "" {
+			lang = renderables.NewLang(bcp47)
+		}
+		data, err := cache.MarshalDelimitedFacts(id, lang, comma(ext))
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentType(ext))
+		w.Header().Set("Content-Disposition", "attachment; filename=\"facts"+ext+"\"")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
+func comma(ext string) rune {
+	if ext == ".tsv" {
+		return renderables.TSV
+	}
+	return renderables.CSV
+}
+
+func diagramFormat(ext string) renderables.DiagramFormat {
+	if ext == ".mmd" {
+		return renderables.Mermaid
+	}
+	return renderables.DOT
+}
+
+func contentType(ext string) string {
+	switch ext {
+	case ".tsv":
+		return "text/tab-separated-values; charset=utf-8"
+	case ".txt":
+		return "text/plain; charset=utf-8"
+	case ".md":
+		return "text/markdown; charset=utf-8"
+	case ".dot":
+		return "text/vnd.graphviz; charset=utf-8"
+	case ".mmd":
+		return "text/plain; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+func Concepts() func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Error: incorrect verb, "+r.Method, http.StatusInternalServerError)
+			return
+		}
+		vars := mux.Vars(r)
+		id := vars["id"]
+		if len(id) <= 0 {
+			http.Error(w, "Error: invalid id '"+id+"'", http.StatusBadRequest)
+			return
+		}
+		parsedquery, err := neturl.ParseQuery(r.URL.RawQuery)
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		query := renderables.ConceptQuery{
+			Text:       parsedquery.Get("q"),
+			Type:       parsedquery.Get("type"),
+			Balance:    parsedquery.Get("balance"),
+			PeriodType: parsedquery.Get("periodType"),
+		}
+		if limit := parsedquery.Get("limit"); limit != "" {
+			query.Limit, err = strconv.Atoi(limit)
+			if err != nil {
+				http.Error(w, "Error: invalid limit '"+limit+"'", http.StatusBadRequest)
+				return
+			}
+		}
+		data, err := cache.MarshalConcepts(id, query)
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
+func ConceptDetail() func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Error: incorrect verb, "+r.Method, http.StatusInternalServerError)
+			return
+		}
+		vars := mux.Vars(r)
+		id := vars["id"]
+		if len(id) <= 0 {
+			http.Error(w, "Error: invalid id '"+id+"'", http.StatusBadRequest)
+			return
+		}
+		parsedquery, err := neturl.ParseQuery(r.URL.RawQuery)
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		href := parsedquery.Get("href")
+		if len(href) <= 0 {
+			href, err = neturl.PathUnescape(vars["href"])
+			if err != nil {
+				http.Error(w, "Error: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if len(href) <= 0 {
+			http.Error(w, "Error: invalid href", http.StatusBadRequest)
+			return
+		}
+		data, err := cache.MarshalConceptDetail(id, href)
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
 func NewRouter() http.Handler {
-	r := mux.NewRouter()
+	r := mux.NewRouter().UseEncodedPath()
 	foldersRoute := r.PathPrefix("/folders").Subrouter()
 	foldersRoute.HandleFunc("/{id}", Catalog()).Methods("GET")
 	projectIDRoute := foldersRoute.PathPrefix("/{id}").Subrouter()
 	projectIDRoute.HandleFunc("/facts", Expressable()).Methods("GET")
+	projectIDRoute.HandleFunc("/facts.{ext:csv|tsv}", DelimitedFacts()).Methods("GET")
+	projectIDRoute.HandleFunc("/xbrl.json", XBRLJSON()).Methods("GET")
+	projectIDRoute.HandleFunc("/index.html", CatalogHTML()).Methods("GET")
+	projectIDRoute.HandleFunc("/viewer.json", ViewerIndex()).Methods("GET")
+	projectIDRoute.HandleFunc("/dts.{ext:dot|mmd}", DTSDiagram()).Methods("GET")
+	projectIDRoute.HandleFunc("/elements/{elementID}", SourceElement()).Methods("GET")
+	projectIDRoute.HandleFunc("/tables", Tables()).Methods("GET")
+	projectIDRoute.HandleFunc("/tables/{hash}", TGrids()).Methods("GET")
+	projectIDRoute.HandleFunc("/concepts/{href:.+}", ConceptDetail()).Methods("GET")
+	projectIDRoute.HandleFunc("/concepts", ConceptDetail()).Queries("href", "{href}").Methods("GET")
+	projectIDRoute.HandleFunc("/concepts", Concepts()).Methods("GET")
 	projectIDRoute.HandleFunc("/{hash}", Renderable()).Methods("GET")
 	wd, err := os.Getwd()
 	if err != nil {
@@ -18,12 +18,25 @@ const DTRNONNUM = `http://www.xbrl.org/dtr/type/nonNumeric-2009-12-16.xsd`
 const DTRNUM = `http://www.xbrl.org/dtr/type/numeric-2009-12-16.xsd`
 const NONNUM = `http://www.xbrl.org/dtr/type/non-numeric`
 const NUM = `http://www.xbrl.org/dtr/type/numeric`
+const DTR2020 = `http://www.xbrl.org/dtr/type/2020-01-21`
+const DTR2022 = `http://www.xbrl.org/dtr/type/2022-03-31`
+const ENUM = `http://xbrl.org/2014/extensible-enumerations`
+const ENUM2 = `http://xbrl.org/2020/extensible-enumerations-2.0`
 const XSI = `http://www.w3.org/2001/XMLSchema-instance`
 const ISO4217 = `http://www.xbrl.org/2003/iso4217`
+const OIM = `https://xbrl.org/2021`
+const XBRLJSON = `https://xbrl.org/2021/xbrl-json`
+const XBRLCSV = `https://xbrl.org/2021/xbrl-csv`
+const GEN = `http://xbrl.org/2008/generic`
+const GENLABEL = `http://xbrl.org/2008/label`
+const FORMULA = `http://xbrl.org/2008/formula`
+const TABLE = `http://xbrl.org/2014/table`
+const XFI = `http://www.xbrl.org/2008/function/instance`
 const LabelLinkbaseRef = `http://www.xbrl.org/2003/role/labelLinkbaseRef`
 const CalculationLinkbaseRef = `http://www.xbrl.org/2003/role/calculationLinkbaseRef`
 const DefinitionLinkbaseRef = `http://www.xbrl.org/2003/role/definitionLinkbaseRef`
 const PresentationLinkbaseRef = `http://www.xbrl.org/2003/role/presentationLinkbaseRef`
+const ReferenceLinkbaseRef = `http://www.xbrl.org/2003/role/referenceLinkbaseRef`
 const PresentationArcrole = `http://www.xbrl.org/2003/arcrole/parent-child`
 const DomainMemberArcrole = `http://xbrl.org/int/dim/arcrole/domain-member`
 const DimensionDomainArcrole = `http://xbrl.org/int/dim/arcrole/dimension-domain`
@@ -33,8 +46,16 @@ const HasInclusiveHypercubeArcrole = `http://xbrl.org/int/dim/arcrole/all`
 const HasExclusiveHypercubeArcrole = `http://xbrl.org/int/dim/arcrole/notAll`
 const CalculationArcrole = `http://www.xbrl.org/2003/arcrole/summation-item`
 const LabelArcrole = `http://www.xbrl.org/2003/arcrole/concept-label`
+const ReferenceArcrole = `http://www.xbrl.org/2003/arcrole/concept-reference`
+const ElementLabelArcrole = `http://xbrl.org/arcrole/2008/element-label`
+const TableBreakdownArcrole = `http://xbrl.org/arcrole/2014/table-breakdown`
+const BreakdownTreeArcrole = `http://xbrl.org/arcrole/2014/breakdown-tree`
+const DefinitionNodeSubtreeArcrole = `http://xbrl.org/arcrole/2014/definition-node-subtree`
 const Label = `http://www.xbrl.org/2003/role/label`
 const VerboseLabel = `http://www.xbrl.org/2003/role/verboseLabel`
+const GenericLabel = `http://www.xbrl.org/2008/role/label`
+const GenericTerseLabel = `http://www.xbrl.org/2008/role/terseLabel`
+const GenericVerboseLabel = `http://www.xbrl.org/2008/role/verboseLabel`
 const TerseLabel = `http://www.xbrl.org/2003/role/terseLabel`
 const TotalLabel = `http://www.xbrl.org/2003/role/totalLabel`
 const PeriodEndLabel = `http://www.xbrl.org/2003/role/periodEndLabel`
@@ -42,9 +63,19 @@ const PeriodStartLabel = `http://www.xbrl.org/2003/role/periodStartLabel`
 const NegatedLabel = `http://www.xbrl.org/2009/role/negatedLabel`
 const NegatedTerseLabel = `http://www.xbrl.org/2009/role/negatedTerseLabel`
 const NegatedTotalLabel = `http://www.xbrl.org/2009/role/negatedTotalLabel`
+const NegatedPeriodEndLabel = `http://www.xbrl.org/2009/role/negatedPeriodEndLabel`
+const NegatedPeriodStartLabel = `http://www.xbrl.org/2009/role/negatedPeriodStartLabel`
+const NegatedNetLabel = `http://www.xbrl.org/2009/role/negatedNetLabel`
+const NetLabel = `http://www.xbrl.org/2009/role/netLabel`
 const PositiveLabel = `http://www.xbrl.org/2003/role/positiveLabel`
 const PositiveTerseLabel = `http://www.xbrl.org/2003/role/positiveTerseLabel`
 const PositiveVerboseLabel = `http://www.xbrl.org/2003/role/positiveVerboseLabel`
+const NegativeLabel = `http://www.xbrl.org/2003/role/negativeLabel`
+const NegativeTerseLabel = `http://www.xbrl.org/2003/role/negativeTerseLabel`
+const NegativeVerboseLabel = `http://www.xbrl.org/2003/role/negativeVerboseLabel`
+const ZeroLabel = `http://www.xbrl.org/2003/role/zeroLabel`
+const ZeroTerseLabel = `http://www.xbrl.org/2003/role/zeroTerseLabel`
+const ZeroVerboseLabel = `http://www.xbrl.org/2003/role/zeroVerboseLabel`
 const DefinitionGuidance = `http://www.xbrl.org/2003/role/definitionGuidance`
 const DisclosureGuidance = `http://www.xbrl.org/2003/role/disclosureGuidance`
 const PresentationGuidance = `http://www.xbrl.org/2003/role/presentationGuidance`
@@ -53,5 +84,11 @@ const MeasurementGuidance = `http://www.xbrl.org/2003/role/measurementGuidance`
 const Documentation = `http://www.xbrl.org/2003/role/documentation`
 const Commentary = `http://www.xbrl.org/2003/role/commentary`
 const Example = `http://www.xbrl.org/2003/role/example`
+const CommentaryGuidance = `http://www.xbrl.org/2003/role/commentaryGuidance`
+const ExampleGuidance = `http://www.xbrl.org/2003/role/exampleGuidance`
+const MonetaryItemType = `monetaryItemType`
 const PercentItemType = `percentItemType`
+const PerShareItemType = `perShareItemType`
 const TextBlockItemType = `textBlockItemType`
+const EnumerationItemType = `enumerationItemType`
+const EnumerationSetItemType = `enumerationSetItemType`
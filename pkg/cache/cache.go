@@ -2,9 +2,11 @@ package cache
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"hash/fnv"
 	"path/filepath"
+	"strconv"
 	"sync"
 
 	"ecksbee.com/telefacts/pkg/hydratables"
@@ -14,14 +16,23 @@ import (
 )
 
 var (
-	dry      bool
-	lock     sync.RWMutex
-	once     sync.Once
-	appCache *gocache.Cache
+	dry           bool
+	lock          sync.RWMutex
+	once          sync.Once
+	appCache      *gocache.Cache
+	renderOptions []renderables.RenderOption
 )
 
-func NewCache(runDry bool) *gocache.Cache {
+// NewCache renders with the options, flushing what was rendered with the
+// options of a previous call
+func NewCache(runDry bool, options ...renderables.RenderOption) *gocache.Cache {
+	lock.Lock()
+	defer lock.Unlock()
 	dry = runDry
+	renderOptions = options
+	if appCache != nil {
+		appCache.Flush()
+	}
 	if dry {
 		return appCache
 	}
@@ -48,7 +59,7 @@ func MarshalExpressable(id string, name string, contextref string) ([]byte, erro
 		}
 	}
 	lock.RUnlock()
-	byteArr, err := renderables.MarshalExpressable(name, contextref, h)
+	byteArr, err := renderables.MarshalExpressable(name, contextref, h, renderOptions...)
 	go func() {
 		if dry {
 			return
@@ -90,7 +101,7 @@ func MarshalRenderable(id string, hash string) ([]byte, error) {
 			return data, nil
 		}
 	}
-	byteArr, err := renderables.MarshalRenderable(hash, h)
+	byteArr, err := renderables.MarshalRenderable(hash, h, renderOptions...)
 	go func() {
 		if dry {
 			return
@@ -102,6 +113,433 @@ func MarshalRenderable(id string, hash string) ([]byte, error) {
 	return byteArr, err
 }
 
+func MarshalWorkbook(id string, hash string, lang renderables.Lang) ([]byte, error) {
+	cachekey := id + "/" + hash + ".xlsx/" + string(lang)
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalWorkbook(hash, h, lang, renderOptions...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalHTML(id string, hash string, lang renderables.Lang, labelRole renderables.LabelRole) ([]byte, error) {
+	cachekey := id + "/" + hash + ".html/" + string(lang) + "/" + string(labelRole)
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalHTML(hash, h, lang, labelRole, renderOptions...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalText(id string, hash string, format renderables.TextFormat, lang renderables.Lang, labelRole renderables.LabelRole, width int) ([]byte, error) {
+	cachekey := id + "/" + hash + "." + string(format) + "/" + string(lang) + "/" + string(labelRole) + "/" + strconv.Itoa(width)
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalText(hash, h, format, lang, labelRole, width, renderOptions...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalDiagram(id string, hash string, network string, format renderables.DiagramFormat, lang renderables.Lang) ([]byte, error) {
+	cachekey := id + "/" + hash + "." + string(format) + network + "/" + string(lang)
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalDiagram(hash, h, network, format, lang)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalDTSDiagram(id string, format renderables.DiagramFormat) ([]byte, error) {
+	cachekey := id + "/dts." + string(format)
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalDTSDiagram(h, format)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalViewer(id string, lang renderables.Lang) ([]byte, error) {
+	cachekey := id + "/viewer/" + string(lang)
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalViewer(h, lang, renderOptions...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalViewerIndex(id string, lang renderables.Lang) ([]byte, error) {
+	cachekey := id + "/viewer.json/" + string(lang)
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalViewerIndex(h, lang, renderOptions...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalCatalogHTML(id string) ([]byte, error) {
+	cachekey := id + "/index.html"
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalCatalogHTML(h)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalTables(id string) ([]byte, error) {
+	cachekey := id + "/tables"
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalTables(h)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalTGrids(id string, hash string) ([]byte, error) {
+	cachekey := id + "/tables/" + hash
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalTGrids(hash, h, renderOptions...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalDelimitedGrid(id string, hash string, network string, lang renderables.Lang, comma rune) ([]byte, error) {
+	cachekey := id + "/" + hash + "." + string(comma) + network + "/" + string(lang)
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalDelimitedGrid(hash, h, network, lang, comma, renderOptions...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalDelimitedFacts(id string, lang renderables.Lang, comma rune) ([]byte, error) {
+	cachekey := id + "/facts." + string(comma) + "/" + string(lang)
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalDelimitedFacts(h, lang, comma)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalXBRLJSON(id string) ([]byte, error) {
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(id + "/xbrl.json"); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalXBRLJSON(h)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(id+"/xbrl.json", byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalXBRLCSV(id string, hash string, network string) ([]byte, error) {
+	cachekey := id + "/" + hash + ".zip/" + network
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(cachekey); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalXBRLCSV(hash, h, network)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(cachekey, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func MarshalSourceElement(id string, elementID string) ([]byte, error) {
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	return renderables.MarshalSourceElement(elementID, h)
+}
+
 func MarshalCatalog(id string) ([]byte, error) {
 	h, err := hydratable(id)
 	if err != nil {
@@ -128,6 +566,69 @@ func MarshalCatalog(id string) ([]byte, error) {
 	return byteArr, err
 }
 
+func MarshalConcepts(id string, query renderables.ConceptQuery) ([]byte, error) {
+	index, err := conceptIndex(id)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(index.Search(query))
+}
+
+func MarshalConceptDetail(id string, href string) ([]byte, error) {
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(id + "/concepts/" + href); found {
+			ret := x.([]byte)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	byteArr, err := renderables.MarshalConceptDetail(href, h, renderOptions...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(id+"/concepts/"+href, byteArr, gocache.DefaultExpiration)
+	}()
+	return byteArr, nil
+}
+
+func conceptIndex(id string) (*renderables.ConceptIndex, error) {
+	lock.RLock()
+	if !dry {
+		if x, found := appCache.Get(id + "/concepts"); found {
+			ret := x.(*renderables.ConceptIndex)
+			lock.RUnlock()
+			return ret, nil
+		}
+	}
+	lock.RUnlock()
+	h, err := hydratable(id)
+	if err != nil {
+		return nil, err
+	}
+	ret := renderables.NewConceptIndex(h)
+	go func() {
+		if dry {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		appCache.Set(id+"/concepts", ret, gocache.DefaultExpiration)
+	}()
+	return ret, nil
+}
+
 func hydratable(id string) (*hydratables.Hydratable, error) {
 	lock.RLock()
 	if !dry {
@@ -0,0 +1,269 @@
+package hydratables
+
+import (
+	"fmt"
+	"sort"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+// BaseSets are the effective networks of the folder's linkbases and of the
+// global linkbases they refer to, one extended
+// link per role with locators labelled by href. Of equivalent relationships,
+// only the one of highest priority remains, and none if it is prohibited.
+type BaseSets struct {
+	Presentation PresentationLinkbase
+	Definition   DefinitionLinkbase
+	Calculation  CalculationLinkbase
+}
+
+// arcKey identifies equivalent relationships by their base set, source,
+// target and non-exempt attributes
+type arcKey struct {
+	role    string
+	arcrole string
+	from    string
+	to      string
+	attrs   string
+}
+
+type arcCandidate struct {
+	key        arcKey
+	priority   int
+	prohibited bool
+}
+
+func effectiveArcs(candidates []arcCandidate) []int {
+	highest := make(map[arcKey]int)
+	for _, candidate := range candidates {
+		if priority, found := highest[candidate.key]; !found || candidate.priority > priority {
+			highest[candidate.key] = candidate.priority
+		}
+	}
+	prohibited := make(map[arcKey]bool)
+	for _, candidate := range candidates {
+		if candidate.prohibited && candidate.priority == highest[candidate.key] {
+			prohibited[candidate.key] = true
+		}
+	}
+	kept := make(map[arcKey]bool)
+	ret := make([]int, 0, len(candidates))
+	for i, candidate := range candidates {
+		if prohibited[candidate.key] || kept[candidate.key] ||
+			candidate.prohibited || candidate.priority != highest[candidate.key] {
+			continue
+		}
+		kept[candidate.key] = true
+		ret = append(ret, i)
+	}
+	return ret
+}
+
+func appendRoleRefs(dst []RoleRef, roleRefs []RoleRef) []RoleRef {
+	for _, roleRef := range roleRefs {
+		found := false
+		for _, existing := range dst {
+			if existing.RoleURI == roleRef.RoleURI {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, roleRef)
+		}
+	}
+	return dst
+}
+
+// hydrateBaseSets resolves the local and global linkbases together, so that
+// an extension may prohibit or override a relationship of a standard taxonomy
+func hydrateBaseSets(h *Hydratable) BaseSets {
+	presentationLinkbases := make(map[string]PresentationLinkbase, len(h.PresentationLinkbases))
+	for fileName, linkbase := range h.PresentationLinkbases {
+		presentationLinkbases[fileName] = linkbase
+	}
+	definitionLinkbases := make(map[string]DefinitionLinkbase, len(h.DefinitionLinkbases))
+	for fileName, linkbase := range h.DefinitionLinkbases {
+		definitionLinkbases[fileName] = linkbase
+	}
+	calculationLinkbases := make(map[string]CalculationLinkbase, len(h.CalculationLinkbases))
+	for fileName, linkbase := range h.CalculationLinkbases {
+		calculationLinkbases[fileName] = linkbase
+	}
+	if h.Folder != nil {
+		for href, role := range globalLinkbaseRefs(h.Folder) {
+			switch role {
+			case attr.PresentationLinkbaseRef:
+				linkbase, err := HydrateGlobalPresentationLinkbase(href)
+				if err != nil {
+					continue
+				}
+				presentationLinkbases[href] = *linkbase
+			case attr.DefinitionLinkbaseRef:
+				linkbase, err := HydrateGlobalDefinitionLinkbase(href)
+				if err != nil {
+					continue
+				}
+				definitionLinkbases[href] = *linkbase
+			case attr.CalculationLinkbaseRef:
+				linkbase, err := HydrateGlobalCalculationLinkbase(href)
+				if err != nil {
+					continue
+				}
+				calculationLinkbases[href] = *linkbase
+			}
+		}
+	}
+	return BaseSets{
+		Presentation: presentationBaseSet(presentationLinkbases),
+		Definition:   definitionBaseSet(definitionLinkbases),
+		Calculation:  calculationBaseSet(calculationLinkbases),
+	}
+}
+
+func presentationBaseSet(linkbases map[string]PresentationLinkbase) PresentationLinkbase {
+	ret := PresentationLinkbase{}
+	fileNames := make([]string, 0, len(linkbases))
+	for fileName := range linkbases {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	links := make([]PresentationLink, 0)
+	for _, fileName := range fileNames {
+		ret.RoleRefs = appendRoleRefs(ret.RoleRefs, linkbases[fileName].RoleRefs)
+		links = append(links, linkbases[fileName].PresentationLinks...)
+	}
+	ret.PresentationLinks = dedupPresentationLink(links)
+	for i, link := range ret.PresentationLinks {
+		candidates := make([]arcCandidate, 0, len(link.PresentationArcs))
+		for _, arc := range link.PresentationArcs {
+			candidates = append(candidates, arcCandidate{
+				key: arcKey{
+					role:    link.Role,
+					arcrole: arc.Arcrole,
+					from:    arc.From,
+					to:      arc.To,
+					attrs:   fmt.Sprint(arc.Order, arc.PreferredLabel),
+				},
+				priority:   arc.Priority,
+				prohibited: arc.Prohibited,
+			})
+		}
+		arcs := make([]PresentationArc, 0, len(candidates))
+		for _, j := range effectiveArcs(candidates) {
+			arcs = append(arcs, link.PresentationArcs[j])
+		}
+		ret.PresentationLinks[i].PresentationArcs = arcs
+	}
+	return ret
+}
+
+func definitionBaseSet(linkbases map[string]DefinitionLinkbase) DefinitionLinkbase {
+	ret := DefinitionLinkbase{}
+	fileNames := make([]string, 0, len(linkbases))
+	for fileName := range linkbases {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	links := make([]DefinitionLink, 0)
+	for _, fileName := range fileNames {
+		ret.RoleRefs = appendRoleRefs(ret.RoleRefs, linkbases[fileName].RoleRefs)
+		links = append(links, linkbases[fileName].DefinitionLinks...)
+	}
+	ret.DefinitionLinks = dedupDefinitionLink(links)
+	for i, link := range ret.DefinitionLinks {
+		candidates := make([]arcCandidate, 0, len(link.DefinitionArcs))
+		for _, arc := range link.DefinitionArcs {
+			candidates = append(candidates, arcCandidate{
+				key: arcKey{
+					role:    link.Role,
+					arcrole: arc.Arcrole,
+					from:    arc.From,
+					to:      arc.To,
+					attrs: fmt.Sprint(arc.Order, arc.Closed, arc.Usable,
+						arc.ContextElement, arc.TargetRole),
+				},
+				priority:   arc.Priority,
+				prohibited: arc.Prohibited,
+			})
+		}
+		arcs := make([]DefinitionArc, 0, len(candidates))
+		for _, j := range effectiveArcs(candidates) {
+			arcs = append(arcs, link.DefinitionArcs[j])
+		}
+		ret.DefinitionLinks[i].DefinitionArcs = arcs
+	}
+	return ret
+}
+
+func calculationBaseSet(linkbases map[string]CalculationLinkbase) CalculationLinkbase {
+	ret := CalculationLinkbase{}
+	fileNames := make([]string, 0, len(linkbases))
+	for fileName := range linkbases {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	links := make([]CalculationLink, 0)
+	for _, fileName := range fileNames {
+		ret.RoleRefs = appendRoleRefs(ret.RoleRefs, linkbases[fileName].RoleRefs)
+		links = append(links, linkbases[fileName].CalculationLinks...)
+	}
+	ret.CalculationLinks = dedupCalculationLink(links)
+	for i, link := range ret.CalculationLinks {
+		candidates := make([]arcCandidate, 0, len(link.CalculationArcs))
+		for _, arc := range link.CalculationArcs {
+			candidates = append(candidates, arcCandidate{
+				key: arcKey{
+					role:    link.Role,
+					arcrole: arc.Arcrole,
+					from:    arc.From,
+					to:      arc.To,
+					attrs:   fmt.Sprint(arc.Order, arc.Weight),
+				},
+				priority:   arc.Priority,
+				prohibited: arc.Prohibited,
+			})
+		}
+		arcs := make([]CalculationArc, 0, len(candidates))
+		for _, j := range effectiveArcs(candidates) {
+			arcs = append(arcs, link.CalculationArcs[j])
+		}
+		ret.CalculationLinks[i].CalculationArcs = arcs
+	}
+	return ret
+}
+
+// globalLinkbaseRefs are the global linkbases referred to by the local
+// schemas, and by the global schemas of the DTS they import
+func globalLinkbaseRefs(folder *serializables.Folder) map[string]string {
+	ret := make(map[string]string, len(folder.GlobalLinkbaseRefs))
+	for href, role := range folder.GlobalLinkbaseRefs {
+		ret[href] = role
+	}
+	queue := make([]string, 0)
+	for _, schemaLocation := range folder.Namespaces {
+		if attr.IsValidUrl(schemaLocation) {
+			queue = append(queue, schemaLocation)
+		}
+	}
+	sort.Strings(queue)
+	visited := make(map[string]bool)
+	for len(queue) > 0 {
+		urlStr := queue[0]
+		queue = queue[1:]
+		if visited[urlStr] {
+			continue
+		}
+		visited[urlStr] = true
+		schema, err := HydrateGlobalSchema(urlStr)
+		if err != nil {
+			continue
+		}
+		for href, role := range schema.LinkbaseRefs {
+			ret[href] = role
+		}
+		queue = append(queue, schema.Imports...)
+	}
+	return ret
+}
@@ -2,7 +2,6 @@ package hydratables
 
 import (
 	"fmt"
-	"math"
 	"strconv"
 
 	"ecksbee.com/telefacts/pkg/attr"
@@ -10,11 +9,13 @@ import (
 )
 
 type CalculationArc struct {
-	Order   float64
-	Arcrole string
-	From    string
-	To      string
-	Weight  float64
+	Order      float64
+	Arcrole    string
+	From       string
+	To         string
+	Weight     float64
+	Priority   int
+	Prohibited bool
 }
 
 type CalculationLink struct {
@@ -108,10 +109,6 @@ func hydrateCalculationLink(linkbaseFile *serializables.CalculationLinkbaseFile)
 			if ttypeAttr == nil || ttypeAttr.Name.Space != attr.XLINK || ttypeAttr.Value != "arc" {
 				continue
 			}
-			orderAttr := attr.FindAttr(arc.XMLAttrs, "order")
-			if orderAttr == nil || orderAttr.Value == "" {
-				continue
-			}
 			arcroleAttr := attr.FindAttr(arc.XMLAttrs, "arcrole")
 			if arcroleAttr == nil || arcroleAttr.Name.Space != attr.XLINK || arcroleAttr.Value == "" {
 				continue
@@ -128,19 +125,16 @@ func hydrateCalculationLink(linkbaseFile *serializables.CalculationLinkbaseFile)
 			if weightAttr == nil || weightAttr.Value == "" {
 				continue
 			}
-			order, err := strconv.ParseFloat(orderAttr.Value, 64)
-			if err != nil {
-				order = math.MaxFloat64
-			}
 			weight, err := strconv.ParseFloat(weightAttr.Value, 64)
 			if err != nil {
 				weight = 0.0
 			}
 			newArc.Arcrole = arcroleAttr.Value
-			newArc.Order = order
+			newArc.Order = arcOrder(arc.XMLAttrs)
 			newArc.From = fromAttr.Value
 			newArc.To = toAttr.Value
 			newArc.Weight = weight
+			newArc.Priority, newArc.Prohibited = arcUse(arc.XMLAttrs)
 			newLink.CalculationArcs = append(newLink.CalculationArcs, newArc)
 		}
 		ret = append(ret, newLink)
@@ -148,20 +142,36 @@ func hydrateCalculationLink(linkbaseFile *serializables.CalculationLinkbaseFile)
 	return dedupCalculationLink(ret)
 }
 
+// dedupCalculationLink merges the extended links of a role, with locators
+// labelled by href; prohibited arcs are kept for base set resolution
 func dedupCalculationLink(links []CalculationLink) []CalculationLink {
 	occured := map[string]CalculationLink{}
+	roles := make([]string, 0)
 	for _, link := range links {
-		if merged, found := occured[link.Role]; found {
-			merged.Locs = append(merged.Locs, link.Locs...)
-			merged.CalculationArcs = append(merged.CalculationArcs, link.CalculationArcs...)
-			occured[link.Role] = merged
-		} else {
-			occured[link.Role] = link
+		merged, found := occured[link.Role]
+		if !found {
+			merged = CalculationLink{
+				Role: link.Role,
+			}
+			roles = append(roles, link.Role)
+		}
+		hrefs := locatorHrefs(link.Locs)
+		for _, arc := range link.CalculationArcs {
+			for _, from := range hrefs[arc.From] {
+				for _, to := range hrefs[arc.To] {
+					resolved := arc
+					resolved.From = from
+					resolved.To = to
+					merged.CalculationArcs = append(merged.CalculationArcs, resolved)
+				}
+			}
 		}
+		merged.Locs = appendHrefLocs(merged.Locs, link.Locs)
+		occured[link.Role] = merged
 	}
-	ret := make([]CalculationLink, 0)
-	for _, r := range occured {
-		ret = append(ret, r)
+	ret := make([]CalculationLink, 0, len(roles))
+	for _, role := range roles {
+		ret = append(ret, occured[role])
 	}
 	return ret
 }
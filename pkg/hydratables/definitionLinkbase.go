@@ -2,7 +2,6 @@ package hydratables
 
 import (
 	"fmt"
-	"math"
 	"strconv"
 
 	"ecksbee.com/telefacts/pkg/attr"
@@ -18,6 +17,8 @@ type DefinitionArc struct {
 	Usable         bool
 	ContextElement string
 	TargetRole     string
+	Priority       int
+	Prohibited     bool
 }
 
 type DefinitionLink struct {
@@ -111,10 +112,6 @@ func hydrateDefinitionLink(linkbaseFile *serializables.DefinitionLinkbaseFile) [
 			if ttypeAttr == nil || ttypeAttr.Name.Space != attr.XLINK || ttypeAttr.Value != "arc" {
 				continue
 			}
-			orderAttr := attr.FindAttr(arc.XMLAttrs, "order")
-			if orderAttr == nil || orderAttr.Value == "" {
-				continue
-			}
 			arcroleAttr := attr.FindAttr(arc.XMLAttrs, "arcrole")
 			if arcroleAttr == nil || arcroleAttr.Name.Space != attr.XLINK || arcroleAttr.Value == "" {
 				continue
@@ -128,28 +125,24 @@ func hydrateDefinitionLink(linkbaseFile *serializables.DefinitionLinkbaseFile) [
 				continue
 			}
 			newArc.Arcrole = arcroleAttr.Value
-			order, err := strconv.ParseFloat(orderAttr.Value, 64)
-			if err != nil {
-				order = math.MaxFloat64
-			}
-			newArc.Order = order
+			newArc.Order = arcOrder(arc.XMLAttrs)
 			newArc.From = fromAttr.Value
 			newArc.To = toAttr.Value
+			newArc.Priority, newArc.Prohibited = arcUse(arc.XMLAttrs)
 			closedAttr := attr.FindAttr(arc.XMLAttrs, "closed")
 			if closedAttr != nil {
 				closed, err := strconv.ParseBool(closedAttr.Value)
 				if err == nil {
-					closed = false
+					newArc.Closed = closed
 				}
-				newArc.Closed = closed
 			}
+			newArc.Usable = true
 			usableAttr := attr.FindAttr(arc.XMLAttrs, "usable")
 			if usableAttr != nil {
 				usable, err := strconv.ParseBool(usableAttr.Value)
 				if err == nil {
-					usable = true
+					newArc.Usable = usable
 				}
-				newArc.Usable = usable
 			}
 			contextElementAttr := attr.FindAttr(arc.XMLAttrs, "contextElement")
 			if contextElementAttr != nil {
@@ -166,20 +159,36 @@ func hydrateDefinitionLink(linkbaseFile *serializables.DefinitionLinkbaseFile) [
 	return dedupDefinitionLink(ret)
 }
 
+// dedupDefinitionLink merges the extended links of a role, with locators
+// labelled by href; prohibited arcs are kept for base set resolution
 func dedupDefinitionLink(links []DefinitionLink) []DefinitionLink {
 	occured := map[string]DefinitionLink{}
+	roles := make([]string, 0)
 	for _, link := range links {
-		if merged, found := occured[link.Role]; found {
-			merged.Locs = append(merged.Locs, link.Locs...)
-			merged.DefinitionArcs = append(merged.DefinitionArcs, link.DefinitionArcs...)
-			occured[link.Role] = merged
-		} else {
-			occured[link.Role] = link
+		merged, found := occured[link.Role]
+		if !found {
+			merged = DefinitionLink{
+				Role: link.Role,
+			}
+			roles = append(roles, link.Role)
+		}
+		hrefs := locatorHrefs(link.Locs)
+		for _, arc := range link.DefinitionArcs {
+			for _, from := range hrefs[arc.From] {
+				for _, to := range hrefs[arc.To] {
+					resolved := arc
+					resolved.From = from
+					resolved.To = to
+					merged.DefinitionArcs = append(merged.DefinitionArcs, resolved)
+				}
+			}
 		}
+		merged.Locs = appendHrefLocs(merged.Locs, link.Locs)
+		occured[link.Role] = merged
 	}
-	ret := make([]DefinitionLink, 0)
-	for _, r := range occured {
-		ret = append(ret, r)
+	ret := make([]DefinitionLink, 0, len(roles))
+	for _, role := range roles {
+		ret = append(ret, occured[role])
 	}
 	return ret
 }
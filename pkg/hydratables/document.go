@@ -2,6 +2,9 @@ package hydratables
 
 import (
 	"encoding/xml"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"ecksbee.com/telefacts/pkg/attr"
@@ -10,8 +13,19 @@ import (
 )
 
 type Document struct {
-	NamespaceMap  map[string]xml.Name
-	ContextRefMap map[string]bool
+	NamespaceMap          map[string]xml.Name
+	ContextRefMap         map[string]bool
+	Footnotes             map[string]Footnote
+	FootnoteRelationships []FootnoteRelationship
+}
+
+// FootnoteRelationship is an ix:relationship from facts to footnotes
+type FootnoteRelationship struct {
+	Arcrole  string
+	LinkRole string
+	Order    float64
+	FromRefs []string
+	ToRefs   []string
 }
 
 func HydrateDocument(folder *serializables.Folder) (*Document, error) {
@@ -80,7 +94,98 @@ func HydrateDocument(folder *serializables.Folder) (*Document, error) {
 		return nil, goErr2
 	}
 	return &Document{
-		NamespaceMap:  nret,
-		ContextRefMap: cret,
+		NamespaceMap:          nret,
+		ContextRefMap:         cret,
+		Footnotes:             hydrateIxFootnotes(source),
+		FootnoteRelationships: hydrateIxRelationships(source),
 	}, nil
 }
+
+// hydrateIxFootnotes reads ix:footnote content, following continuedAt through
+// ix:continuation elements; the text is kept verbatim, since a continuation
+// may start in the middle of a word
+func hydrateIxFootnotes(source *serializables.Document) map[string]Footnote {
+	continuations := make(map[string]*xmlquery.Node)
+	for _, continuation := range source.Continuations {
+		id := attr.FindXpathAttr(continuation.Attr, "id")
+		if id == nil || id.Value == "" {
+			continue
+		}
+		continuations[id.Value] = continuation
+	}
+	ret := make(map[string]Footnote)
+	for _, footnote := range source.Footnotes {
+		id := attr.FindXpathAttr(footnote.Attr, "id")
+		if id == nil || id.Value == "" {
+			continue
+		}
+		innerHtml := footnote.OutputXMLWithOptions(xmlquery.WithPreserveSpace())
+		visited := make(map[string]bool)
+		curr := footnote
+		for {
+			continuedAt := attr.FindXpathAttr(curr.Attr, "continuedAt")
+			if continuedAt == nil || visited[continuedAt.Value] {
+				break
+			}
+			visited[continuedAt.Value] = true
+			next, found := continuations[continuedAt.Value]
+			if !found {
+				break
+			}
+			innerHtml += next.OutputXMLWithOptions(xmlquery.WithPreserveSpace())
+			curr = next
+		}
+		lang := ""
+		langAttr := attr.FindXpathAttr(footnote.Attr, "lang")
+		if langAttr != nil {
+			lang = langAttr.Value
+		}
+		ret[id.Value] = Footnote{
+			ID:        id.Value,
+			Label:     id.Value,
+			Lang:      lang,
+			InnerHtml: strings.TrimSpace(innerHtml),
+		}
+	}
+	return ret
+}
+
+func hydrateIxRelationships(source *serializables.Document) []FootnoteRelationship {
+	ret := make([]FootnoteRelationship, 0, len(source.FootnoteRelationships))
+	for _, relationship := range source.FootnoteRelationships {
+		fromRefs := attr.FindXpathAttr(relationship.Attr, "fromRefs")
+		toRefs := attr.FindXpathAttr(relationship.Attr, "toRefs")
+		if fromRefs == nil || toRefs == nil {
+			continue
+		}
+		arcrole := attr.FactFootnoteArcrole
+		arcroleAttr := attr.FindXpathAttr(relationship.Attr, "arcrole")
+		if arcroleAttr != nil && arcroleAttr.Value != "" {
+			arcrole = arcroleAttr.Value
+		}
+		linkRole := attr.ROLELINK
+		linkRoleAttr := attr.FindXpathAttr(relationship.Attr, "linkRole")
+		if linkRoleAttr != nil && linkRoleAttr.Value != "" {
+			linkRole = linkRoleAttr.Value
+		}
+		order := 1.0
+		orderAttr := attr.FindXpathAttr(relationship.Attr, "order")
+		if orderAttr != nil {
+			v, err := strconv.ParseFloat(orderAttr.Value, 64)
+			if err == nil {
+				order = v
+			}
+		}
+		ret = append(ret, FootnoteRelationship{
+			Arcrole:  arcrole,
+			LinkRole: linkRole,
+			Order:    order,
+			FromRefs: strings.Fields(fromRefs.Value),
+			ToRefs:   strings.Fields(toRefs.Value),
+		})
+	}
+	sort.SliceStable(ret, func(i int, j int) bool {
+		return ret[i].Order < ret[j].Order
+	})
+	return ret
+}
@@ -0,0 +1,125 @@
+package hydratables
+
+import (
+	"fmt"
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/attr"
+)
+
+func (concept *Concept) IsEnumeration() bool {
+	return concept.EnumDomain.Local != "" && concept.EnumLinkrole != ""
+}
+
+// EnumerationMembers lists the hrefs of the domain members that are allowed
+// values of an enumeration concept. The boolean is false when the folder has
+// no definition network for the concept's linkrole.
+func (h *Hydratable) EnumerationMembers(concept *Concept) ([]string, bool) {
+	if concept == nil || !concept.IsEnumeration() {
+		return nil, false
+	}
+	domainHref, _, err := h.NameQuery(concept.EnumDomain.Space, concept.EnumDomain.Local)
+	if err != nil || domainHref == "" {
+		return nil, false
+	}
+	children := make(map[string][]string)
+	usables := make(map[string]bool)
+	found := false
+	definition := h.BaseSets.Definition
+	for _, definitionLink := range definition.DefinitionLinks {
+		if definitionLink.Role != concept.EnumLinkrole {
+			continue
+		}
+		found = true
+		locs := make(map[string]string)
+		for _, loc := range definitionLink.Locs {
+			locs[loc.Label] = loc.Href
+		}
+		for _, arc := range definitionLink.DefinitionArcs {
+			if arc.Arcrole != attr.DomainMemberArcrole {
+				continue
+			}
+			from, to := locs[arc.From], locs[arc.To]
+			if from == "" || to == "" {
+				continue
+			}
+			children[from] = append(children[from], to)
+			usables[to] = arc.Usable
+		}
+	}
+	if !found {
+		return nil, false
+	}
+	ret := make([]string, 0)
+	if concept.EnumHeadUsable {
+		ret = append(ret, domainHref)
+	}
+	visited := map[string]bool{
+		domainHref: true,
+	}
+	queue := []string{domainHref}
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		for _, child := range children[curr] {
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			if usables[child] {
+				ret = append(ret, child)
+			}
+			queue = append(queue, child)
+		}
+	}
+	return ret, true
+}
+
+// enumerationHrefs maps an enumeration fact value, a QName for Extensible
+// Enumerations 1.0 or a list of namespace#localname URIs for 2.0, to concept
+// hrefs
+func (h *Hydratable) enumerationHrefs(value *Value) ([]string, error) {
+	ret := make([]string, 0)
+	switch value.Kind {
+	case QNameValue:
+		href, _, err := h.NameQuery(value.QName.Space, value.QName.Local)
+		if err != nil || href == "" {
+			return nil, fmt.Errorf("enumeration value not found %s", value.Text)
+		}
+		ret = append(ret, href)
+	case EnumerationSetValue:
+		for _, uri := range value.EnumerationSet {
+			i := strings.LastIndex(uri, "#")
+			href, _, err := h.NameQuery(uri[:i], uri[i+1:])
+			if err != nil || href == "" {
+				return nil, fmt.Errorf("enumeration value not found %s", uri)
+			}
+			ret = append(ret, href)
+		}
+	}
+	return ret, nil
+}
+
+func (h *Hydratable) validateEnumeration(concept *Concept, value *Value) ([]string, error) {
+	hrefs, err := h.enumerationHrefs(value)
+	if err != nil {
+		return nil, err
+	}
+	members, found := h.EnumerationMembers(concept)
+	if !found {
+		return hrefs, fmt.Errorf("no network %s to validate the members of %s", concept.EnumLinkrole, concept.EnumDomain.Local)
+	}
+	for _, href := range hrefs {
+		allowed := false
+		for _, member := range members {
+			if member == href {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return hrefs, fmt.Errorf("%s is not an allowed member of %s", href, concept.EnumDomain.Local)
+		}
+	}
+	return hrefs, nil
+}
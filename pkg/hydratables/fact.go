@@ -1,6 +1,7 @@
 package hydratables
 
 import (
+	"sort"
 	"strings"
 
 	"ecksbee.com/telefacts/internal/graph"
@@ -9,13 +10,16 @@ import (
 )
 
 type Fact struct {
-	Href       string
-	ID         string
-	ContextRef string
-	UnitRef    string
-	Precision  Precision
-	IsNil      bool
-	XMLInner   string
+	Href         string
+	ID           string
+	ContextRef   string
+	UnitRef      string
+	Precision    Precision
+	IsNil        bool
+	XMLInner     string
+	Value        *Value
+	ValueError   error
+	Enumerations []string
 }
 
 func (h *Hydratable) FindFact(href string, contextRef string) *Fact {
@@ -42,10 +46,51 @@ func fnoteArcs(fnoteArcs []FootnoteArc) []myarcs.Arc {
 	return ret
 }
 
+// GetFootnotes lists the footnotes of a fact from the footnote links of the
+// instances and the ix:relationship elements of the inline document
 func (h *Hydratable) GetFootnotes(fact *Fact) []*Footnote {
 	if fact == nil {
 		return make([]*Footnote, 0)
 	}
+	ret := h.getInstanceFootnotes(fact)
+	if h.Document == nil {
+		return ret
+	}
+	for _, relationship := range h.Document.FootnoteRelationships {
+		if relationship.Arcrole != attr.FactFootnoteArcrole {
+			continue
+		}
+		isFrom := false
+		for _, fromRef := range relationship.FromRefs {
+			if fromRef == fact.ID {
+				isFrom = true
+				break
+			}
+		}
+		if !isFrom {
+			continue
+		}
+		for _, toRef := range relationship.ToRefs {
+			footnote, found := h.Document.Footnotes[toRef]
+			if !found {
+				continue
+			}
+			isDuplicate := false
+			for _, existing := range ret {
+				if existing.ID == footnote.ID {
+					isDuplicate = true
+					break
+				}
+			}
+			if !isDuplicate {
+				ret = append(ret, &footnote)
+			}
+		}
+	}
+	return ret
+}
+
+func (h *Hydratable) getInstanceFootnotes(fact *Fact) []*Footnote {
 	for _, instance := range h.Instances {
 		for _, footnoteLink := range instance.FootnoteLinks {
 			arcs := footnoteLink.FootnoteArcs
@@ -116,3 +161,39 @@ func (h *Hydratable) FindFootnote(id string) *Footnote {
 	}
 	return nil
 }
+
+// FactSource is the file a fact is reported in, with the id of its
+// ix:nonFraction or ix:nonNumeric element when it is tagged inline
+type FactSource struct {
+	FileName  string
+	ElementID string
+}
+
+// FindFactSource locates a fact in the inline document of the folder, or
+// else in the instance that reports it
+func (h *Hydratable) FindFactSource(fact *Fact) *FactSource {
+	if fact == nil {
+		return nil
+	}
+	if h.Folder != nil && fact.ID != "" && h.Folder.Document.FactElement(fact.ID) != nil {
+		return &FactSource{
+			FileName:  h.Folder.EntryFileName,
+			ElementID: fact.ID,
+		}
+	}
+	fileNames := make([]string, 0, len(h.Instances))
+	for fileName := range h.Instances {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		for _, candidate := range h.Instances[fileName].Facts {
+			if candidate.Href == fact.Href && candidate.ContextRef == fact.ContextRef && candidate.ID == fact.ID {
+				return &FactSource{
+					FileName: fileName,
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package hydratables
 
 import (
 	"fmt"
+	"net/url"
 	"sync"
 
 	"ecksbee.com/telefacts/pkg/attr"
@@ -37,6 +38,14 @@ func HydrateGlobalSchema(urlStr string) (*Schema, error) {
 	if err != nil {
 		return nil, err
 	}
+	for i, schemaLocation := range schema.Imports {
+		schema.Imports[i] = globalHref(urlStr, schemaLocation)
+	}
+	linkbaseRefs := make(map[string]string, len(schema.LinkbaseRefs))
+	for href, role := range schema.LinkbaseRefs {
+		linkbaseRefs[globalHref(urlStr, href)] = role
+	}
+	schema.LinkbaseRefs = linkbaseRefs
 	go func() {
 		lock.Lock()
 		defer lock.Unlock()
@@ -45,6 +54,151 @@ func HydrateGlobalSchema(urlStr string) (*Schema, error) {
 	return schema, err
 }
 
+// HydrateGlobalPresentationLinkbase reads a linkbase of the global taxonomy
+// set, with the hrefs of its locators resolved against its URL
+func HydrateGlobalPresentationLinkbase(urlStr string) (*PresentationLinkbase, error) {
+	if globalCache == nil {
+		return nil, fmt.Errorf("no accessible cache")
+	}
+	lock.RLock()
+	if x, found := globalCache.Get(urlStr); found {
+		ret := x.(PresentationLinkbase)
+		lock.RUnlock()
+		return &ret, nil
+	}
+	lock.RUnlock()
+	file, err := serializables.DiscoverGlobalPresentationLinkbase(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	linkbase, err := HydratePresentationLinkbase(file, urlStr)
+	if err != nil {
+		return nil, err
+	}
+	linkbase.RoleRefs = globalRoleRefs(urlStr, linkbase.RoleRefs)
+	for i, link := range linkbase.PresentationLinks {
+		linkbase.PresentationLinks[i].Locs = globalLocs(urlStr, link.Locs)
+		for j, arc := range link.PresentationArcs {
+			link.PresentationArcs[j].From = globalHref(urlStr, arc.From)
+			link.PresentationArcs[j].To = globalHref(urlStr, arc.To)
+		}
+	}
+	go func() {
+		lock.Lock()
+		defer lock.Unlock()
+		globalCache.Set(urlStr, *linkbase, gocache.DefaultExpiration)
+	}()
+	return linkbase, err
+}
+
+// HydrateGlobalDefinitionLinkbase reads a linkbase of the global taxonomy
+// set, with the hrefs of its locators resolved against its URL
+func HydrateGlobalDefinitionLinkbase(urlStr string) (*DefinitionLinkbase, error) {
+	if globalCache == nil {
+		return nil, fmt.Errorf("no accessible cache")
+	}
+	lock.RLock()
+	if x, found := globalCache.Get(urlStr); found {
+		ret := x.(DefinitionLinkbase)
+		lock.RUnlock()
+		return &ret, nil
+	}
+	lock.RUnlock()
+	file, err := serializables.DiscoverGlobalDefinitionLinkbase(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	linkbase, err := HydrateDefinitionLinkbase(file, urlStr)
+	if err != nil {
+		return nil, err
+	}
+	linkbase.RoleRefs = globalRoleRefs(urlStr, linkbase.RoleRefs)
+	for i, link := range linkbase.DefinitionLinks {
+		linkbase.DefinitionLinks[i].Locs = globalLocs(urlStr, link.Locs)
+		for j, arc := range link.DefinitionArcs {
+			link.DefinitionArcs[j].From = globalHref(urlStr, arc.From)
+			link.DefinitionArcs[j].To = globalHref(urlStr, arc.To)
+		}
+	}
+	go func() {
+		lock.Lock()
+		defer lock.Unlock()
+		globalCache.Set(urlStr, *linkbase, gocache.DefaultExpiration)
+	}()
+	return linkbase, err
+}
+
+// HydrateGlobalCalculationLinkbase reads a linkbase of the global taxonomy
+// set, with the hrefs of its locators resolved against its URL
+func HydrateGlobalCalculationLinkbase(urlStr string) (*CalculationLinkbase, error) {
+	if globalCache == nil {
+		return nil, fmt.Errorf("no accessible cache")
+	}
+	lock.RLock()
+	if x, found := globalCache.Get(urlStr); found {
+		ret := x.(CalculationLinkbase)
+		lock.RUnlock()
+		return &ret, nil
+	}
+	lock.RUnlock()
+	file, err := serializables.DiscoverGlobalCalculationLinkbase(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	linkbase, err := HydrateCalculationLinkbase(file, urlStr)
+	if err != nil {
+		return nil, err
+	}
+	linkbase.RoleRefs = globalRoleRefs(urlStr, linkbase.RoleRefs)
+	for i, link := range linkbase.CalculationLinks {
+		linkbase.CalculationLinks[i].Locs = globalLocs(urlStr, link.Locs)
+		for j, arc := range link.CalculationArcs {
+			link.CalculationArcs[j].From = globalHref(urlStr, arc.From)
+			link.CalculationArcs[j].To = globalHref(urlStr, arc.To)
+		}
+	}
+	go func() {
+		lock.Lock()
+		defer lock.Unlock()
+		globalCache.Set(urlStr, *linkbase, gocache.DefaultExpiration)
+	}()
+	return linkbase, err
+}
+
+// globalHref resolves an href of a global linkbase, such as
+// ../elts/us-gaap-2019-01-31.xsd#us-gaap_Assets, against the linkbase's URL
+func globalHref(base string, href string) string {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return href
+	}
+	ref, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+	return baseURL.ResolveReference(ref).String()
+}
+
+func globalLocs(base string, locs []Loc) []Loc {
+	ret := make([]Loc, 0, len(locs))
+	for _, loc := range locs {
+		ret = append(ret, Loc{
+			Href:  globalHref(base, loc.Href),
+			Label: globalHref(base, loc.Label),
+		})
+	}
+	return ret
+}
+
+func globalRoleRefs(base string, roleRefs []RoleRef) []RoleRef {
+	ret := make([]RoleRef, 0, len(roleRefs))
+	for _, roleRef := range roleRefs {
+		roleRef.Href = globalHref(base, roleRef.Href)
+		ret = append(ret, roleRef)
+	}
+	return ret
+}
+
 func HydrateFundamentalSchema() (*Schema, error) {
 	return HydrateGlobalSchema(attr.LRR)
 }
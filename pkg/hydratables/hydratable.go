@@ -14,6 +14,9 @@ type Hydratable struct {
 	PresentationLinkbases map[string]PresentationLinkbase
 	DefinitionLinkbases   map[string]DefinitionLinkbase
 	CalculationLinkbases  map[string]CalculationLinkbase
+	ReferenceLinkbases    map[string]ReferenceLinkbase
+	TableLinkbases        map[string]TableLinkbase
+	BaseSets              BaseSets
 }
 
 func Hydrate(folder *serializables.Folder) (*Hydratable, error) {
@@ -25,6 +28,8 @@ func Hydrate(folder *serializables.Folder) (*Hydratable, error) {
 		PresentationLinkbases: make(map[string]PresentationLinkbase),
 		DefinitionLinkbases:   make(map[string]DefinitionLinkbase),
 		CalculationLinkbases:  make(map[string]CalculationLinkbase),
+		ReferenceLinkbases:    make(map[string]ReferenceLinkbase),
+		TableLinkbases:        make(map[string]TableLinkbase),
 	}
 	for filename, file := range folder.Schemas {
 		entry, err := HydrateSchema(&file, filename)
@@ -54,6 +59,7 @@ func Hydrate(folder *serializables.Folder) (*Hydratable, error) {
 		}
 		ret.CalculationLinkbases[filename] = *entry
 	}
+	ret.BaseSets = hydrateBaseSets(ret)
 	for filename, file := range folder.LabelLinkbases {
 		entry, err := HydrateLabelLinkbase(&file, filename)
 		if err != nil {
@@ -61,6 +67,20 @@ func Hydrate(folder *serializables.Folder) (*Hydratable, error) {
 		}
 		ret.LabelLinkbases[filename] = *entry
 	}
+	for filename, file := range folder.ReferenceLinkbases {
+		entry, err := HydrateReferenceLinkbase(&file, filename)
+		if err != nil {
+			return nil, err
+		}
+		ret.ReferenceLinkbases[filename] = *entry
+	}
+	for filename, file := range folder.TableLinkbases {
+		entry, err := HydrateTableLinkbase(&file, filename)
+		if err != nil {
+			return nil, err
+		}
+		ret.TableLinkbases[filename] = *entry
+	}
 	for filename, file := range folder.Instances {
 		entry, err := HydrateInstance(&file, filename, ret)
 		if err != nil {
@@ -43,12 +43,9 @@ type TypedMember struct {
 }
 
 type Context struct {
-	ID     string
-	Entity Entity
-	Period struct {
-		Instant  Instant
-		Duration Duration
-	}
+	ID       string
+	Entity   Entity
+	Period   Period
 	Scenario DimensionContext
 }
 
@@ -71,6 +68,12 @@ type TypedDomainArc struct {
 	To    string
 }
 
+type Period struct {
+	Instant  Instant
+	Duration Duration
+	Forever  bool
+}
+
 type Instant struct {
 	CharData string
 }
@@ -230,17 +233,20 @@ func hydrateContexts(instanceFile *serializables.InstanceFile, h *Hydratable) []
 		if len(context.Period) > 0 {
 			if len(context.Period[0].Instant) > 0 {
 				instant := Instant{
-					CharData: context.Period[0].Instant[0].CharData,
+					CharData: strings.TrimSpace(context.Period[0].Instant[0].CharData),
 				}
 				item.Period.Instant = instant
 			}
 			if len(context.Period[0].StartDate) > 0 && len(context.Period[0].EndDate) > 0 {
 				duration := Duration{
-					StartDate: context.Period[0].StartDate[0].CharData,
-					EndDate:   context.Period[0].EndDate[0].CharData,
+					StartDate: strings.TrimSpace(context.Period[0].StartDate[0].CharData),
+					EndDate:   strings.TrimSpace(context.Period[0].EndDate[0].CharData),
 				}
 				item.Period.Duration = duration
 			}
+			if len(context.Period[0].Forever) > 0 {
+				item.Period.Forever = true
+			}
 		}
 		if len(context.Scenario) > 0 {
 			scenario := DimensionContext{}
@@ -416,7 +422,15 @@ func hydrateUnits(instanceFile *serializables.InstanceFile) []Unit {
 		if idAttr == nil || idAttr.Value == "" {
 			continue
 		}
-		item := Unit{}
+		toUnitMeasure := func(prefixedName string) UnitMeasure {
+			prefixedName = strings.TrimSpace(prefixedName)
+			return UnitMeasure{
+				XMLName:  attr.Xmlns(instanceFile.XMLAttrs, prefixedName),
+				CharData: prefixedName,
+			}
+		}
+		numerators := make([]UnitMeasure, 0, len(unit.Measure))
+		denominators := make([]UnitMeasure, 0)
 		if len(unit.Measure) <= 0 {
 			if len(unit.Divide) <= 0 {
 				continue
@@ -427,34 +441,18 @@ func hydrateUnits(instanceFile *serializables.InstanceFile) []Unit {
 			if len(unit.Divide[0].UnitDenominator[0].Measure) <= 0 || len(unit.Divide[0].UnitNumerator[0].Measure) <= 0 {
 				continue
 			}
-			numeratorPrefixedName := unit.Divide[0].UnitNumerator[0].Measure[0].CharData
-			numeratorMeasure := UnitMeasure{
-				XMLName:  attr.Xmlns(instanceFile.XMLAttrs, numeratorPrefixedName),
-				CharData: numeratorPrefixedName,
-			}
-			denominatorPrefixedName := unit.Divide[0].UnitDenominator[0].Measure[0].CharData
-			denominatorMeasure := UnitMeasure{
-				XMLName:  attr.Xmlns(instanceFile.XMLAttrs, denominatorPrefixedName),
-				CharData: denominatorPrefixedName,
-			}
-			divide := UnitDivide{
-				UnitNumerator: struct{ Measure UnitMeasure }{
-					Measure: numeratorMeasure,
-				},
-				UnitDenominator: struct{ Measure UnitMeasure }{
-					Measure: denominatorMeasure,
-				},
-			}
-			item.Divide = divide
+			for _, measure := range unit.Divide[0].UnitNumerator[0].Measure {
+				numerators = append(numerators, toUnitMeasure(measure.CharData))
+			}
+			for _, measure := range unit.Divide[0].UnitDenominator[0].Measure {
+				denominators = append(denominators, toUnitMeasure(measure.CharData))
+			}
 		} else {
-			itemPrefixedName := unit.Measure[0].CharData
-			item.Measure = UnitMeasure{
-				XMLName:  attr.Xmlns(instanceFile.XMLAttrs, itemPrefixedName),
-				CharData: itemPrefixedName,
+			for _, measure := range unit.Measure {
+				numerators = append(numerators, toUnitMeasure(measure.CharData))
 			}
 		}
-		item.ID = idAttr.Value
-		ret = append(ret, item)
+		ret = append(ret, NewUnit(idAttr.Value, numerators, denominators))
 	}
 
 	sort.SliceStable(ret, func(i int, j int) bool {
@@ -465,6 +463,7 @@ func hydrateUnits(instanceFile *serializables.InstanceFile) []Unit {
 
 func hydrateFacts(instanceFile *serializables.InstanceFile, h *Hydratable) []Fact {
 	ret := make([]Fact, 0, len(instanceFile.Facts))
+	rootNamespaces := namespaces(instanceFile.XMLAttrs, nil)
 	for _, fact := range instanceFile.Facts {
 		idAttr := attr.FindAttr(fact.XMLAttrs, "id")
 		idVal := ""
@@ -513,6 +512,13 @@ func hydrateFacts(instanceFile *serializables.InstanceFile, h *Hydratable) []Fac
 			IsNil:      nilVal,
 			XMLInner:   fact.XMLInner,
 		}
+		if !nilVal {
+			newFact.Value, newFact.ValueError = ParseValue(fact.XMLInner, factConcept.valueType(),
+				namespaces(fact.XMLAttrs, rootNamespaces))
+			if newFact.Value != nil && factConcept.IsEnumeration() {
+				newFact.Enumerations, newFact.ValueError = h.validateEnumeration(factConcept, newFact.Value)
+			}
+		}
 		ret = append(ret, newFact)
 	}
 	sort.SliceStable(ret, func(i int, j int) bool {
@@ -162,3 +162,27 @@ func hydrateLabelLink(linkbaseFile *serializables.LabelLinkbaseFile) []LabelLink
 	}
 	return ret
 }
+
+func (h *Hydratable) FindLabels(href string) []LabelLinkLabel {
+	ret := make([]LabelLinkLabel, 0)
+	for _, labels := range h.LabelLinkbases {
+		for _, labelLink := range labels.LabelLink {
+			for _, loc := range labelLink.Locs {
+				if loc.Href != href {
+					continue
+				}
+				for _, labelArc := range labelLink.LabelArcs {
+					if labelArc.From != loc.Label || labelArc.Arcrole != attr.LabelArcrole {
+						continue
+					}
+					for _, labelLinkLabel := range labelLink.Labels {
+						if labelLinkLabel.Label == labelArc.To {
+							ret = append(ret, labelLinkLabel)
+						}
+					}
+				}
+			}
+		}
+	}
+	return append(ret, h.FindGenericLabels(href)...)
+}
@@ -1,5 +1,13 @@
 package hydratables
 
+import (
+	"encoding/xml"
+	"math"
+	"strconv"
+
+	"ecksbee.com/telefacts/pkg/attr"
+)
+
 type RoleRef struct {
 	RoleURI string
 	Href    string
@@ -9,3 +17,58 @@ type Loc struct {
 	Href  string
 	Label string
 }
+
+func arcOrder(attrs []xml.Attr) float64 {
+	orderAttr := attr.FindAttr(attrs, "order")
+	if orderAttr == nil || orderAttr.Value == "" {
+		return 1
+	}
+	order, err := strconv.ParseFloat(orderAttr.Value, 64)
+	if err != nil {
+		return math.MaxFloat64
+	}
+	return order
+}
+
+func arcUse(attrs []xml.Attr) (int, bool) {
+	priority := 0
+	priorityAttr := attr.FindAttr(attrs, "priority")
+	if priorityAttr != nil {
+		v, err := strconv.Atoi(priorityAttr.Value)
+		if err == nil {
+			priority = v
+		}
+	}
+	useAttr := attr.FindAttr(attrs, "use")
+	return priority, useAttr != nil && useAttr.Value == "prohibited"
+}
+
+// locatorHrefs maps the locator labels of an extended link to the hrefs they
+// locate; several locators may share a label
+func locatorHrefs(locs []Loc) map[string][]string {
+	ret := make(map[string][]string)
+	for _, loc := range locs {
+		ret[loc.Label] = append(ret[loc.Label], loc.Href)
+	}
+	return ret
+}
+
+// appendHrefLocs adds locators labelled by their hrefs, so that the arcs of
+// extended links from different files can be merged into one
+func appendHrefLocs(dst []Loc, locs []Loc) []Loc {
+	occured := make(map[string]bool, len(dst))
+	for _, loc := range dst {
+		occured[loc.Href] = true
+	}
+	for _, loc := range locs {
+		if occured[loc.Href] {
+			continue
+		}
+		occured[loc.Href] = true
+		dst = append(dst, Loc{
+			Href:  loc.Href,
+			Label: loc.Href,
+		})
+	}
+	return dst
+}
@@ -9,10 +9,12 @@ import (
 	gocache "github.com/patrickmn/go-cache"
 )
 
+// Unit is a product of measures, with the measures of the divisor held as
+// Denominators. Both products are kept sorted by measure name.
 type Unit struct {
-	ID      string
-	Measure UnitMeasure
-	Divide  UnitDivide
+	ID           string
+	Numerators   []UnitMeasure
+	Denominators []UnitMeasure
 }
 
 type UnitMeasure struct {
@@ -20,15 +22,6 @@ type UnitMeasure struct {
 	CharData string
 }
 
-type UnitDivide struct {
-	UnitNumerator struct {
-		Measure UnitMeasure
-	}
-	UnitDenominator struct {
-		Measure UnitMeasure
-	}
-}
-
 type Measurement struct {
 	UnitID                 string
 	UnitName               string
@@ -159,23 +152,42 @@ func queryUTR(namespace string, id string) *Measurement {
 	return nil
 }
 
-func (h *Hydratable) FindMeasurement(unitRef string) (*Measurement, *Measurement) {
+func measurements(measures []UnitMeasure) []Measurement {
+	ret := make([]Measurement, 0, len(measures))
+	for _, measure := range measures {
+		found := queryUTR(measure.XMLName.Space, measure.XMLName.Local)
+		if found != nil {
+			ret = append(ret, *found)
+			continue
+		}
+		ret = append(ret, Measurement{
+			UnitID:   measure.XMLName.Local,
+			UnitName: measure.XMLName.Local,
+			NSUnit:   measure.XMLName.Space,
+		})
+	}
+	return ret
+}
+
+func (h *Hydratable) FindUnit(unitRef string) *Unit {
 	for _, ins := range h.Instances {
 		for _, unit := range ins.Units {
 			if unit.ID == unitRef {
-				if unit.Measure.CharData == "" {
-					if unit.Divide.UnitNumerator.Measure.CharData == "" ||
-						unit.Divide.UnitDenominator.Measure.CharData == "" {
-						return nil, nil
-					}
-					return queryUTR(unit.Divide.UnitNumerator.Measure.XMLName.Space,
-							unit.Divide.UnitNumerator.Measure.XMLName.Local),
-						queryUTR(unit.Divide.UnitDenominator.Measure.XMLName.Space,
-							unit.Divide.UnitNumerator.Measure.XMLName.Local)
-				}
-				return queryUTR(unit.Measure.XMLName.Space, unit.Measure.XMLName.Local), nil
+				return &unit
 			}
 		}
 	}
-	return nil, nil
+	return nil
+}
+
+// FindMeasurement returns the UTR entries of the numerator and denominator
+// measures of the simplified unit. Measures missing from the UTR are returned
+// with their local name as UnitName.
+func (h *Hydratable) FindMeasurement(unitRef string) ([]Measurement, []Measurement) {
+	unit := h.FindUnit(unitRef)
+	if unit == nil {
+		return nil, nil
+	}
+	simplified := unit.Simplify()
+	return measurements(simplified.Numerators), measurements(simplified.Denominators)
 }
@@ -0,0 +1,233 @@
+package hydratables
+
+import (
+	"sort"
+)
+
+// Relationship is an effective arc of the base sets between two concept hrefs
+type Relationship struct {
+	LinkRole       string
+	Arcrole        string
+	From           string
+	To             string
+	Order          float64
+	PreferredLabel string
+	Weight         float64
+	Closed         bool
+	Usable         bool
+	ContextElement string
+	TargetRole     string
+}
+
+// RelationshipNetwork is the network of the relationships of an arcrole in a
+// link role, navigable by concept href
+type RelationshipNetwork struct {
+	LinkRole      string
+	Arcrole       string
+	Relationships []Relationship
+	outgoing      map[string][]Relationship
+	incoming      map[string][]Relationship
+}
+
+// RelationshipNetwork resolves the network of an arcrole in a link role from
+// the presentation, definition and calculation base sets
+func (h *Hydratable) RelationshipNetwork(linkrole string, arcrole string) *RelationshipNetwork {
+	ret := RelationshipNetwork{
+		LinkRole:      linkrole,
+		Arcrole:       arcrole,
+		Relationships: make([]Relationship, 0),
+		outgoing:      map[string][]Relationship{},
+		incoming:      map[string][]Relationship{},
+	}
+	for _, relationship := range h.relationships() {
+		if relationship.LinkRole != linkrole || relationship.Arcrole != arcrole {
+			continue
+		}
+		ret.Relationships = append(ret.Relationships, relationship)
+		ret.outgoing[relationship.From] = append(ret.outgoing[relationship.From], relationship)
+		ret.incoming[relationship.To] = append(ret.incoming[relationship.To], relationship)
+	}
+	byOrder := func(relationships []Relationship) {
+		sort.SliceStable(relationships, func(p, q int) bool {
+			return relationships[p].Order < relationships[q].Order
+		})
+	}
+	byOrder(ret.Relationships)
+	for _, relationships := range ret.outgoing {
+		byOrder(relationships)
+	}
+	for _, relationships := range ret.incoming {
+		byOrder(relationships)
+	}
+	return &ret
+}
+
+// LinkRoles lists the link roles with relationships of the arcrole that the
+// concept is a source or target of; an empty arcrole matches any arcrole
+func (h *Hydratable) LinkRoles(href string, arcrole string) []string {
+	occured := map[string]bool{}
+	ret := make([]string, 0)
+	for _, relationship := range h.relationships() {
+		if arcrole != "" && relationship.Arcrole != arcrole {
+			continue
+		}
+		if relationship.From != href && relationship.To != href {
+			continue
+		}
+		if occured[relationship.LinkRole] {
+			continue
+		}
+		occured[relationship.LinkRole] = true
+		ret = append(ret, relationship.LinkRole)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func (h *Hydratable) relationships() []Relationship {
+	ret := make([]Relationship, 0)
+	for _, link := range h.BaseSets.Presentation.PresentationLinks {
+		for _, arc := range link.PresentationArcs {
+			ret = append(ret, Relationship{
+				LinkRole:       link.Role,
+				Arcrole:        arc.Arcrole,
+				From:           arc.From,
+				To:             arc.To,
+				Order:          arc.Order,
+				PreferredLabel: arc.PreferredLabel,
+			})
+		}
+	}
+	for _, link := range h.BaseSets.Definition.DefinitionLinks {
+		for _, arc := range link.DefinitionArcs {
+			ret = append(ret, Relationship{
+				LinkRole:       link.Role,
+				Arcrole:        arc.Arcrole,
+				From:           arc.From,
+				To:             arc.To,
+				Order:          arc.Order,
+				Closed:         arc.Closed,
+				Usable:         arc.Usable,
+				ContextElement: arc.ContextElement,
+				TargetRole:     arc.TargetRole,
+			})
+		}
+	}
+	for _, link := range h.BaseSets.Calculation.CalculationLinks {
+		for _, arc := range link.CalculationArcs {
+			ret = append(ret, Relationship{
+				LinkRole: link.Role,
+				Arcrole:  arc.Arcrole,
+				From:     arc.From,
+				To:       arc.To,
+				Order:    arc.Order,
+				Weight:   arc.Weight,
+			})
+		}
+	}
+	return ret
+}
+
+// Roots lists the sources that are not the target of any relationship, in
+// the order of their first relationship
+func (network *RelationshipNetwork) Roots() []string {
+	occured := map[string]bool{}
+	ret := make([]string, 0)
+	for _, relationship := range network.Relationships {
+		if len(network.incoming[relationship.From]) > 0 || occured[relationship.From] {
+			continue
+		}
+		occured[relationship.From] = true
+		ret = append(ret, relationship.From)
+	}
+	return ret
+}
+
+func (network *RelationshipNetwork) Parents(href string) []Relationship {
+	return network.incoming[href]
+}
+
+func (network *RelationshipNetwork) Children(href string) []Relationship {
+	return network.outgoing[href]
+}
+
+// Ancestors lists the concepts the href descends from, nearest first
+func (network *RelationshipNetwork) Ancestors(href string) []string {
+	ret := make([]string, 0)
+	visited := map[string]bool{
+		href: true,
+	}
+	queue := []string{href}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, relationship := range network.incoming[node] {
+			if visited[relationship.From] {
+				continue
+			}
+			visited[relationship.From] = true
+			ret = append(ret, relationship.From)
+			queue = append(queue, relationship.From)
+		}
+	}
+	return ret
+}
+
+// Descendants lists the concepts that descend from the href, depth first in
+// arc order
+func (network *RelationshipNetwork) Descendants(href string) []string {
+	ret := make([]string, 0)
+	visited := map[string]bool{
+		href: true,
+	}
+	var descend func(node string)
+	descend = func(node string) {
+		for _, relationship := range network.outgoing[node] {
+			if visited[relationship.To] {
+				continue
+			}
+			visited[relationship.To] = true
+			ret = append(ret, relationship.To)
+			descend(relationship.To)
+		}
+	}
+	descend(href)
+	return ret
+}
+
+// Siblings lists the other children of the parents of the href, in arc order
+func (network *RelationshipNetwork) Siblings(href string) []string {
+	occured := map[string]bool{
+		href: true,
+	}
+	ret := make([]string, 0)
+	for _, parent := range network.incoming[href] {
+		for _, relationship := range network.outgoing[parent.From] {
+			if occured[relationship.To] {
+				continue
+			}
+			occured[relationship.To] = true
+			ret = append(ret, relationship.To)
+		}
+	}
+	return ret
+}
+
+// PathToRoot follows the first parent of each concept from the href up to a
+// root, starting with the href itself
+func (network *RelationshipNetwork) PathToRoot(href string) []string {
+	ret := []string{href}
+	visited := map[string]bool{
+		href: true,
+	}
+	node := href
+	for len(network.incoming[node]) > 0 {
+		node = network.incoming[node][0].From
+		if visited[node] {
+			break
+		}
+		visited[node] = true
+		ret = append(ret, node)
+	}
+	return ret
+}
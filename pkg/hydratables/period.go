@@ -0,0 +1,67 @@
+package hydratables
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02Z07:00",
+}
+
+var dateTimeLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999Z07:00",
+}
+
+// ParsePeriodDate parses an xs:date or xs:dateTime period value. Per XBRL 2.1,
+// a date-only instant or end date denotes the end of that day, which is the
+// midnight that starts the following day. The boolean reports whether the
+// value was date-only.
+func ParsePeriodDate(charData string, isEnd bool) (time.Time, bool, error) {
+	value := strings.TrimSpace(charData)
+	if strings.ContainsRune(value, 'T') {
+		for _, layout := range dateTimeLayouts {
+			t, err := time.Parse(layout, value)
+			if err == nil {
+				return t, false, nil
+			}
+		}
+		return time.Time{}, false, fmt.Errorf("invalid period dateTime %s", value)
+	}
+	for _, layout := range dateLayouts {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			if isEnd {
+				t = t.AddDate(0, 0, 1)
+			}
+			return t, true, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("invalid period date %s", value)
+}
+
+func (instant Instant) Time() (time.Time, error) {
+	t, _, err := ParsePeriodDate(instant.CharData, true)
+	return t, err
+}
+
+func (duration Duration) Start() (time.Time, error) {
+	t, _, err := ParsePeriodDate(duration.StartDate, false)
+	return t, err
+}
+
+func (duration Duration) End() (time.Time, error) {
+	t, _, err := ParsePeriodDate(duration.EndDate, true)
+	return t, err
+}
+
+func (period Period) IsInstant() bool {
+	return period.Instant.CharData != ""
+}
+
+func (period Period) IsDuration() bool {
+	return period.Duration.StartDate != "" && period.Duration.EndDate != ""
+}
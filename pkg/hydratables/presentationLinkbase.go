@@ -2,8 +2,6 @@ package hydratables
 
 import (
 	"fmt"
-	"math"
-	"strconv"
 
 	"ecksbee.com/telefacts/pkg/attr"
 	"ecksbee.com/telefacts/pkg/serializables"
@@ -15,6 +13,8 @@ type PresentationArc struct {
 	From           string
 	To             string
 	PreferredLabel string
+	Priority       int
+	Prohibited     bool
 }
 
 type PresentationLink struct {
@@ -108,10 +108,6 @@ func hydratePresentationLink(linkbaseFile *serializables.PresentationLinkbaseFil
 			if ttypeAttr == nil || ttypeAttr.Name.Space != attr.XLINK || ttypeAttr.Value != "arc" {
 				continue
 			}
-			orderAttr := attr.FindAttr(arc.XMLAttrs, "order")
-			if orderAttr == nil || orderAttr.Value == "" {
-				continue
-			}
 			arcroleAttr := attr.FindAttr(arc.XMLAttrs, "arcrole")
 			if arcroleAttr == nil || arcroleAttr.Name.Space != attr.XLINK || arcroleAttr.Value == "" {
 				continue
@@ -125,17 +121,14 @@ func hydratePresentationLink(linkbaseFile *serializables.PresentationLinkbaseFil
 				continue
 			}
 			newArc.Arcrole = arcroleAttr.Value
-			order, err := strconv.ParseFloat(orderAttr.Value, 64)
-			if err != nil {
-				order = math.MaxFloat64
-			}
-			newArc.Order = order
+			newArc.Order = arcOrder(arc.XMLAttrs)
 			preferredLabelAttr := attr.FindAttr(arc.XMLAttrs, "preferredLabel")
 			if preferredLabelAttr != nil {
 				newArc.PreferredLabel = preferredLabelAttr.Value
 			}
 			newArc.From = fromAttr.Value
 			newArc.To = toAttr.Value
+			newArc.Priority, newArc.Prohibited = arcUse(arc.XMLAttrs)
 			newLink.PresentationArcs = append(newLink.PresentationArcs, newArc)
 		}
 		ret = append(ret, newLink)
@@ -143,20 +136,36 @@ func hydratePresentationLink(linkbaseFile *serializables.PresentationLinkbaseFil
 	return dedupPresentationLink(ret)
 }
 
+// dedupPresentationLink merges the extended links of a role, with locators
+// labelled by href; prohibited arcs are kept for base set resolution
 func dedupPresentationLink(links []PresentationLink) []PresentationLink {
 	occured := map[string]PresentationLink{}
+	roles := make([]string, 0)
 	for _, link := range links {
-		if merged, found := occured[link.Role]; found {
-			merged.Locs = append(merged.Locs, link.Locs...)
-			merged.PresentationArcs = append(merged.PresentationArcs, link.PresentationArcs...)
-			occured[link.Role] = merged
-		} else {
-			occured[link.Role] = link
+		merged, found := occured[link.Role]
+		if !found {
+			merged = PresentationLink{
+				Role: link.Role,
+			}
+			roles = append(roles, link.Role)
+		}
+		hrefs := locatorHrefs(link.Locs)
+		for _, arc := range link.PresentationArcs {
+			for _, from := range hrefs[arc.From] {
+				for _, to := range hrefs[arc.To] {
+					resolved := arc
+					resolved.From = from
+					resolved.To = to
+					merged.PresentationArcs = append(merged.PresentationArcs, resolved)
+				}
+			}
 		}
+		merged.Locs = appendHrefLocs(merged.Locs, link.Locs)
+		occured[link.Role] = merged
 	}
-	ret := make([]PresentationLink, 0)
-	for _, r := range occured {
-		ret = append(ret, r)
+	ret := make([]PresentationLink, 0, len(roles))
+	for _, role := range roles {
+		ret = append(ret, occured[role])
 	}
 	return ret
 }
@@ -43,6 +43,7 @@ func (h *Hydratable) HashQuery(query string) (string, *Concept, error) {
 	}
 	for _, candidate := range concepts {
 		if fragment == candidate.ID {
+			candidate.TypeHierarchy = h.typeHierarchy(candidate.Type)
 			return namespace, &candidate, nil
 		}
 	}
@@ -72,6 +73,7 @@ func (h *Hydratable) NameQuery(namespace string, localName string) (string, *Con
 	}
 	for _, candidate := range concepts {
 		if localName == candidate.XMLName.Local && namespace == candidate.XMLName.Space {
+			candidate.TypeHierarchy = h.typeHierarchy(candidate.Type)
 			return schemaLoc + "#" + candidate.ID, &candidate, nil
 		}
 	}
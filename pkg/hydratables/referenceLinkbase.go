@@ -0,0 +1,188 @@
+package hydratables
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+type ReferencePart struct {
+	XMLName  xml.Name
+	CharData string
+}
+
+type Reference struct {
+	Label string
+	Role  string
+	Parts []ReferencePart
+}
+
+type ReferenceArc struct {
+	Order   float64
+	Arcrole string
+	From    string
+	To      string
+}
+
+type ReferenceLink struct {
+	Role          string
+	Locs          []Loc
+	References    []Reference
+	ReferenceArcs []ReferenceArc
+}
+
+type ReferenceLinkbase struct {
+	FileName       string
+	RoleRefs       []RoleRef
+	ReferenceLinks []ReferenceLink
+}
+
+func HydrateReferenceLinkbase(file *serializables.ReferenceLinkbaseFile, fileName string) (*ReferenceLinkbase, error) {
+	if len(fileName) <= 0 {
+		return nil, fmt.Errorf("empty file name")
+	}
+	if file == nil {
+		return nil, fmt.Errorf("empty file")
+	}
+	ret := ReferenceLinkbase{}
+	ret.FileName = fileName
+	ret.RoleRefs = hydrateReferenceLinkbaseRoleRefs(file)
+	ret.ReferenceLinks = hydrateReferenceLink(file)
+	return &ret, nil
+}
+
+func hydrateReferenceLinkbaseRoleRefs(linkbaseFile *serializables.ReferenceLinkbaseFile) []RoleRef {
+	ret := make([]RoleRef, 0, len(linkbaseFile.RoleRef))
+	for _, roleRef := range linkbaseFile.RoleRef {
+		if roleRef.XMLName.Space != attr.LINK {
+			continue
+		}
+		roleURIAttr := attr.FindAttr(roleRef.XMLAttrs, "roleURI")
+		if roleURIAttr == nil || roleURIAttr.Value == "" {
+			continue
+		}
+		hrefAttr := attr.FindAttr(roleRef.XMLAttrs, "href")
+		if hrefAttr == nil || hrefAttr.Value == "" {
+			continue
+		}
+		if hrefAttr.Name.Space != attr.XLINK {
+			continue
+		}
+		newRoleRef := RoleRef{
+			RoleURI: roleURIAttr.Value,
+			Href:    hrefAttr.Value,
+		}
+		ret = append(ret, newRoleRef)
+	}
+	return ret
+}
+
+func hydrateReferenceLink(linkbaseFile *serializables.ReferenceLinkbaseFile) []ReferenceLink {
+	ret := make([]ReferenceLink, 0, len(linkbaseFile.ReferenceLink))
+	for _, link := range linkbaseFile.ReferenceLink {
+		typeAttr := attr.FindAttr(link.XMLAttrs, "type")
+		if typeAttr == nil || typeAttr.Name.Space != attr.XLINK || typeAttr.Value != "extended" {
+			continue
+		}
+		roleAttr := attr.FindAttr(link.XMLAttrs, "role")
+		if roleAttr == nil || roleAttr.Value == "" {
+			continue
+		}
+		newLink := ReferenceLink{}
+		newLink.Role = roleAttr.Value
+		newLink.Locs = make([]Loc, 0, len(link.Loc))
+		for _, loc := range link.Loc {
+			newLoc := Loc{}
+			ttypeAttr := attr.FindAttr(loc.XMLAttrs, "type")
+			if ttypeAttr == nil || ttypeAttr.Name.Space != attr.XLINK || ttypeAttr.Value != "locator" {
+				continue
+			}
+			labelAttr := attr.FindAttr(loc.XMLAttrs, "label")
+			if labelAttr == nil || labelAttr.Name.Space != attr.XLINK || labelAttr.Value == "" {
+				continue
+			}
+			hrefAttr := attr.FindAttr(loc.XMLAttrs, "href")
+			if hrefAttr == nil || hrefAttr.Value == "" {
+				continue
+			}
+			newLoc.Href = hrefAttr.Value
+			newLoc.Label = labelAttr.Value
+			newLink.Locs = append(newLink.Locs, newLoc)
+		}
+		newLink.ReferenceArcs = make([]ReferenceArc, 0, len(link.ReferenceArc))
+		for _, arc := range link.ReferenceArc {
+			newArc := ReferenceArc{}
+			ttypeAttr := attr.FindAttr(arc.XMLAttrs, "type")
+			if ttypeAttr == nil || ttypeAttr.Name.Space != attr.XLINK || ttypeAttr.Value != "arc" {
+				continue
+			}
+			arcroleAttr := attr.FindAttr(arc.XMLAttrs, "arcrole")
+			if arcroleAttr == nil || arcroleAttr.Name.Space != attr.XLINK || arcroleAttr.Value == "" {
+				continue
+			}
+			fromAttr := attr.FindAttr(arc.XMLAttrs, "from")
+			if fromAttr == nil || fromAttr.Name.Space != attr.XLINK || fromAttr.Value == "" {
+				continue
+			}
+			toAttr := attr.FindAttr(arc.XMLAttrs, "to")
+			if toAttr == nil || toAttr.Name.Space != attr.XLINK || toAttr.Value == "" {
+				continue
+			}
+			newArc.Arcrole = arcroleAttr.Value
+			newArc.Order = arcOrder(arc.XMLAttrs)
+			newArc.From = fromAttr.Value
+			newArc.To = toAttr.Value
+			newLink.ReferenceArcs = append(newLink.ReferenceArcs, newArc)
+		}
+		newLink.References = make([]Reference, 0, len(link.Reference))
+		for _, reference := range link.Reference {
+			newReference := Reference{}
+			labelAttr := attr.FindAttr(reference.XMLAttrs, "label")
+			if labelAttr == nil || labelAttr.Value == "" || labelAttr.Name.Space != attr.XLINK {
+				continue
+			}
+			newReference.Label = labelAttr.Value
+			roleAttr := attr.FindAttr(reference.XMLAttrs, "role")
+			if roleAttr != nil && roleAttr.Name.Space == attr.XLINK {
+				newReference.Role = roleAttr.Value
+			}
+			newReference.Parts = make([]ReferencePart, 0, len(reference.Part))
+			for _, part := range reference.Part {
+				newReference.Parts = append(newReference.Parts, ReferencePart{
+					XMLName:  part.XMLName,
+					CharData: strings.TrimSpace(part.CharData),
+				})
+			}
+			newLink.References = append(newLink.References, newReference)
+		}
+		ret = append(ret, newLink)
+	}
+	return ret
+}
+
+func (h *Hydratable) FindReferences(href string) []Reference {
+	ret := make([]Reference, 0)
+	for _, references := range h.ReferenceLinkbases {
+		for _, referenceLink := range references.ReferenceLinks {
+			for _, loc := range referenceLink.Locs {
+				if loc.Href != href {
+					continue
+				}
+				for _, referenceArc := range referenceLink.ReferenceArcs {
+					if referenceArc.From != loc.Label || referenceArc.Arcrole != attr.ReferenceArcrole {
+						continue
+					}
+					for _, reference := range referenceLink.References {
+						if reference.Label == referenceArc.To {
+							ret = append(ret, reference)
+						}
+					}
+				}
+			}
+		}
+	}
+	return ret
+}
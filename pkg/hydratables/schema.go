@@ -22,12 +22,26 @@ type Concept struct {
 	Balance           string
 	Abstract          bool
 	TypedDomainHref   string
+	EnumDomain        xml.Name
+	EnumLinkrole      string
+	EnumHeadUsable    bool
+	TypeHierarchy     []xml.Name
 }
 
 type Schema struct {
 	FileName string
 	Annotation
-	Element []Concept
+	Element      []Concept
+	Types        []TypeDefinition
+	Imports      []string
+	LinkbaseRefs map[string]string
+}
+
+// TypeDefinition is a named complexType or simpleType and the type it
+// restricts or extends
+type TypeDefinition struct {
+	XMLName xml.Name
+	Base    xml.Name
 }
 
 type Annotation struct {
@@ -58,9 +72,75 @@ func HydrateSchema(file *serializables.SchemaFile, fileName string) (*Schema, er
 	ret.FileName = fileName
 	ret.Annotation = hydrateAnnotation(file)
 	ret.Element = hydrateConcepts(file, fileName)
+	ret.Types = hydrateTypes(file)
+	ret.Imports = hydrateImports(file)
+	ret.LinkbaseRefs = hydrateLinkbaseRefs(file)
 	return &ret, nil
 }
 
+// hydrateImports lists the schemaLocations of the schemas imported or
+// included by the schema
+func hydrateImports(file *serializables.SchemaFile) []string {
+	ret := make([]string, 0, len(file.Import)+len(file.Include))
+	for _, item := range file.Import {
+		if item.XMLName.Space != attr.XSD {
+			continue
+		}
+		schemaLocationAttr := attr.FindAttr(item.XMLAttrs, "schemaLocation")
+		if schemaLocationAttr == nil || schemaLocationAttr.Value == "" {
+			continue
+		}
+		ret = append(ret, schemaLocationAttr.Value)
+	}
+	for _, item := range file.Include {
+		if item.XMLName.Space != attr.XSD {
+			continue
+		}
+		schemaLocationAttr := attr.FindAttr(item.XMLAttrs, "schemaLocation")
+		if schemaLocationAttr == nil || schemaLocationAttr.Value == "" {
+			continue
+		}
+		ret = append(ret, schemaLocationAttr.Value)
+	}
+	return ret
+}
+
+// hydrateLinkbaseRefs maps the hrefs of the linkbaseRefs of the schema to
+// their roles
+func hydrateLinkbaseRefs(file *serializables.SchemaFile) map[string]string {
+	ret := make(map[string]string)
+	for _, annotation := range file.Annotation {
+		if annotation.XMLName.Space != attr.XSD {
+			continue
+		}
+		for _, appinfo := range annotation.Appinfo {
+			if appinfo.XMLName.Space != attr.XSD {
+				continue
+			}
+			for _, item := range appinfo.LinkbaseRef {
+				if item.XMLName.Space != attr.LINK {
+					continue
+				}
+				arcroleAttr := attr.FindAttr(item.XMLAttrs, "arcrole")
+				if arcroleAttr == nil || arcroleAttr.Name.Space != attr.XLINK || arcroleAttr.Value != attr.LINKARCROLE {
+					continue
+				}
+				hrefAttr := attr.FindAttr(item.XMLAttrs, "href")
+				if hrefAttr == nil || hrefAttr.Name.Space != attr.XLINK || hrefAttr.Value == "" {
+					continue
+				}
+				role := ""
+				roleAttr := attr.FindAttr(item.XMLAttrs, "role")
+				if roleAttr != nil && roleAttr.Name.Space == attr.XLINK {
+					role = roleAttr.Value
+				}
+				ret[hrefAttr.Value] = role
+			}
+		}
+	}
+	return ret
+}
+
 func hydrateTargetNamespace(file *serializables.SchemaFile) string {
 	attr := attr.FindAttr(file.XMLAttrs, "targetNamespace")
 	if attr == nil {
@@ -198,6 +278,25 @@ func hydrateConcepts(file *serializables.SchemaFile, fileName string) []Concept
 			}
 			typedDomainHref += typedDomainRefAttr.Value
 		}
+		enumDomain := xml.Name{}
+		enumLinkrole := ""
+		enumHeadUsable := false
+		for _, a := range element.XMLAttrs {
+			if a.Name.Space != attr.ENUM && a.Name.Space != attr.ENUM2 {
+				continue
+			}
+			switch a.Name.Local {
+			case "domain":
+				enumDomain = attr.Xmlns(tlAttrs, a.Value)
+			case "linkrole":
+				enumLinkrole = a.Value
+			case "headUsable":
+				v, err := strconv.ParseBool(a.Value)
+				if err == nil {
+					enumHeadUsable = v
+				}
+			}
+		}
 		ret = append(ret, Concept{
 			XMLName: xml.Name{
 				Space: targetNS,
@@ -211,11 +310,62 @@ func hydrateConcepts(file *serializables.SchemaFile, fileName string) []Concept
 			Nillable:          isNillable,
 			PeriodType:        periodType,
 			TypedDomainHref:   typedDomainHref,
+			EnumDomain:        enumDomain,
+			EnumLinkrole:      enumLinkrole,
+			EnumHeadUsable:    enumHeadUsable,
 		})
 	}
 	return ret
 }
 
+func hydrateTypes(file *serializables.SchemaFile) []TypeDefinition {
+	ret := make([]TypeDefinition, 0, len(file.ComplexType)+len(file.SimpleType))
+	tlAttrs := file.XMLAttrs
+	targetNS := hydrateTargetNamespace(file)
+	appendType := func(nameAttrs []xml.Attr, baseAttrs []xml.Attr) {
+		nameAttr := attr.FindAttr(nameAttrs, "name")
+		if nameAttr == nil || nameAttr.Value == "" {
+			return
+		}
+		baseAttr := attr.FindAttr(baseAttrs, "base")
+		if baseAttr == nil || baseAttr.Value == "" {
+			return
+		}
+		scope := append(append([]xml.Attr{}, nameAttrs...), tlAttrs...)
+		ret = append(ret, TypeDefinition{
+			XMLName: xml.Name{
+				Space: targetNS,
+				Local: nameAttr.Value,
+			},
+			Base: attr.Xmlns(scope, baseAttr.Value),
+		})
+	}
+	for _, complexType := range file.ComplexType {
+		for _, content := range complexType.SimpleContent {
+			for _, restriction := range content.Restriction {
+				appendType(complexType.XMLAttrs, restriction.XMLAttrs)
+			}
+			for _, extension := range content.Extension {
+				appendType(complexType.XMLAttrs, extension.XMLAttrs)
+			}
+		}
+		for _, content := range complexType.ComplexContent {
+			for _, restriction := range content.Restriction {
+				appendType(complexType.XMLAttrs, restriction.XMLAttrs)
+			}
+			for _, extension := range content.Extension {
+				appendType(complexType.XMLAttrs, extension.XMLAttrs)
+			}
+		}
+	}
+	for _, simpleType := range file.SimpleType {
+		for _, restriction := range simpleType.Restriction {
+			appendType(simpleType.XMLAttrs, restriction.XMLAttrs)
+		}
+	}
+	return ret
+}
+
 type Stack []*Concept
 
 // IsEmpty: check if stack is empty
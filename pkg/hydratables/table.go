@@ -0,0 +1,301 @@
+package hydratables
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+var xsDate = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+
+// Table is a table of the Table Linkbases, with the breakdowns of its x, y
+// and z axes in order
+type Table struct {
+	Href             string
+	LinkRole         string
+	ParentChildOrder string
+	Breakdowns       map[string][]Breakdown
+}
+
+type Breakdown struct {
+	Href             string
+	ParentChildOrder string
+	Nodes            []DefinitionNode
+}
+
+// DefinitionNode is a rule, concept relationship, dimension relationship or
+// aspect node of a breakdown. The concept, explicit dimensions and period of
+// a rule node are its aspect rules; the QNames of all nodes are resolved to
+// hrefs, with an empty relationship source for xfi:root.
+type DefinitionNode struct {
+	Href                string
+	Kind                string
+	Abstract            bool
+	Merge               bool
+	TagSelector         string
+	Concept             string
+	ExplicitDimensions  map[string]string
+	Period              *Period
+	RelationshipSources []string
+	LinkRole            string
+	Arcrole             string
+	FormulaAxis         string
+	Generations         int
+	Dimension           string
+	Aspect              string
+	Children            []DefinitionNode
+}
+
+// Tables resolves the tables of the Table Linkbases, across files, from the
+// effective table-breakdown, breakdown-tree and definition-node-subtree
+// relationships
+func (h *Hydratable) Tables() []Table {
+	resources := map[string]TableResource{}
+	linkRoles := map[string]string{}
+	candidates := make([]arcCandidate, 0)
+	arcs := make([]TableArc, 0)
+	fileNames := make([]string, 0, len(h.TableLinkbases))
+	for fileName := range h.TableLinkbases {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		for _, link := range h.TableLinkbases[fileName].TableLinks {
+			hrefs := locatorHrefs(link.Locs)
+			for _, resource := range link.Resources {
+				hrefs[resource.Label] = append(hrefs[resource.Label], resource.Href)
+				if _, found := resources[resource.Href]; !found {
+					resources[resource.Href] = resource
+					linkRoles[resource.Href] = link.Role
+				}
+			}
+			for _, arc := range link.TableArcs {
+				for _, from := range hrefs[arc.From] {
+					for _, to := range hrefs[arc.To] {
+						candidates = append(candidates, arcCandidate{
+							key: arcKey{
+								role:    link.Role,
+								arcrole: arc.Arcrole,
+								from:    from,
+								to:      to,
+								attrs:   arc.Axis,
+							},
+							priority:   arc.Priority,
+							prohibited: arc.Prohibited,
+						})
+						arcs = append(arcs, TableArc{
+							Order:   arc.Order,
+							Arcrole: arc.Arcrole,
+							From:    from,
+							To:      to,
+							Axis:    arc.Axis,
+						})
+					}
+				}
+			}
+		}
+	}
+	outgoing := map[string][]TableArc{}
+	for _, i := range effectiveArcs(candidates) {
+		outgoing[arcs[i].From] = append(outgoing[arcs[i].From], arcs[i])
+	}
+	for _, tableArcs := range outgoing {
+		sort.SliceStable(tableArcs, func(p, q int) bool {
+			return tableArcs[p].Order < tableArcs[q].Order
+		})
+	}
+	var nodes func(href string, arcrole string, visited map[string]bool) []DefinitionNode
+	nodes = func(href string, arcrole string, visited map[string]bool) []DefinitionNode {
+		ret := make([]DefinitionNode, 0)
+		for _, arc := range outgoing[href] {
+			resource, found := resources[arc.To]
+			if arc.Arcrole != arcrole || !found || visited[arc.To] {
+				continue
+			}
+			visited[arc.To] = true
+			node := h.definitionNode(resource)
+			node.Children = nodes(arc.To, attr.DefinitionNodeSubtreeArcrole, visited)
+			delete(visited, arc.To)
+			ret = append(ret, node)
+		}
+		return ret
+	}
+	ret := make([]Table, 0)
+	hrefs := make([]string, 0, len(resources))
+	for href := range resources {
+		hrefs = append(hrefs, href)
+	}
+	sort.Strings(hrefs)
+	for _, href := range hrefs {
+		resource := resources[href]
+		if resource.XMLName.Space != attr.TABLE || resource.XMLName.Local != "table" {
+			continue
+		}
+		table := Table{
+			Href:             href,
+			LinkRole:         linkRoles[href],
+			ParentChildOrder: parentChildOrder(resource, "parent-first"),
+			Breakdowns:       map[string][]Breakdown{},
+		}
+		for _, arc := range outgoing[href] {
+			breakdown, found := resources[arc.To]
+			if arc.Arcrole != attr.TableBreakdownArcrole || !found {
+				continue
+			}
+			table.Breakdowns[arc.Axis] = append(table.Breakdowns[arc.Axis], Breakdown{
+				Href:             arc.To,
+				ParentChildOrder: parentChildOrder(breakdown, table.ParentChildOrder),
+				Nodes:            nodes(arc.To, attr.BreakdownTreeArcrole, map[string]bool{}),
+			})
+		}
+		ret = append(ret, table)
+	}
+	return ret
+}
+
+func parentChildOrder(resource TableResource, inherited string) string {
+	if orderAttr := attr.FindAttr(resource.XMLAttrs, "parentChildOrder"); orderAttr != nil && orderAttr.Value != "" {
+		return orderAttr.Value
+	}
+	return inherited
+}
+
+func (h *Hydratable) definitionNode(resource TableResource) DefinitionNode {
+	ret := DefinitionNode{
+		Href:               resource.Href,
+		Kind:               resource.XMLName.Local,
+		ExplicitDimensions: map[string]string{},
+	}
+	for _, name := range []string{"abstract", "merge"} {
+		boolAttr := attr.FindAttr(resource.XMLAttrs, name)
+		if boolAttr == nil {
+			continue
+		}
+		value, err := strconv.ParseBool(boolAttr.Value)
+		if err != nil {
+			continue
+		}
+		if name == "abstract" {
+			ret.Abstract = value
+		} else {
+			ret.Merge = value
+		}
+	}
+	if tagSelectorAttr := attr.FindAttr(resource.XMLAttrs, "tagSelector"); tagSelectorAttr != nil {
+		ret.TagSelector = tagSelectorAttr.Value
+	}
+	href := func(qname string, namespaces map[string]string) string {
+		prefix, local := "", strings.TrimSpace(qname)
+		if i := strings.IndexRune(local, ':'); i >= 0 {
+			prefix, local = local[:i], local[i+1:]
+		}
+		namespace := namespaces[prefix]
+		if namespace == attr.XFI && local == "root" {
+			return ""
+		}
+		ret, _, _ := h.NameQuery(namespace, local)
+		return ret
+	}
+	for _, child := range resource.Children {
+		namespaces := declareNamespaces(resource.Namespaces, child.XMLAttrs)
+		text := strings.TrimSpace(child.CharData)
+		switch child.XMLName.Space {
+		case attr.FORMULA:
+			switch child.XMLName.Local {
+			case "concept":
+				for _, qname := range child.Children {
+					if qname.XMLName.Local == "qname" {
+						ret.Concept = href(qname.CharData, declareNamespaces(namespaces, qname.XMLAttrs))
+					}
+				}
+			case "explicitDimension":
+				dimensionAttr := attr.FindAttr(child.XMLAttrs, "dimension")
+				if dimensionAttr == nil {
+					continue
+				}
+				dimension := href(dimensionAttr.Value, namespaces)
+				if dimension == "" {
+					continue
+				}
+				for _, member := range child.Children {
+					if member.XMLName.Local != "member" {
+						continue
+					}
+					for _, qname := range member.Children {
+						if qname.XMLName.Local == "qname" {
+							ret.ExplicitDimensions[dimension] = href(qname.CharData, declareNamespaces(namespaces, qname.XMLAttrs))
+						}
+					}
+				}
+			case "period":
+				ret.Period = rulePeriod(child.Children)
+			}
+		case attr.TABLE:
+			switch child.XMLName.Local {
+			case "relationshipSource":
+				ret.RelationshipSources = append(ret.RelationshipSources, href(text, namespaces))
+			case "linkrole":
+				ret.LinkRole = text
+			case "arcrole":
+				ret.Arcrole = text
+			case "formulaAxis":
+				ret.FormulaAxis = text
+			case "generations":
+				ret.Generations, _ = strconv.Atoi(text)
+			case "dimension":
+				ret.Dimension = href(text, namespaces)
+			case "conceptAspect", "entityIdentifierAspect", "periodAspect", "unitAspect":
+				ret.Aspect = strings.TrimSuffix(child.XMLName.Local, "Aspect")
+			case "dimensionAspect":
+				ret.Aspect = "dimension"
+				ret.Dimension = href(text, namespaces)
+			}
+		}
+	}
+	return ret
+}
+
+// rulePeriod reads a period rule of literal dates, e.g. xs:date('2023-12-31')
+func rulePeriod(elements []serializables.LinkElement) *Period {
+	for _, element := range elements {
+		if element.XMLName.Space != attr.FORMULA {
+			continue
+		}
+		date := func(name string) string {
+			valueAttr := attr.FindAttr(element.XMLAttrs, name)
+			if valueAttr == nil {
+				return ""
+			}
+			return xsDate.FindString(valueAttr.Value)
+		}
+		switch element.XMLName.Local {
+		case "forever":
+			return &Period{
+				Forever: true,
+			}
+		case "instant":
+			if value := date("value"); value != "" {
+				return &Period{
+					Instant: Instant{
+						CharData: value,
+					},
+				}
+			}
+		case "duration":
+			start, end := date("start"), date("end")
+			if start != "" && end != "" {
+				return &Period{
+					Duration: Duration{
+						StartDate: start,
+						EndDate:   end,
+					},
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package hydratables
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+type TableArc struct {
+	Order      float64
+	Arcrole    string
+	From       string
+	To         string
+	Axis       string
+	Priority   int
+	Prohibited bool
+}
+
+// TableResource is a resource of a generic link, such as a table, a
+// breakdown, a definition node or a generic label, with the prefixes in
+// scope of the QNames of its rules
+type TableResource struct {
+	XMLName    xml.Name
+	Href       string
+	Label      string
+	Role       string
+	Lang       string
+	CharData   string
+	XMLAttrs   []xml.Attr
+	Children   []serializables.LinkElement
+	Namespaces map[string]string
+}
+
+type TableLink struct {
+	Role      string
+	Locs      []Loc
+	Resources []TableResource
+	TableArcs []TableArc
+}
+
+type TableLinkbase struct {
+	FileName   string
+	RoleRefs   []RoleRef
+	TableLinks []TableLink
+}
+
+func HydrateTableLinkbase(file *serializables.TableLinkbaseFile, fileName string) (*TableLinkbase, error) {
+	if len(fileName) <= 0 {
+		return nil, fmt.Errorf("empty file name")
+	}
+	if file == nil {
+		return nil, fmt.Errorf("empty file")
+	}
+	ret := TableLinkbase{}
+	ret.FileName = fileName
+	ret.RoleRefs = hydrateTableLinkbaseRoleRefs(file)
+	ret.TableLinks = hydrateTableLink(file, fileName)
+	return &ret, nil
+}
+
+func hydrateTableLinkbaseRoleRefs(linkbaseFile *serializables.TableLinkbaseFile) []RoleRef {
+	ret := make([]RoleRef, 0, len(linkbaseFile.RoleRef))
+	for _, roleRef := range linkbaseFile.RoleRef {
+		if roleRef.XMLName.Space != attr.LINK {
+			continue
+		}
+		roleURIAttr := attr.FindAttr(roleRef.XMLAttrs, "roleURI")
+		if roleURIAttr == nil || roleURIAttr.Value == "" {
+			continue
+		}
+		hrefAttr := attr.FindAttr(roleRef.XMLAttrs, "href")
+		if hrefAttr == nil || hrefAttr.Value == "" || hrefAttr.Name.Space != attr.XLINK {
+			continue
+		}
+		ret = append(ret, RoleRef{
+			RoleURI: roleURIAttr.Value,
+			Href:    hrefAttr.Value,
+		})
+	}
+	return ret
+}
+
+func hydrateTableLink(linkbaseFile *serializables.TableLinkbaseFile, fileName string) []TableLink {
+	ret := make([]TableLink, 0, len(linkbaseFile.Link))
+	for _, link := range linkbaseFile.Link {
+		if link.XMLName.Space != attr.GEN {
+			continue
+		}
+		typeAttr := attr.FindAttr(link.XMLAttrs, "type")
+		if typeAttr == nil || typeAttr.Name.Space != attr.XLINK || typeAttr.Value != "extended" {
+			continue
+		}
+		roleAttr := attr.FindAttr(link.XMLAttrs, "role")
+		if roleAttr == nil || roleAttr.Value == "" {
+			continue
+		}
+		namespaces := declareNamespaces(declareNamespaces(nil, linkbaseFile.XMLAttrs), link.XMLAttrs)
+		newLink := TableLink{}
+		newLink.Role = roleAttr.Value
+		for _, element := range link.Elements {
+			ttypeAttr := attr.FindAttr(element.XMLAttrs, "type")
+			if ttypeAttr == nil || ttypeAttr.Name.Space != attr.XLINK {
+				continue
+			}
+			labelAttr := attr.FindAttr(element.XMLAttrs, "label")
+			switch ttypeAttr.Value {
+			case "locator":
+				hrefAttr := attr.FindAttr(element.XMLAttrs, "href")
+				if labelAttr == nil || labelAttr.Value == "" || hrefAttr == nil || hrefAttr.Value == "" {
+					continue
+				}
+				newLink.Locs = append(newLink.Locs, Loc{
+					Href:  resolveHref(fileName, hrefAttr.Value),
+					Label: labelAttr.Value,
+				})
+			case "resource":
+				if labelAttr == nil || labelAttr.Value == "" {
+					continue
+				}
+				resource := TableResource{
+					XMLName:    element.XMLName,
+					Label:      labelAttr.Value,
+					CharData:   element.CharData,
+					XMLAttrs:   element.XMLAttrs,
+					Children:   element.Children,
+					Namespaces: declareNamespaces(namespaces, element.XMLAttrs),
+				}
+				// resources without an id are only reachable from their link
+				resource.Href = fileName + "#" + labelAttr.Value
+				if idAttr := attr.FindAttr(element.XMLAttrs, "id"); idAttr != nil && idAttr.Value != "" {
+					resource.Href = fileName + "#" + idAttr.Value
+				}
+				if roleAttr := attr.FindAttr(element.XMLAttrs, "role"); roleAttr != nil && roleAttr.Name.Space == attr.XLINK {
+					resource.Role = roleAttr.Value
+				}
+				if langAttr := attr.FindAttr(element.XMLAttrs, "lang"); langAttr != nil {
+					resource.Lang = langAttr.Value
+				}
+				newLink.Resources = append(newLink.Resources, resource)
+			case "arc":
+				arcroleAttr := attr.FindAttr(element.XMLAttrs, "arcrole")
+				if arcroleAttr == nil || arcroleAttr.Name.Space != attr.XLINK || arcroleAttr.Value == "" {
+					continue
+				}
+				fromAttr := attr.FindAttr(element.XMLAttrs, "from")
+				if fromAttr == nil || fromAttr.Name.Space != attr.XLINK || fromAttr.Value == "" {
+					continue
+				}
+				toAttr := attr.FindAttr(element.XMLAttrs, "to")
+				if toAttr == nil || toAttr.Name.Space != attr.XLINK || toAttr.Value == "" {
+					continue
+				}
+				newArc := TableArc{
+					Arcrole: arcroleAttr.Value,
+					Order:   arcOrder(element.XMLAttrs),
+					From:    fromAttr.Value,
+					To:      toAttr.Value,
+				}
+				newArc.Priority, newArc.Prohibited = arcUse(element.XMLAttrs)
+				if axisAttr := attr.FindAttr(element.XMLAttrs, "axis"); axisAttr != nil {
+					newArc.Axis = axisAttr.Value
+				}
+				newLink.TableArcs = append(newLink.TableArcs, newArc)
+			}
+		}
+		ret = append(ret, newLink)
+	}
+	return ret
+}
+
+// declareNamespaces adds the namespace declarations of the attributes to
+// the prefixes in scope, keyed by prefix with the default namespace at ""
+func declareNamespaces(namespaces map[string]string, attrs []xml.Attr) map[string]string {
+	ret := make(map[string]string, len(namespaces))
+	for prefix, namespace := range namespaces {
+		ret[prefix] = namespace
+	}
+	for _, xmlAttr := range attrs {
+		if xmlAttr.Name.Space == "xmlns" {
+			ret[xmlAttr.Name.Local] = xmlAttr.Value
+		} else if xmlAttr.Name.Space == "" && xmlAttr.Name.Local == "xmlns" {
+			ret[""] = xmlAttr.Value
+		}
+	}
+	return ret
+}
+
+// resolveHref resolves the href of a locator relative to the linkbase file
+func resolveHref(fileName string, href string) string {
+	if attr.IsValidUrl(href) {
+		return href
+	}
+	if len(href) > 0 && href[0] == '#' {
+		return fileName + href
+	}
+	return path.Join(path.Dir(fileName), href)
+}
+
+// FindGenericLabels lists the generic labels of a resource, e.g. a table or
+// a definition node, or of a concept
+func (h *Hydratable) FindGenericLabels(href string) []LabelLinkLabel {
+	ret := make([]LabelLinkLabel, 0)
+	for _, linkbase := range h.TableLinkbases {
+		for _, link := range linkbase.TableLinks {
+			from := map[string]bool{}
+			for _, loc := range link.Locs {
+				if loc.Href == href {
+					from[loc.Label] = true
+				}
+			}
+			for _, resource := range link.Resources {
+				if resource.Href == href {
+					from[resource.Label] = true
+				}
+			}
+			if len(from) <= 0 {
+				continue
+			}
+			for _, arc := range link.TableArcs {
+				if !from[arc.From] || arc.Arcrole != attr.ElementLabelArcrole || arc.Prohibited {
+					continue
+				}
+				for _, resource := range link.Resources {
+					if resource.Label != arc.To || resource.XMLName.Space != attr.GENLABEL {
+						continue
+					}
+					ret = append(ret, LabelLinkLabel{
+						Label:    resource.Label,
+						Role:     resource.Role,
+						Lang:     resource.Lang,
+						CharData: resource.CharData,
+					})
+				}
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,236 @@
+package hydratables
+
+import (
+	"encoding/xml"
+
+	"ecksbee.com/telefacts/pkg/attr"
+)
+
+var xbrliItemTypes = map[string]string{
+	"decimalItemType":            "decimal",
+	"floatItemType":              "float",
+	"doubleItemType":             "double",
+	"monetaryItemType":           "decimal",
+	"sharesItemType":             "decimal",
+	"pureItemType":               "decimal",
+	"integerItemType":            "integer",
+	"nonPositiveIntegerItemType": "nonPositiveInteger",
+	"negativeIntegerItemType":    "negativeInteger",
+	"longItemType":               "long",
+	"intItemType":                "int",
+	"shortItemType":              "short",
+	"byteItemType":               "byte",
+	"nonNegativeIntegerItemType": "nonNegativeInteger",
+	"unsignedLongItemType":       "unsignedLong",
+	"unsignedIntItemType":        "unsignedInt",
+	"unsignedShortItemType":      "unsignedShort",
+	"unsignedByteItemType":       "unsignedByte",
+	"positiveIntegerItemType":    "positiveInteger",
+	"stringItemType":             "string",
+	"booleanItemType":            "boolean",
+	"hexBinaryItemType":          "hexBinary",
+	"base64BinaryItemType":       "base64Binary",
+	"anyURIItemType":             "anyURI",
+	"QNameItemType":              "QName",
+	"durationItemType":           "duration",
+	"dateTimeItemType":           "dateTime",
+	"timeItemType":               "time",
+	"dateItemType":               "date",
+	"gYearMonthItemType":         "gYearMonth",
+	"gYearItemType":              "gYear",
+	"gMonthDayItemType":          "gMonthDay",
+	"gDayItemType":               "gDay",
+	"gMonthItemType":             "gMonth",
+	"normalizedStringItemType":   "normalizedString",
+	"tokenItemType":              "token",
+	"languageItemType":           "language",
+	"NameItemType":               "Name",
+	"NCNameItemType":             "NCName",
+}
+
+// xsdBaseType resolves a concept type to the XML Schema built-in type that
+// its values are lexically checked against
+func xsdBaseType(typ xml.Name) string {
+	switch typ.Space {
+	case attr.XSD:
+		return typ.Local
+	case attr.XBRLI:
+		if base, found := xbrliItemTypes[typ.Local]; found {
+			return base
+		}
+	case attr.NUM:
+		return "decimal"
+	case attr.ENUM:
+		if typ.Local == attr.EnumerationItemType {
+			return "QName"
+		}
+	case attr.ENUM2:
+		if typ.Local == attr.EnumerationItemType || typ.Local == attr.EnumerationSetItemType {
+			return "enumerationSet"
+		}
+	}
+	return "string"
+}
+
+// dtrItemTypes are the bases of the Data Type Registry item types, for when
+// the registry schemas are not in the global taxonomy set
+var dtrItemTypes = map[string]string{
+	"percentItemType":             "pureItemType",
+	"perShareItemType":            "decimalItemType",
+	"areaItemType":                "decimalItemType",
+	"volumeItemType":              "decimalItemType",
+	"massItemType":                "decimalItemType",
+	"weightItemType":              "decimalItemType",
+	"energyItemType":              "decimalItemType",
+	"powerItemType":               "decimalItemType",
+	"lengthItemType":              "decimalItemType",
+	"memoryItemType":              "decimalItemType",
+	"noDecimalsMonetaryItemType":  "monetaryItemType",
+	"nonNegativeMonetaryItemType": "monetaryItemType",
+	"nonNegativeDecimal2ItemType": "decimalItemType",
+	"domainItemType":              "stringItemType",
+	"escapedItemType":             "stringItemType",
+	"xmlNodesItemType":            "stringItemType",
+	"xmlItemType":                 "stringItemType",
+	"textBlockItemType":           "stringItemType",
+	"gYearListItemType":           "stringItemType",
+	"prefixedContentItemType":     "stringItemType",
+}
+
+var dtrSchemas = map[string]string{
+	attr.NUM:    attr.DTRNUM,
+	attr.NONNUM: attr.DTRNONNUM,
+}
+
+// typeHierarchy lists a type followed by the types it derives from, up to an
+// xbrli item type or an XML Schema built-in type
+func (h *Hydratable) typeHierarchy(typ xml.Name) []xml.Name {
+	ret := []xml.Name{typ}
+	curr := typ
+	for len(ret) < 32 {
+		if curr.Space == attr.XSD || curr.Space == attr.XBRLI {
+			break
+		}
+		base, found := h.findTypeBase(curr)
+		if !found {
+			break
+		}
+		ret = append(ret, base)
+		curr = base
+	}
+	return ret
+}
+
+func (h *Hydratable) findTypeBase(typ xml.Name) (xml.Name, bool) {
+	var schema *Schema
+	schemaLoc := h.Folder.Namespaces[typ.Space]
+	if schemaLoc == "" {
+		schemaLoc = dtrSchemas[typ.Space]
+	}
+	if attr.IsValidUrl(schemaLoc) {
+		schema, _ = HydrateGlobalSchema(schemaLoc)
+	} else if local, found := h.Schemas[schemaLoc]; found {
+		schema = &local
+	}
+	if schema != nil {
+		for _, definition := range schema.Types {
+			if definition.XMLName == typ {
+				return definition.Base, true
+			}
+		}
+	}
+	switch typ.Space {
+	case attr.NUM, attr.NONNUM, attr.DTR2020, attr.DTR2022:
+		if base, found := dtrItemTypes[typ.Local]; found {
+			return xml.Name{
+				Space: attr.XBRLI,
+				Local: base,
+			}, true
+		}
+	}
+	return xml.Name{}, false
+}
+
+// BaseXBRLType is the xbrli item type, or the XML Schema built-in type, that
+// the concept's type derives from
+func (concept *Concept) BaseXBRLType() xml.Name {
+	if len(concept.TypeHierarchy) <= 0 {
+		return concept.Type
+	}
+	return concept.TypeHierarchy[len(concept.TypeHierarchy)-1]
+}
+
+func (concept *Concept) DerivesFrom(typ xml.Name) bool {
+	for _, t := range concept.types() {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func (concept *Concept) IsNumeric() bool {
+	base := concept.BaseXBRLType()
+	if base.Space == attr.XBRLI && base.Local == "fractionItemType" {
+		return true
+	}
+	if base.Space == attr.NUM {
+		return true
+	}
+	if base.Space != attr.XSD && base.Space != attr.XBRLI {
+		return false
+	}
+	switch xsdBaseType(base) {
+	case "decimal", "float", "double":
+		return true
+	}
+	_, isInteger := integerTypes[xsdBaseType(base)]
+	return isInteger
+}
+
+func (concept *Concept) IsMonetary() bool {
+	return concept.DerivesFrom(xml.Name{
+		Space: attr.XBRLI,
+		Local: attr.MonetaryItemType,
+	})
+}
+
+func (concept *Concept) IsPercent() bool {
+	for _, t := range concept.types() {
+		switch t.Space {
+		case attr.NUM, attr.DTR2020, attr.DTR2022:
+			if t.Local == attr.PercentItemType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (concept *Concept) IsTextBlock() bool {
+	for _, t := range concept.types() {
+		if t.Local == attr.TextBlockItemType {
+			return true
+		}
+	}
+	return false
+}
+
+func (concept *Concept) types() []xml.Name {
+	if len(concept.TypeHierarchy) <= 0 {
+		return []xml.Name{concept.Type}
+	}
+	return concept.TypeHierarchy
+}
+
+// valueType is the nearest type in the hierarchy that xsdBaseType knows the
+// lexical space of
+func (concept *Concept) valueType() xml.Name {
+	for _, t := range concept.types() {
+		switch t.Space {
+		case attr.XSD, attr.XBRLI, attr.NUM, attr.ENUM, attr.ENUM2:
+			return t
+		}
+	}
+	return concept.Type
+}
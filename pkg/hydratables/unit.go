@@ -0,0 +1,160 @@
+package hydratables
+
+import (
+	"encoding/xml"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/attr"
+)
+
+const pureMeasure = "pure"
+
+func NewUnit(id string, numerators []UnitMeasure, denominators []UnitMeasure) Unit {
+	ret := Unit{
+		ID:           id,
+		Numerators:   make([]UnitMeasure, len(numerators)),
+		Denominators: make([]UnitMeasure, len(denominators)),
+	}
+	copy(ret.Numerators, numerators)
+	copy(ret.Denominators, denominators)
+	sortMeasures(ret.Numerators)
+	sortMeasures(ret.Denominators)
+	return ret
+}
+
+func sortMeasures(measures []UnitMeasure) {
+	sort.SliceStable(measures, func(i, j int) bool {
+		if measures[i].XMLName.Space == measures[j].XMLName.Space {
+			return measures[i].XMLName.Local < measures[j].XMLName.Local
+		}
+		return measures[i].XMLName.Space < measures[j].XMLName.Space
+	})
+}
+
+func (measure UnitMeasure) IsPure() bool {
+	return measure.XMLName.Space == attr.XBRLI && measure.XMLName.Local == pureMeasure
+}
+
+func (measure UnitMeasure) Equal(other UnitMeasure) bool {
+	return measure.XMLName.Space == other.XMLName.Space &&
+		measure.XMLName.Local == other.XMLName.Local
+}
+
+func equalMeasures(a []UnitMeasure, b []UnitMeasure) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal compares the numerator and denominator measures of two units, as in
+// the u-equal comparison of XBRL 2.1. Unit IDs are not compared.
+func (unit Unit) Equal(other Unit) bool {
+	a := NewUnit(unit.ID, unit.Numerators, unit.Denominators)
+	b := NewUnit(other.ID, other.Numerators, other.Denominators)
+	return equalMeasures(a.Numerators, b.Numerators) &&
+		equalMeasures(a.Denominators, b.Denominators)
+}
+
+// Simplify cancels the measures shared by the numerator and the denominator,
+// and drops xbrli:pure from a product that has other measures
+func (unit Unit) Simplify() Unit {
+	numerators := make([]UnitMeasure, 0, len(unit.Numerators))
+	cancelled := make([]bool, len(unit.Denominators))
+	for _, numerator := range unit.Numerators {
+		isCancelled := false
+		for j, denominator := range unit.Denominators {
+			if !cancelled[j] && numerator.Equal(denominator) {
+				cancelled[j] = true
+				isCancelled = true
+				break
+			}
+		}
+		if !isCancelled {
+			numerators = append(numerators, numerator)
+		}
+	}
+	denominators := make([]UnitMeasure, 0, len(unit.Denominators))
+	for j, denominator := range unit.Denominators {
+		if !cancelled[j] {
+			denominators = append(denominators, denominator)
+		}
+	}
+	numerators = withoutPure(numerators)
+	denominators = withoutPure(denominators)
+	if len(numerators) <= 0 && len(denominators) <= 0 {
+		numerators = append(numerators, UnitMeasure{
+			XMLName: xml.Name{
+				Space: attr.XBRLI,
+				Local: pureMeasure,
+			},
+			CharData: "xbrli:" + pureMeasure,
+		})
+	}
+	return NewUnit(unit.ID, numerators, denominators)
+}
+
+func withoutPure(measures []UnitMeasure) []UnitMeasure {
+	ret := make([]UnitMeasure, 0, len(measures))
+	for _, measure := range measures {
+		if !measure.IsPure() {
+			ret = append(ret, measure)
+		}
+	}
+	return ret
+}
+
+func (unit Unit) IsPure() bool {
+	simplified := unit.Simplify()
+	return len(simplified.Denominators) <= 0 && len(simplified.Numerators) == 1 &&
+		simplified.Numerators[0].IsPure()
+}
+
+var superscripts = map[rune]rune{
+	'-': '⁻', '0': '⁰', '1': '¹', '2': '²', '3': '³', '4': '⁴',
+	'5': '⁵', '6': '⁶', '7': '⁷', '8': '⁸', '9': '⁹',
+}
+
+// Superscript writes an exponent in superscript digits, such as ⁻¹
+func Superscript(exponent int) string {
+	ret := ""
+	for _, r := range strconv.Itoa(exponent) {
+		ret += string(superscripts[r])
+	}
+	return ret
+}
+
+// String writes the unit as a product of measures with exponents, such as
+// iso4217:USD × xbrli:shares⁻¹
+func (unit Unit) String() string {
+	sorted := NewUnit(unit.ID, unit.Numerators, unit.Denominators)
+	factors := make([]string, 0, len(sorted.Numerators)+len(sorted.Denominators))
+	appendFactors := func(measures []UnitMeasure, sign int) {
+		for i := 0; i < len(measures); {
+			j := i
+			for j < len(measures) && measures[j].Equal(measures[i]) {
+				j++
+			}
+			factor := measures[i].CharData
+			if factor == "" {
+				factor = measures[i].XMLName.Local
+			}
+			exponent := (j - i) * sign
+			if exponent != 1 {
+				factor += Superscript(exponent)
+			}
+			factors = append(factors, factor)
+			i = j
+		}
+	}
+	appendFactors(sorted.Numerators, 1)
+	appendFactors(sorted.Denominators, -1)
+	return strings.Join(factors, " × ")
+}
@@ -0,0 +1,269 @@
+package hydratables
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ValueKind int
+
+const (
+	StringValue ValueKind = iota
+	DecimalValue
+	DateValue
+	GYearValue
+	BooleanValue
+	QNameValue
+	EnumerationSetValue
+	DurationValue
+)
+
+// Value is a fact value parsed according to the concept's schema type. Only
+// the field matching Kind is set; Text keeps the collapsed lexical value.
+// Decimal is nil for the special float values INF, -INF and NaN.
+type Value struct {
+	Kind            ValueKind
+	Text            string
+	Decimal         *big.Rat
+	Date            time.Time
+	GYear           int
+	Boolean         bool
+	QName           xml.Name
+	EnumerationSet  []string
+	Duration        XSDuration
+	IsDateTime      bool
+	HasTimezone     bool
+	IsSpecialNumber bool
+}
+
+type XSDuration struct {
+	Negative bool
+	Years    int
+	Months   int
+	Days     int
+	Hours    int
+	Minutes  int
+	Seconds  *big.Rat
+}
+
+var (
+	decimalPattern  = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)$`)
+	floatPattern    = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+	integerPattern  = regexp.MustCompile(`^[+-]?\d+$`)
+	gYearPattern    = regexp.MustCompile(`^(-?\d{4,})(Z|[+-]\d{2}:\d{2})?$`)
+	durationPattern = regexp.MustCompile(`^(-)?P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+	ncNamePattern   = regexp.MustCompile(`^[A-Za-z_][\w.\-]*$`)
+	timezonePattern = regexp.MustCompile(`(Z|[+-]\d{2}:\d{2})$`)
+)
+
+type integerBounds struct {
+	min *big.Int
+	max *big.Int
+}
+
+func bound(s string) *big.Int {
+	ret, _ := new(big.Int).SetString(s, 10)
+	return ret
+}
+
+var integerTypes = map[string]integerBounds{
+	"integer":            {},
+	"nonPositiveInteger": {max: bound("0")},
+	"negativeInteger":    {max: bound("-1")},
+	"long":               {min: bound("-9223372036854775808"), max: bound("9223372036854775807")},
+	"int":                {min: bound("-2147483648"), max: bound("2147483647")},
+	"short":              {min: bound("-32768"), max: bound("32767")},
+	"byte":               {min: bound("-128"), max: bound("127")},
+	"nonNegativeInteger": {min: bound("0")},
+	"unsignedLong":       {min: bound("0"), max: bound("18446744073709551615")},
+	"unsignedInt":        {min: bound("0"), max: bound("4294967295")},
+	"unsignedShort":      {min: bound("0"), max: bound("65535")},
+	"unsignedByte":       {min: bound("0"), max: bound("255")},
+	"positiveInteger":    {min: bound("1")},
+}
+
+// ParseValue parses the lexical value of a fact of the given concept type.
+// Namespaces maps the prefixes in scope of the fact to their namespace URIs,
+// and is only consulted for QName values.
+func ParseValue(charData string, typ xml.Name, namespaces map[string]string) (*Value, error) {
+	base := xsdBaseType(typ)
+	if base == "string" || base == "normalizedString" {
+		return &Value{
+			Kind: StringValue,
+			Text: charData,
+		}, nil
+	}
+	text := strings.Join(strings.Fields(charData), " ")
+	ret := Value{
+		Text: text,
+	}
+	if bounds, found := integerTypes[base]; found {
+		if !integerPattern.MatchString(text) {
+			return nil, fmt.Errorf("invalid %s %s", base, text)
+		}
+		i, _ := new(big.Int).SetString(strings.TrimPrefix(text, "+"), 10)
+		if (bounds.min != nil && i.Cmp(bounds.min) < 0) ||
+			(bounds.max != nil && i.Cmp(bounds.max) > 0) {
+			return nil, fmt.Errorf("out of range %s %s", base, text)
+		}
+		ret.Kind = DecimalValue
+		ret.Decimal = new(big.Rat).SetInt(i)
+		return &ret, nil
+	}
+	switch base {
+	case "decimal":
+		if !decimalPattern.MatchString(text) {
+			return nil, fmt.Errorf("invalid decimal %s", text)
+		}
+		ret.Kind = DecimalValue
+		ret.Decimal, _ = new(big.Rat).SetString(text)
+	case "float", "double":
+		ret.Kind = DecimalValue
+		if text == "INF" || text == "-INF" || text == "NaN" {
+			ret.IsSpecialNumber = true
+			return &ret, nil
+		}
+		if !floatPattern.MatchString(text) {
+			return nil, fmt.Errorf("invalid %s %s", base, text)
+		}
+		ret.Decimal, _ = new(big.Rat).SetString(text)
+	case "boolean":
+		ret.Kind = BooleanValue
+		switch text {
+		case "true", "1":
+			ret.Boolean = true
+		case "false", "0":
+			ret.Boolean = false
+		default:
+			return nil, fmt.Errorf("invalid boolean %s", text)
+		}
+	case "date":
+		ret.Kind = DateValue
+		for _, layout := range dateLayouts {
+			t, err := time.Parse(layout, text)
+			if err == nil {
+				ret.Date = t
+				ret.HasTimezone = timezonePattern.MatchString(text)
+				return &ret, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid date %s", text)
+	case "dateTime":
+		ret.Kind = DateValue
+		t, isDate, err := ParsePeriodDate(text, false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dateTime %s", text)
+		}
+		ret.Date = t
+		ret.IsDateTime = !isDate
+		ret.HasTimezone = timezonePattern.MatchString(text)
+	case "gYear":
+		matches := gYearPattern.FindStringSubmatch(text)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid gYear %s", text)
+		}
+		year, err := strconv.Atoi(matches[1])
+		if err != nil || year == 0 {
+			return nil, fmt.Errorf("invalid gYear %s", text)
+		}
+		ret.Kind = GYearValue
+		ret.GYear = year
+		ret.HasTimezone = matches[2] != ""
+	case "QName":
+		name, err := parseQName(text, namespaces)
+		if err != nil {
+			return nil, err
+		}
+		ret.Kind = QNameValue
+		ret.QName = *name
+	case "enumerationSet":
+		ret.Kind = EnumerationSetValue
+		ret.EnumerationSet = strings.Fields(text)
+		for _, uri := range ret.EnumerationSet {
+			i := strings.LastIndex(uri, "#")
+			if i <= 0 || i >= len(uri)-1 {
+				return nil, fmt.Errorf("invalid enumeration value %s", uri)
+			}
+		}
+	case "duration":
+		duration, err := parseDuration(text)
+		if err != nil {
+			return nil, err
+		}
+		ret.Kind = DurationValue
+		ret.Duration = *duration
+	default:
+		ret.Kind = StringValue
+	}
+	return &ret, nil
+}
+
+func parseQName(text string, namespaces map[string]string) (*xml.Name, error) {
+	prefix := ""
+	local := text
+	i := strings.IndexRune(text, ':')
+	if i >= 0 {
+		prefix = text[:i]
+		local = text[i+1:]
+		if !ncNamePattern.MatchString(prefix) {
+			return nil, fmt.Errorf("invalid QName %s", text)
+		}
+	}
+	if !ncNamePattern.MatchString(local) {
+		return nil, fmt.Errorf("invalid QName %s", text)
+	}
+	space, found := namespaces[prefix]
+	if !found && prefix != "" {
+		return nil, fmt.Errorf("prefix, %s, does not match a namespace", prefix)
+	}
+	return &xml.Name{
+		Space: space,
+		Local: local,
+	}, nil
+}
+
+func parseDuration(text string) (*XSDuration, error) {
+	matches := durationPattern.FindStringSubmatch(text)
+	if matches == nil || strings.HasSuffix(text, "P") || strings.HasSuffix(text, "T") {
+		return nil, fmt.Errorf("invalid duration %s", text)
+	}
+	ret := XSDuration{
+		Negative: matches[1] != "",
+		Seconds:  new(big.Rat),
+	}
+	fields := []*int{&ret.Years, &ret.Months, &ret.Days, &ret.Hours, &ret.Minutes}
+	for i, field := range fields {
+		if matches[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(matches[i+2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %s", text)
+		}
+		*field = n
+	}
+	if matches[7] != "" {
+		ret.Seconds.SetString(matches[7])
+	}
+	return &ret, nil
+}
+
+func namespaces(attrs []xml.Attr, inherited map[string]string) map[string]string {
+	ret := make(map[string]string, len(inherited))
+	for prefix, space := range inherited {
+		ret[prefix] = space
+	}
+	for _, a := range attrs {
+		if a.Name.Space == "xmlns" {
+			ret[a.Name.Local] = a.Value
+		} else if a.Name.Space == "" && a.Name.Local == "xmlns" {
+			ret[""] = a.Value
+		}
+	}
+	return ret
+}
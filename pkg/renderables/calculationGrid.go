@@ -20,6 +20,7 @@ type SummationItem struct {
 	FactualQuadrant      FactualQuadrant
 	FootnoteGrid         [][][]int
 	Footnotes            []string
+	ProvenanceGrid       [][]*CellProvenance
 }
 
 type ContributingConcept struct {
@@ -36,120 +37,120 @@ type CGrid struct {
 }
 
 func cGrid(schemedEntity string, linkroleURI string, h *hydratables.Hydratable,
-	factFinder FactFinder, conceptFinder ConceptFinder, measurementFinder MeasurementFinder) (CGrid, []LabelRole, []Lang, error) {
+	factFinder FactFinder, conceptFinder ConceptFinder, measurementFinder MeasurementFinder, renderers *FactRenderers) (CGrid, []LabelRole, []Lang, error) {
 	summationItems, labelRoles, langs := getSummationItems(schemedEntity, linkroleURI,
-		h, factFinder, conceptFinder, measurementFinder)
+		h, factFinder, conceptFinder, measurementFinder, renderers)
 	return CGrid{
 		SummationItems: summationItems,
 	}, labelRoles, langs, nil
 }
 
 func getSummationItems(schemedEntity string, linkroleURI string, h *hydratables.Hydratable,
-	factFinder FactFinder, conceptFinder ConceptFinder, measurementFinder MeasurementFinder) ([]SummationItem, []LabelRole, []Lang) {
+	factFinder FactFinder, conceptFinder ConceptFinder, measurementFinder MeasurementFinder, renderers *FactRenderers) ([]SummationItem, []LabelRole, []Lang) {
 	var calculationLinks []hydratables.CalculationLink
-	for _, calculation := range h.CalculationLinkbases {
-		for _, roleRef := range calculation.RoleRefs {
-			if linkroleURI == roleRef.RoleURI {
-				calculationLinks = calculation.CalculationLinks
-				break
-			}
+	calculation := h.BaseSets.Calculation
+	for _, roleRef := range calculation.RoleRefs {
+		if linkroleURI == roleRef.RoleURI {
+			calculationLinks = calculation.CalculationLinks
+			break
 		}
-		for _, calculationLink := range calculationLinks {
-			if calculationLink.Role == linkroleURI {
-				arcs := calculationLink.CalculationArcs
-				type cStruct struct {
-					Href  string
-					Order float64
-					Sign  rune
-					Scale float64
-				}
-				cMap := make(map[string][]*cStruct)
-				for _, arc := range arcs {
-					if arc.Arcrole == attr.CalculationArcrole {
-						order := arc.Order
-						weight := arc.Weight
-						fromHref := mapCLocatorToHref(linkroleURI, &calculation, arc.From)
-						sign := '+'
-						if weight < 0 {
-							sign = '-'
-						}
-						scale := math.Abs(weight)
-						cMap[fromHref] = append(cMap[fromHref],
-							&cStruct{
-								Href:  mapCLocatorToHref(linkroleURI, &calculation, arc.To),
-								Order: order,
-								Sign:  sign,
-								Scale: scale,
-							})
+	}
+	for _, calculationLink := range calculationLinks {
+		if calculationLink.Role == linkroleURI {
+			arcs := calculationLink.CalculationArcs
+			type cStruct struct {
+				Href  string
+				Order float64
+				Sign  rune
+				Scale float64
+			}
+			cMap := make(map[string][]*cStruct)
+			for _, arc := range arcs {
+				if arc.Arcrole == attr.CalculationArcrole {
+					order := arc.Order
+					weight := arc.Weight
+					fromHref := mapCLocatorToHref(linkroleURI, &calculation, arc.From)
+					sign := '+'
+					if weight < 0 {
+						sign = '-'
 					}
-				}
-				for key, slice := range cMap {
-					sort.SliceStable(cMap[key], func(i, j int) bool {
-						return slice[i].Order < slice[j].Order
-					})
-				}
-				labelPacks := make([]LabelPack, 0, len(calculationLinks))
-				var (
-					labelRoles []LabelRole
-					langs      []Lang
-				)
-				ret := make([]SummationItem, 0, len(cMap))
-				for from, slice := range cMap {
-					contributingConcepts := make([]ContributingConcept, 0, len(slice))
-					fqLabels := make([]string, 0, len(slice)+1)
-					for _, cstruct := range slice {
-						_, isSummationItem := cMap[cstruct.Href]
-						sign := fmt.Sprintf("%c", cstruct.Sign)
-						scale := fmt.Sprintf("%.1f", cstruct.Scale)
-						cLabelPack := GetLabel(h, cstruct.Href)
-						_, concept, err := h.HashQuery(cstruct.Href)
-						if err != nil {
-							continue
-						}
-						contributingConcepts = append(contributingConcepts, ContributingConcept{
-							Href:            cstruct.Href,
-							Label:           cLabelPack,
-							BalanceType:     concept.Balance,
-							Scale:           scale,
-							Sign:            sign,
-							IsSummationItem: isSummationItem,
+					scale := math.Abs(weight)
+					cMap[fromHref] = append(cMap[fromHref],
+						&cStruct{
+							Href:  mapCLocatorToHref(linkroleURI, &calculation, arc.To),
+							Order: order,
+							Sign:  sign,
+							Scale: scale,
 						})
-						fqLabels = append(fqLabels, cstruct.Href)
-						labelPacks = append(labelPacks, cLabelPack)
-					}
-					fqLabels = append(fqLabels, from)
-					relevantContexts, segmentTypedDomainArcs, scenarioTypedDomainArcs, contextualLabelPack := getRelevantContexts(schemedEntity, h, fqLabels)
-					siLabelPack := GetLabel(h, from)
-					labelPacks = append(labelPacks, siLabelPack)
-					labelPacks = append(labelPacks, contextualLabelPack...)
-					reduced := reduce(labelPacks)
-					if reduced != nil {
-						labelRoles, langs = destruct(*reduced)
-					}
-					factualQuadrant, footnoteGrid, footnotes := getFactualQuadrant(fqLabels, relevantContexts, factFinder, conceptFinder, measurementFinder, langs)
-					_, sumConcept, err := h.HashQuery(from)
+				}
+			}
+			for key, slice := range cMap {
+				sort.SliceStable(cMap[key], func(i, j int) bool {
+					return slice[i].Order < slice[j].Order
+				})
+			}
+			labelPacks := make([]LabelPack, 0, len(calculationLinks))
+			var (
+				labelRoles []LabelRole
+				langs      []Lang
+			)
+			ret := make([]SummationItem, 0, len(cMap))
+			for from, slice := range cMap {
+				contributingConcepts := make([]ContributingConcept, 0, len(slice))
+				fqLabels := make([]string, 0, len(slice)+1)
+				for _, cstruct := range slice {
+					_, isSummationItem := cMap[cstruct.Href]
+					sign := fmt.Sprintf("%c", cstruct.Sign)
+					scale := fmt.Sprintf("%.1f", cstruct.Scale)
+					cLabelPack := GetLabel(h, cstruct.Href)
+					_, concept, err := h.HashQuery(cstruct.Href)
 					if err != nil {
 						continue
 					}
-					memberGrid, voidQuadrant := getMemberGridAndVoidQuadrant(relevantContexts, segmentTypedDomainArcs, scenarioTypedDomainArcs)
-					ret = append(ret, SummationItem{
-						Href:                 from,
-						Label:                siLabelPack,
-						BalanceType:          sumConcept.Balance,
-						ContributingConcepts: contributingConcepts,
-						PeriodHeaders:        getPeriodHeaders(relevantContexts),
-						ContextualMemberGrid: memberGrid,
-						VoidQuadrant:         voidQuadrant,
-						FactualQuadrant:      factualQuadrant,
-						FootnoteGrid:         footnoteGrid,
-						Footnotes:            footnotes,
+					contributingConcepts = append(contributingConcepts, ContributingConcept{
+						Href:            cstruct.Href,
+						Label:           cLabelPack,
+						BalanceType:     concept.Balance,
+						Scale:           scale,
+						Sign:            sign,
+						IsSummationItem: isSummationItem,
 					})
+					fqLabels = append(fqLabels, cstruct.Href)
+					labelPacks = append(labelPacks, cLabelPack)
+				}
+				fqLabels = append(fqLabels, from)
+				relevantContexts, segmentTypedDomainArcs, scenarioTypedDomainArcs, contextualLabelPack := getRelevantContexts(schemedEntity, h, fqLabels)
+				siLabelPack := GetLabel(h, from)
+				labelPacks = append(labelPacks, siLabelPack)
+				labelPacks = append(labelPacks, contextualLabelPack...)
+				reduced := reduce(labelPacks)
+				if reduced != nil {
+					labelRoles, langs = destruct(*reduced)
+				}
+				factualQuadrant, footnoteGrid, footnotes := getFactualQuadrant(fqLabels, relevantContexts, factFinder, conceptFinder, measurementFinder, langs, renderers)
+				_, sumConcept, err := h.HashQuery(from)
+				if err != nil {
+					continue
 				}
-				sort.SliceStable(ret, func(i, j int) bool {
-					return getPureLabel(ret[i].Label) < getPureLabel(ret[j].Label)
+				memberGrid, voidQuadrant := getMemberGridAndVoidQuadrant(relevantContexts, segmentTypedDomainArcs, scenarioTypedDomainArcs)
+				ret = append(ret, SummationItem{
+					Href:                 from,
+					Label:                siLabelPack,
+					BalanceType:          sumConcept.Balance,
+					ContributingConcepts: contributingConcepts,
+					PeriodHeaders:        getPeriodHeaders(relevantContexts),
+					ContextualMemberGrid: memberGrid,
+					VoidQuadrant:         voidQuadrant,
+					FactualQuadrant:      factualQuadrant,
+					FootnoteGrid:         footnoteGrid,
+					Footnotes:            footnotes,
+					ProvenanceGrid:       getProvenanceGrid(fqLabels, relevantContexts, factFinder),
 				})
-				return ret, labelRoles, langs
 			}
+			sort.SliceStable(ret, func(i, j int) bool {
+				return getPureLabel(ret[i].Label) < getPureLabel(ret[j].Label)
+			})
+			return ret, labelRoles, langs
 		}
 	}
 	return nil, nil, nil
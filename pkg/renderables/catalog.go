@@ -16,6 +16,12 @@ type Catalog struct {
 }
 
 func MarshalCatalog(h *hydratables.Hydratable) ([]byte, error) {
+	return json.Marshal(GetCatalog(h))
+}
+
+// GetCatalog lists the subjects and networks of the hydratable, with the
+// slug of each network of each subject
+func GetCatalog(h *hydratables.Hydratable) Catalog {
 	schemedEntities := sortedEntities(h)
 	rsets := sortedRelationshipSets(h)
 	subjects := make([]Subject, 0, len(schemedEntities))
@@ -31,23 +37,17 @@ func MarshalCatalog(h *hydratables.Hydratable) ([]byte, error) {
 			hash := hash(entityStr, rset.RoleURI, rset.Title)
 			networks[entityStr][rset.RoleURI] = hash
 		}
-		name := schemedEntity.Scheme + "/" + schemedEntity.CharData
-		hydratedName, err := hydratables.EntityQuery(schemedEntity.Scheme,
-			schemedEntity.CharData)
-		if err == nil {
-			name = hydratedName
-		}
 		subjects = append(subjects, Subject{
-			Name:   name,
+			Name:   subjectName(h, schemedEntity),
 			Entity: schemedEntity,
 		})
 	}
-	return json.Marshal(Catalog{
+	return Catalog{
 		Subjects:         subjects,
 		RelationshipSets: rsets,
 		Networks:         networks,
 		DocumentName:     documentName,
-	})
+	}
 }
 
 func hash(schemedEntity string, linkroleURI string, title string) string {
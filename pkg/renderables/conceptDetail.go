@@ -0,0 +1,356 @@
+package renderables
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/hydratables"
+)
+
+type ConceptDetail struct {
+	Href              string
+	Namespace         string
+	Name              string
+	Type              xml.Name
+	TypeHierarchy     []xml.Name
+	SubstitutionGroup xml.Name
+	Balance           string
+	PeriodType        string
+	Abstract          bool
+	Nillable          bool
+	Labels            LabelPack
+	References        []ConceptReference
+	Networks          []ConceptNetwork
+	Dimensions        []ConceptDimension
+	Facts             []ConceptFact
+}
+
+type ConceptReference struct {
+	Role  string
+	Parts []ReferencePart
+}
+
+type ReferencePart struct {
+	Name  string
+	Value string
+}
+
+// ConceptNetwork is a network of a base set that the concept takes part in,
+// with the concepts related to it by incoming and outgoing arcs
+type ConceptNetwork struct {
+	Linkbase        string
+	RelationshipSet RelationshipSet
+	Parents         []ConceptRelative
+	Children        []ConceptRelative
+}
+
+type ConceptRelative struct {
+	Href           string
+	Label          LabelPack
+	Arcrole        string
+	Order          float64
+	PreferredLabel string
+	Weight         float64
+}
+
+type ConceptDimension struct {
+	RelationshipSet RelationshipSet
+	Hypercube       string
+	Dimension       Dimension
+	Participation   string
+}
+
+const (
+	PrimaryItemParticipation = "PrimaryItem"
+	DimensionParticipation   = "Dimension"
+	MemberParticipation      = "Member"
+)
+
+type ConceptFact struct {
+	ID         string
+	ContextRef string
+	Entity     Entity
+	Period     LanguagePack
+	Members    []RelevantMember
+	UnitRef    string
+	Expression *MultilingualFact
+	Footnotes  []string
+}
+
+func MarshalConceptDetail(href string, h *hydratables.Hydratable, options ...RenderOption) ([]byte, error) {
+	namespace, concept, err := h.HashQuery(href)
+	if err != nil {
+		return nil, err
+	}
+	if concept == nil {
+		return nil, fmt.Errorf("concept not found %s", href)
+	}
+	if concept.XMLName.Space != "" {
+		namespace = concept.XMLName.Space
+	}
+	labels := GetLabel(h, href)
+	rsets := map[string]RelationshipSet{}
+	for _, rset := range sortedRelationshipSets(h) {
+		rsets[rset.RoleURI] = rset
+	}
+	return json.Marshal(ConceptDetail{
+		Href:              href,
+		Namespace:         namespace,
+		Name:              concept.XMLName.Local,
+		Type:              concept.Type,
+		TypeHierarchy:     concept.TypeHierarchy,
+		SubstitutionGroup: concept.SubstitutionGroup,
+		Balance:           concept.Balance,
+		PeriodType:        concept.PeriodType,
+		Abstract:          concept.Abstract,
+		Nillable:          concept.Nillable,
+		Labels:            labels,
+		References:        getConceptReferences(href, h),
+		Networks:          getConceptNetworks(href, h, rsets),
+		Dimensions:        getConceptDimensions(href, h, rsets),
+		Facts:             getConceptFacts(href, labels, h, NewFactRenderers(options...)),
+	})
+}
+
+func getConceptReferences(href string, h *hydratables.Hydratable) []ConceptReference {
+	references := h.FindReferences(href)
+	ret := make([]ConceptReference, 0, len(references))
+	for _, reference := range references {
+		parts := make([]ReferencePart, 0, len(reference.Parts))
+		for _, part := range reference.Parts {
+			parts = append(parts, ReferencePart{
+				Name:  part.XMLName.Local,
+				Value: part.CharData,
+			})
+		}
+		ret = append(ret, ConceptReference{
+			Role:  reference.Role,
+			Parts: parts,
+		})
+	}
+	return ret
+}
+
+func relationshipSet(roleURI string, rsets map[string]RelationshipSet) RelationshipSet {
+	if rset, found := rsets[roleURI]; found {
+		return rset
+	}
+	return RelationshipSet{
+		RoleURI: roleURI,
+	}
+}
+
+func getConceptNetworks(href string, h *hydratables.Hydratable, rsets map[string]RelationshipSet) []ConceptNetwork {
+	ret := make([]ConceptNetwork, 0)
+	appendNetwork := func(network ConceptNetwork) {
+		if len(network.Parents) <= 0 && len(network.Children) <= 0 {
+			return
+		}
+		sortRelatives := func(relatives []ConceptRelative) {
+			sort.SliceStable(relatives, func(p, q int) bool {
+				return relatives[p].Order < relatives[q].Order
+			})
+		}
+		sortRelatives(network.Parents)
+		sortRelatives(network.Children)
+		ret = append(ret, network)
+	}
+	for _, link := range h.BaseSets.Presentation.PresentationLinks {
+		network := ConceptNetwork{
+			Linkbase:        "presentation",
+			RelationshipSet: relationshipSet(link.Role, rsets),
+		}
+		for _, arc := range link.PresentationArcs {
+			relative := ConceptRelative{
+				Arcrole:        arc.Arcrole,
+				Order:          arc.Order,
+				PreferredLabel: arc.PreferredLabel,
+			}
+			if arc.To == href {
+				relative.Href = arc.From
+				relative.Label = GetLabel(h, arc.From)
+				network.Parents = append(network.Parents, relative)
+			}
+			if arc.From == href {
+				relative.Href = arc.To
+				relative.Label = GetLabel(h, arc.To)
+				network.Children = append(network.Children, relative)
+			}
+		}
+		appendNetwork(network)
+	}
+	for _, link := range h.BaseSets.Definition.DefinitionLinks {
+		network := ConceptNetwork{
+			Linkbase:        "definition",
+			RelationshipSet: relationshipSet(link.Role, rsets),
+		}
+		for _, arc := range link.DefinitionArcs {
+			relative := ConceptRelative{
+				Arcrole: arc.Arcrole,
+				Order:   arc.Order,
+			}
+			if arc.To == href {
+				relative.Href = arc.From
+				relative.Label = GetLabel(h, arc.From)
+				network.Parents = append(network.Parents, relative)
+			}
+			if arc.From == href {
+				relative.Href = arc.To
+				relative.Label = GetLabel(h, arc.To)
+				network.Children = append(network.Children, relative)
+			}
+		}
+		appendNetwork(network)
+	}
+	for _, link := range h.BaseSets.Calculation.CalculationLinks {
+		network := ConceptNetwork{
+			Linkbase:        "calculation",
+			RelationshipSet: relationshipSet(link.Role, rsets),
+		}
+		for _, arc := range link.CalculationArcs {
+			relative := ConceptRelative{
+				Arcrole: arc.Arcrole,
+				Order:   arc.Order,
+				Weight:  arc.Weight,
+			}
+			if arc.To == href {
+				relative.Href = arc.From
+				relative.Label = GetLabel(h, arc.From)
+				network.Parents = append(network.Parents, relative)
+			}
+			if arc.From == href {
+				relative.Href = arc.To
+				relative.Label = GetLabel(h, arc.To)
+				network.Children = append(network.Children, relative)
+			}
+		}
+		appendNetwork(network)
+	}
+	return ret
+}
+
+// getConceptDimensions lists the dimensions of the hypercubes a concept is a
+// primary item of, the dimensions it is, and the dimensions whose domains it
+// is a member of
+func getConceptDimensions(href string, h *hydratables.Hydratable, rsets map[string]RelationshipSet) []ConceptDimension {
+	ret := make([]ConceptDimension, 0)
+	for _, link := range h.BaseSets.Definition.DefinitionLinks {
+		targets := map[string]map[string][]string{}
+		for _, arc := range link.DefinitionArcs {
+			if _, found := targets[arc.Arcrole]; !found {
+				targets[arc.Arcrole] = map[string][]string{}
+			}
+			targets[arc.Arcrole][arc.From] = append(targets[arc.Arcrole][arc.From], arc.To)
+		}
+		var isMember func(domain string, visited map[string]bool) bool
+		isMember = func(domain string, visited map[string]bool) bool {
+			if domain == href {
+				return true
+			}
+			if visited[domain] {
+				return false
+			}
+			visited[domain] = true
+			for _, member := range targets[attr.DomainMemberArcrole][domain] {
+				if isMember(member, visited) {
+					return true
+				}
+			}
+			return false
+		}
+		rset := relationshipSet(link.Role, rsets)
+		appendDimension := func(hypercube string, dimension string, participation string) {
+			ret = append(ret, ConceptDimension{
+				RelationshipSet: rset,
+				Hypercube:       hypercube,
+				Dimension: Dimension{
+					Href:  dimension,
+					Label: GetLabel(h, dimension),
+				},
+				Participation: participation,
+			})
+		}
+		for _, arcrole := range []string{attr.HasInclusiveHypercubeArcrole, attr.HasExclusiveHypercubeArcrole} {
+			for primaryItem, hypercubes := range targets[arcrole] {
+				if !isMember(primaryItem, map[string]bool{}) {
+					continue
+				}
+				for _, hypercube := range hypercubes {
+					for _, dimension := range targets[attr.HypercubeDimensionArcrole][hypercube] {
+						appendDimension(hypercube, dimension, PrimaryItemParticipation)
+					}
+				}
+			}
+		}
+		for hypercube, dimensions := range targets[attr.HypercubeDimensionArcrole] {
+			for _, dimension := range dimensions {
+				if dimension == href {
+					appendDimension(hypercube, dimension, DimensionParticipation)
+				}
+			}
+		}
+		for dimension, domains := range targets[attr.DimensionDomainArcrole] {
+			for _, domain := range domains {
+				if isMember(domain, map[string]bool{}) {
+					appendDimension("", dimension, MemberParticipation)
+				}
+			}
+		}
+	}
+	sort.SliceStable(ret, func(p, q int) bool {
+		if ret[p].RelationshipSet.RoleURI == ret[q].RelationshipSet.RoleURI {
+			if ret[p].Participation == ret[q].Participation {
+				return ret[p].Dimension.Href < ret[q].Dimension.Href
+			}
+			return ret[p].Participation < ret[q].Participation
+		}
+		return ret[p].RelationshipSet.RoleURI < ret[q].RelationshipSet.RoleURI
+	})
+	return ret
+}
+
+func getConceptFacts(href string, labels LabelPack, h *hydratables.Hydratable, renderers *FactRenderers) []ConceptFact {
+	ret := make([]ConceptFact, 0)
+	_, langs := destruct(labels)
+	langs = dedupLang(append(langs, PureLabel))
+	fileNames := make([]string, 0, len(h.Instances))
+	for fileName := range h.Instances {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		instance := h.Instances[fileName]
+		for _, fact := range instance.Facts {
+			if fact.Href != href {
+				continue
+			}
+			context := getContext(&instance, fact.ContextRef)
+			if context == nil {
+				continue
+			}
+			members, _, _, _ := getContextualMembers(context, h)
+			footnotes := h.GetFootnotes(&fact)
+			footnoteTexts := make([]string, 0, len(footnotes))
+			for _, footnote := range footnotes {
+				footnoteTexts = append(footnoteTexts, footnote.InnerHtml)
+			}
+			ret = append(ret, ConceptFact{
+				ID:         fact.ID,
+				ContextRef: fact.ContextRef,
+				Entity: Entity{
+					Scheme:   context.Entity.Identifier.Scheme,
+					CharData: context.Entity.Identifier.CharData,
+				},
+				Period:     periodMultilingualString(labels, context, renderers),
+				Members:    members,
+				UnitRef:    fact.UnitRef,
+				Expression: render(&fact, h, h, langs, renderers),
+				Footnotes:  footnoteTexts,
+			})
+		}
+	}
+	return ret
+}
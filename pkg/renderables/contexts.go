@@ -96,13 +96,15 @@ func periodString(context *hydratables.Context) LanguagePack {
 		ret[PureLabel] = context.Period.Duration.StartDate + "/" + context.Period.Duration.EndDate
 	} else if context.Period.Instant.CharData != "" {
 		ret[PureLabel] = context.Period.Instant.CharData
+	} else if context.Period.Forever {
+		ret[PureLabel] = forever
 	} else {
 		ret[PureLabel] = ""
 	}
 	return ret
 }
 
-func periodMultilingualString(labels LabelPack, context *hydratables.Context) LanguagePack {
+func periodMultilingualString(labels LabelPack, context *hydratables.Context, renderers *FactRenderers) LanguagePack {
 	ret := LanguagePack{}
 	start := ""
 	end := ""
@@ -115,26 +117,27 @@ func periodMultilingualString(labels LabelPack, context *hydratables.Context) La
 		end = context.Period.Instant.CharData
 		ret[PureLabel] = end
 		ret[BriefLabel] = ret[PureLabel]
+	} else if context.Period.Forever {
+		end = forever
+		ret[PureLabel] = end
+		ret[BriefLabel] = ret[PureLabel]
 	} else {
 		ret[PureLabel] = ""
 		return ret
 	}
-	ret = appendLanguagePackFromPeriod(ret, labels, start, end)
+	ret = appendLanguagePackFromPeriod(ret, labels, start, end, renderers)
 	return ret
 }
 
-func appendLanguagePackFromPeriod(langPack LanguagePack, labels LabelPack, start string, end string) LanguagePack {
+func appendLanguagePackFromPeriod(langPack LanguagePack, labels LabelPack, start string, end string, renderers *FactRenderers) LanguagePack {
 	ret := langPack
 	for _, item := range labels {
 		for lang := range item {
-			switch lang {
-			case English:
-				ret[English] = formatEnglishDates(start, end)
-				break
-			case Español:
-				ret[Español] = formatSpanishDates(start, end)
-				break
-			default: //noop
+			if lang == PureLabel || lang == BriefLabel {
+				continue
+			}
+			if formatter, found := renderers.formatter(lang); found {
+				ret[lang] = formatter.formatDates(start, end)
 			}
 		}
 	}
@@ -171,7 +174,13 @@ func dedupEntities(h *hydratables.Hydratable) []Entity {
 	return uniques
 }
 
+// sortedEntities lists the entities of the instance contexts, or for a folder
+// without instances the TaxonomyEntity, so that networks can be browsed
+// before any facts are reported
 func sortedEntities(h *hydratables.Hydratable) []Entity {
+	if len(h.Instances) <= 0 {
+		return []Entity{TaxonomyEntity}
+	}
 	schemedEntities := dedupEntities(h)
 	sort.SliceStable(schemedEntities, func(i, j int) bool {
 		if schemedEntities[i].Scheme == schemedEntities[j].Scheme {
@@ -182,6 +191,21 @@ func sortedEntities(h *hydratables.Hydratable) []Entity {
 	return schemedEntities
 }
 
+func subjectName(h *hydratables.Hydratable, e Entity) string {
+	if e == TaxonomyEntity {
+		if h.Folder != nil {
+			return h.Folder.EntryFileName
+		}
+		return ""
+	}
+	name := e.Scheme + "/" + e.CharData
+	hydratedName, err := hydratables.EntityQuery(e.Scheme, e.CharData)
+	if err == nil {
+		name = hydratedName
+	}
+	return name
+}
+
 func stringify(e *Entity) string {
 	if e == nil {
 		return ""
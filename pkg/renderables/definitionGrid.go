@@ -10,8 +10,8 @@ type DGrid struct {
 }
 
 func dGrid(schemedEntity string, linkroleURI string, h *hydratables.Hydratable,
-	factFinder FactFinder, conceptFinder ConceptFinder, measurementFinder MeasurementFinder) (DGrid, []LabelRole, []Lang, error) {
-	rootDomains, labelRoles, langs := getRootDomains(schemedEntity, linkroleURI, h, factFinder, conceptFinder, measurementFinder)
+	factFinder FactFinder, conceptFinder ConceptFinder, measurementFinder MeasurementFinder, renderers *FactRenderers) (DGrid, []LabelRole, []Lang, error) {
+	rootDomains, labelRoles, langs := getRootDomains(schemedEntity, linkroleURI, h, factFinder, conceptFinder, measurementFinder, renderers)
 	return DGrid{
 		RootDomains: rootDomains,
 		DRS:         getDRS(schemedEntity, linkroleURI, h),
@@ -0,0 +1,296 @@
+package renderables
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+)
+
+const (
+	CSV = ','
+	TSV = '\t'
+)
+
+// MarshalDelimitedGrid writes the presentation, definition or calculation
+// grid of a renderable as CSV or TSV, depending on the comma
+func MarshalDelimitedGrid(slug string, h *hydratables.Hydratable, network string, lang Lang, comma rune, options ...RenderOption) ([]byte, error) {
+	r, err := GetRenderable(slug, h, options...)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	switch network {
+	case "", "presentation":
+		err = WritePGrid(&buf, r.PGrid, lang, comma)
+	case "definition":
+		err = WriteDGrid(&buf, r.DGrid, lang, comma)
+	case "calculation":
+		err = WriteCGrid(&buf, r.CGrid, lang, comma)
+	default:
+		return nil, fmt.Errorf("invalid grid %s", network)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WritePGrid writes one row per concept and one column per context, after
+// a row per dimension of the contexts
+func WritePGrid(w io.Writer, pGrid PGrid, lang Lang, comma rune) error {
+	return writeSections(w, pGridSections(pGrid, lang, ""), lang, comma)
+}
+
+// WriteDGrid writes the table of each root domain after a row of its label,
+// separated by empty rows
+func WriteDGrid(w io.Writer, dGrid DGrid, lang Lang, comma rune) error {
+	return writeSections(w, dGridSections(dGrid, lang, ""), lang, comma)
+}
+
+// WriteCGrid writes the table of each summation item after a row of its
+// label, separated by empty rows
+func WriteCGrid(w io.Writer, cGrid CGrid, lang Lang, comma rune) error {
+	return writeSections(w, cGridSections(cGrid, lang, ""), lang, comma)
+}
+
+func writeSections(w io.Writer, sections []gridSection, lang Lang, comma rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	for i, section := range sections {
+		if i > 0 {
+			writer.Write([]string{})
+		}
+		if section.heading != "" {
+			writer.Write([]string{section.heading})
+		}
+		writeDelimitedGrid(writer, section.grid, lang)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeDelimitedGrid(writer *csv.Writer, g grid, lang Lang) {
+	width := len(g.PeriodHeaders) + 1
+	for i, voidCell := range g.VoidQuadrant {
+		if voidCell == nil {
+			continue
+		}
+		record := make([]string, width)
+		record[0] = voidCell.Dimension.Label.Resolve(Default, lang)
+		if i < len(g.ContextualMemberGrid) {
+			for j, memberCell := range g.ContextualMemberGrid[i] {
+				if memberCell == nil || j+1 >= width {
+					continue
+				}
+				if memberCell.ExplicitMember != nil {
+					record[j+1] = memberCell.ExplicitMember.Label.Resolve(Default, lang)
+				} else {
+					record[j+1] = memberCell.TypedMember
+				}
+			}
+		}
+		writer.Write(record)
+	}
+	header := make([]string, width)
+	header[0] = "Concept"
+	for j, periodHeader := range g.PeriodHeaders {
+		text, found := periodHeader[lang]
+		if !found {
+			text = periodHeader[PureLabel]
+		}
+		header[j+1] = text
+	}
+	writer.Write(header)
+	for i, label := range g.rowLabels {
+		record := make([]string, width)
+		record[0] = label.text
+		if i < len(g.FactualQuadrant) {
+			for j, fact := range g.FactualQuadrant[i] {
+				if fact == nil || j+1 >= width {
+					continue
+				}
+				record[j+1] = delimitedFact(fact, lang)
+			}
+		}
+		writer.Write(record)
+	}
+}
+
+// delimitedFact is the plain number of a numeric fact, without the currency,
+// unit and grouping of its expression, or else its text
+func delimitedFact(fact *MultilingualFact, lang Lang) string {
+	if numeric := factNumeric(fact); numeric != nil {
+		return numeric.Number
+	}
+	return factText(fact, lang)
+}
+
+// MarshalDelimitedFacts writes every fact of the instances as a row of CSV
+// or TSV, depending on the comma
+func MarshalDelimitedFacts(h *hydratables.Hydratable, lang Lang, comma rune) ([]byte, error) {
+	var buf bytes.Buffer
+	err := WriteFacts(&buf, h, lang, comma)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteFacts writes one row per fact of the instances, with the concept
+// QName and label, entity, period, a column per dimension of the contexts,
+// unit, decimals and value
+func WriteFacts(w io.Writer, h *hydratables.Hydratable, lang Lang, comma rune) error {
+	fileNames := make([]string, 0, len(h.Instances))
+	for fileName := range h.Instances {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	dimensions := map[string]string{}
+	for _, fileName := range fileNames {
+		for _, context := range h.Instances[fileName].Contexts {
+			for _, dimensionContext := range []hydratables.DimensionContext{context.Entity.Segment, context.Scenario} {
+				for _, explicitMember := range dimensionContext.ExplicitMembers {
+					if _, found := dimensions[explicitMember.Dimension.Href]; !found {
+						dimensions[explicitMember.Dimension.Href] = explicitMember.Dimension.Value
+					}
+				}
+				for _, typedMember := range dimensionContext.TypedMembers {
+					if _, found := dimensions[typedMember.Dimension.Href]; !found {
+						dimensions[typedMember.Dimension.Href] = typedMember.Dimension.Value
+					}
+				}
+			}
+		}
+	}
+	dimensionHrefs := make([]string, 0, len(dimensions))
+	for href := range dimensions {
+		dimensionHrefs = append(dimensionHrefs, href)
+	}
+	sort.SliceStable(dimensionHrefs, func(p, q int) bool {
+		return dimensions[dimensionHrefs[p]] < dimensions[dimensionHrefs[q]]
+	})
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	header := []string{"Concept", "Label", "Entity Scheme", "Entity", "Period Start", "Period End", "Period Instant"}
+	for _, href := range dimensionHrefs {
+		header = append(header, dimensions[href])
+	}
+	header = append(header, "Unit", "Decimals", "Value")
+	writer.Write(header)
+	qnames := map[string]string{}
+	labels := map[string]string{}
+	for _, fileName := range fileNames {
+		instance := h.Instances[fileName]
+		prefixes := map[string]string{}
+		if instanceFile, found := h.Folder.Instances[fileName]; found {
+			for _, xmlAttr := range instanceFile.XMLAttrs {
+				if xmlAttr.Name.Space == "xmlns" {
+					prefixes[xmlAttr.Value] = xmlAttr.Name.Local
+				}
+			}
+		}
+		for _, fact := range instance.Facts {
+			context := getContext(&instance, fact.ContextRef)
+			if context == nil {
+				continue
+			}
+			if _, found := qnames[fact.Href]; !found {
+				qnames[fact.Href] = factQName(h, fact.Href, prefixes)
+				labels[fact.Href] = GetLabel(h, fact.Href).Resolve(Default, lang)
+			}
+			record := []string{
+				qnames[fact.Href],
+				labels[fact.Href],
+				context.Entity.Identifier.Scheme,
+				context.Entity.Identifier.CharData,
+				context.Period.Duration.StartDate,
+				context.Period.Duration.EndDate,
+				context.Period.Instant.CharData,
+			}
+			members := contextMembers(context)
+			for _, href := range dimensionHrefs {
+				record = append(record, members[href])
+			}
+			record = append(record, unitMeasures(&instance, fact.UnitRef), decimals(fact.Precision))
+			if fact.IsNil {
+				record = append(record, "")
+			} else {
+				record = append(record, xmlText(fact.XMLInner))
+			}
+			writer.Write(record)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func factQName(h *hydratables.Hydratable, href string, prefixes map[string]string) string {
+	namespace, concept, err := h.HashQuery(href)
+	if err != nil || concept == nil {
+		return href
+	}
+	if concept.XMLName.Space != "" {
+		namespace = concept.XMLName.Space
+	}
+	if prefix, found := prefixes[namespace]; found {
+		return prefix + ":" + concept.XMLName.Local
+	}
+	return "{" + namespace + "}" + concept.XMLName.Local
+}
+
+func contextMembers(context *hydratables.Context) map[string]string {
+	ret := map[string]string{}
+	for _, dimensionContext := range []hydratables.DimensionContext{context.Entity.Segment, context.Scenario} {
+		for _, explicitMember := range dimensionContext.ExplicitMembers {
+			ret[explicitMember.Dimension.Href] = explicitMember.Member.CharData
+		}
+		for _, typedMember := range dimensionContext.TypedMembers {
+			values := make([]string, 0, len(typedMember.TypedMembersMap))
+			for _, value := range typedMember.TypedMembersMap {
+				values = append(values, value)
+			}
+			sort.Strings(values)
+			ret[typedMember.Dimension.Href] = strings.Join(values, " ")
+		}
+	}
+	return ret
+}
+
+func unitMeasures(instance *hydratables.Instance, unitRef string) string {
+	if unitRef == "" {
+		return ""
+	}
+	for _, unit := range instance.Units {
+		if unit.ID != unitRef {
+			continue
+		}
+		measures := func(unitMeasures []hydratables.UnitMeasure) string {
+			ret := make([]string, 0, len(unitMeasures))
+			for _, unitMeasure := range unitMeasures {
+				ret = append(ret, unitMeasure.CharData)
+			}
+			return strings.Join(ret, "*")
+		}
+		if len(unit.Denominators) > 0 {
+			return measures(unit.Numerators) + "/" + measures(unit.Denominators)
+		}
+		return measures(unit.Numerators)
+	}
+	return unitRef
+}
+
+func decimals(precision hydratables.Precision) string {
+	switch precision {
+	case hydratables.Precisionless:
+		return ""
+	case hydratables.Exact:
+		return "INF"
+	}
+	return strconv.Itoa(int(precision))
+}
@@ -0,0 +1,381 @@
+package renderables
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+type DiagramFormat string
+
+const DOT = DiagramFormat("dot")
+const Mermaid = DiagramFormat("mermaid")
+
+// diagramShapes are the DOT attributes, and the Mermaid brackets, of each
+// kind of node
+var diagramShapes = map[string]struct {
+	dot   string
+	open  string
+	close string
+}{
+	"concept":   {`shape=box`, `["`, `"]`},
+	"abstract":  {`shape=box, style=rounded`, `("`, `")`},
+	"hypercube": {`shape=hexagon`, `{{"`, `"}}`},
+	"dimension": {`shape=diamond`, `{"`, `"}`},
+	"domain":    {`shape=ellipse`, `(["`, `"])`},
+	"instance":  {`shape=folder`, `[/"`, `"/]`},
+	"schema":    {`shape=component`, `[["`, `"]]`},
+	"linkbase":  {`shape=note`, `["`, `"]`},
+	"external":  {`shape=note, style=dashed`, `>"`, `"]`},
+}
+
+type diagramNode struct {
+	label string
+	kind  string
+}
+
+type diagramEdge struct {
+	from   int
+	to     int
+	label  string
+	dashed bool
+}
+
+// diagram is a directed graph of concepts or files, with the nodes in the
+// order they are first added
+type diagram struct {
+	title string
+	nodes []diagramNode
+	edges []diagramEdge
+	index map[string]int
+}
+
+func newDiagram(title string) *diagram {
+	return &diagram{
+		title: title,
+		index: map[string]int{},
+	}
+}
+
+func (d *diagram) node(key string, label string, kind string) int {
+	if i, found := d.index[key]; found {
+		return i
+	}
+	d.index[key] = len(d.nodes)
+	d.nodes = append(d.nodes, diagramNode{
+		label: label,
+		kind:  kind,
+	})
+	return len(d.nodes) - 1
+}
+
+func (d *diagram) edge(from int, to int, label string, dashed bool) {
+	d.edges = append(d.edges, diagramEdge{
+		from:   from,
+		to:     to,
+		label:  label,
+		dashed: dashed,
+	})
+}
+
+// MarshalDiagram draws the presentation hierarchy, the calculation tree with
+// its weights, or the DRS with its hypercubes, dimensions and domains, of
+// the role hashed into the slug, labelled in the lang
+func MarshalDiagram(slug string, h *hydratables.Hydratable, network string, format DiagramFormat, lang Lang) ([]byte, error) {
+	entity, rset, err := findSlug(slug, h)
+	if err != nil {
+		return nil, err
+	}
+	var d *diagram
+	switch network {
+	case "", "presentation":
+		d = relationshipDiagram(h, rset, attr.PresentationArcrole, lang)
+	case "calculation":
+		d = relationshipDiagram(h, rset, attr.CalculationArcrole, lang)
+	case "definition":
+		d = drsDiagram(getDRS(stringify(&entity), rset.RoleURI, h), rset, lang)
+	default:
+		return nil, fmt.Errorf("invalid network %s", network)
+	}
+	var buf bytes.Buffer
+	err = writeDiagram(&buf, d, format)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalDTSDiagram draws the files of the DTS, from the instances through
+// the schemas they import or include to the linkbases they reference
+func MarshalDTSDiagram(h *hydratables.Hydratable, format DiagramFormat) ([]byte, error) {
+	if h.Folder == nil {
+		return nil, fmt.Errorf("empty folder")
+	}
+	var buf bytes.Buffer
+	err := writeDiagram(&buf, dtsDiagram(h.Folder), format)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func relationshipDiagram(h *hydratables.Hydratable, rset RelationshipSet, arcrole string, lang Lang) *diagram {
+	d := newDiagram(rset.Title)
+	network := h.RelationshipNetwork(rset.RoleURI, arcrole)
+	node := func(href string) int {
+		kind := "concept"
+		if _, concept, err := h.HashQuery(href); err == nil && concept != nil && concept.Abstract {
+			kind = "abstract"
+		}
+		return d.node(href, GetLabel(h, href).Resolve(Default, lang), kind)
+	}
+	var walk func(href string, visited map[string]bool)
+	walk = func(href string, visited map[string]bool) {
+		from := node(href)
+		for _, relationship := range network.Children(href) {
+			to := node(relationship.To)
+			label := ""
+			if arcrole == attr.CalculationArcrole {
+				label = strconv.FormatFloat(relationship.Weight, 'f', -1, 64)
+				if relationship.Weight > 0 {
+					label = "+" + label
+				}
+			}
+			d.edge(from, to, label, false)
+			if visited[relationship.To] {
+				continue
+			}
+			visited[relationship.To] = true
+			walk(relationship.To, visited)
+		}
+	}
+	for _, root := range network.Roots() {
+		walk(root, map[string]bool{
+			root: true,
+		})
+	}
+	return d
+}
+
+func drsDiagram(drs DRS, rset RelationshipSet, lang Lang) *diagram {
+	d := newDiagram(rset.Title)
+	kinds := map[string]string{}
+	for _, link := range drs.Links {
+		switch {
+		case link.HypercubeConnection != nil:
+			kinds[link.TargetHref] = "hypercube"
+		case link.HypercubeDimensionConnection != nil:
+			kinds[link.TargetHref] = "dimension"
+		case link.DimensionDomainConnection != nil:
+			kinds[link.SourceHref] = "dimension"
+			kinds[link.TargetHref] = "domain"
+		}
+	}
+	// the members of a domain are of its kind, unlike the primary items
+	for changed := true; changed; {
+		changed = false
+		for _, link := range drs.Links {
+			if link.DomainMemberConnection != nil && kinds[link.SourceHref] == "domain" && kinds[link.TargetHref] == "" {
+				kinds[link.TargetHref] = "domain"
+				changed = true
+			}
+		}
+	}
+	labels := map[string]string{}
+	for _, node := range drs.Nodes {
+		labels[node.Href] = node.Label.Resolve(Default, lang)
+	}
+	node := func(href string) int {
+		kind := kinds[href]
+		if kind == "" {
+			kind = "concept"
+		}
+		label, found := labels[href]
+		if !found {
+			label = href
+		}
+		return d.node(href, label, kind)
+	}
+	for _, n := range drs.Nodes {
+		node(n.Href)
+	}
+	for _, link := range drs.Links {
+		from, to := node(link.SourceHref), node(link.TargetHref)
+		switch {
+		case link.HypercubeConnection != nil:
+			label := "all"
+			if !link.HypercubeConnection.IsInclusive {
+				label = "notAll"
+			}
+			if link.HypercubeConnection.IsClosed {
+				label += ", closed"
+			}
+			if link.HypercubeConnection.ContextElement != "" {
+				label += ", " + link.HypercubeConnection.ContextElement
+			}
+			d.edge(from, to, label, !link.HypercubeConnection.IsInclusive)
+		case link.HypercubeDimensionConnection != nil:
+			d.edge(from, to, "", false)
+		case link.DimensionDomainConnection != nil:
+			if link.DimensionDomainConnection.Default {
+				d.edge(from, to, "default", true)
+				continue
+			}
+			d.edge(from, to, usableLabel(link.DimensionDomainConnection.Usable), false)
+		case link.DomainMemberConnection != nil:
+			d.edge(from, to, usableLabel(link.DomainMemberConnection.Usable), false)
+		}
+	}
+	return d
+}
+
+func usableLabel(usable bool) string {
+	if usable {
+		return ""
+	}
+	return "unusable"
+}
+
+func dtsDiagram(folder *serializables.Folder) *diagram {
+	d := newDiagram(folder.EntryFileName)
+	file := func(href string, kind string) int {
+		if attr.IsValidUrl(href) {
+			kind = "external"
+		}
+		return d.node(href, href, kind)
+	}
+	instanceNames := make([]string, 0, len(folder.Instances))
+	for fileName := range folder.Instances {
+		instanceNames = append(instanceNames, fileName)
+	}
+	sort.Strings(instanceNames)
+	for _, fileName := range instanceNames {
+		from := file(fileName, "instance")
+		for _, schemaRef := range folder.Instances[fileName].SchemaRef {
+			hrefAttr := attr.FindAttr(schemaRef.XMLAttrs, "href")
+			if hrefAttr == nil || hrefAttr.Value == "" {
+				continue
+			}
+			d.edge(from, file(hrefAttr.Value, "schema"), "schemaRef", false)
+		}
+	}
+	if folder.Document != nil {
+		from := file(folder.EntryFileName, "instance")
+		for _, schemaRef := range folder.Document.SchemaRefs {
+			for _, xmlAttr := range schemaRef.Attr {
+				if xmlAttr.Name.Local == "href" && xmlAttr.Value != "" {
+					d.edge(from, file(xmlAttr.Value, "schema"), "schemaRef", false)
+				}
+			}
+		}
+	}
+	schemaNames := make([]string, 0, len(folder.Schemas))
+	for fileName := range folder.Schemas {
+		schemaNames = append(schemaNames, fileName)
+	}
+	sort.Strings(schemaNames)
+	for _, fileName := range schemaNames {
+		schema := folder.Schemas[fileName]
+		from := file(fileName, "schema")
+		for _, include := range schema.Include {
+			if schemaLocationAttr := attr.FindAttr(include.XMLAttrs, "schemaLocation"); schemaLocationAttr != nil && schemaLocationAttr.Value != "" {
+				d.edge(from, file(schemaLocationAttr.Value, "schema"), "include", false)
+			}
+		}
+		for _, imported := range schema.Import {
+			if schemaLocationAttr := attr.FindAttr(imported.XMLAttrs, "schemaLocation"); schemaLocationAttr != nil && schemaLocationAttr.Value != "" {
+				d.edge(from, file(schemaLocationAttr.Value, "schema"), "import", false)
+			}
+		}
+		for _, annotation := range schema.Annotation {
+			for _, appinfo := range annotation.Appinfo {
+				for _, linkbaseRef := range appinfo.LinkbaseRef {
+					hrefAttr := attr.FindAttr(linkbaseRef.XMLAttrs, "href")
+					if hrefAttr == nil || hrefAttr.Value == "" {
+						continue
+					}
+					label := "linkbaseRef"
+					if roleAttr := attr.FindAttr(linkbaseRef.XMLAttrs, "role"); roleAttr != nil && linkbaseKinds[roleAttr.Value] != "" {
+						label = linkbaseKinds[roleAttr.Value]
+					}
+					d.edge(from, file(hrefAttr.Value, "linkbase"), label, false)
+				}
+			}
+		}
+	}
+	return d
+}
+
+var linkbaseKinds = map[string]string{
+	attr.PresentationLinkbaseRef: "presentation",
+	attr.DefinitionLinkbaseRef:   "definition",
+	attr.CalculationLinkbaseRef:  "calculation",
+	attr.LabelLinkbaseRef:        "label",
+	attr.ReferenceLinkbaseRef:    "reference",
+}
+
+func writeDiagram(w io.Writer, d *diagram, format DiagramFormat) error {
+	var err error
+	printf := func(format string, a ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, a...)
+		}
+	}
+	switch format {
+	case DOT:
+		printf("digraph %s {\n\trankdir=LR;\n\tnode [fontname=\"Helvetica\"];\n", dotQuote(d.title))
+		for i, node := range d.nodes {
+			printf("\tn%d [label=%s, %s];\n", i, dotQuote(node.label), diagramShapes[node.kind].dot)
+		}
+		for _, edge := range d.edges {
+			attrs := make([]string, 0, 2)
+			if edge.label != "" {
+				attrs = append(attrs, "label="+dotQuote(edge.label))
+			}
+			if edge.dashed {
+				attrs = append(attrs, "style=dashed")
+			}
+			if len(attrs) > 0 {
+				printf("\tn%d -> n%d [%s];\n", edge.from, edge.to, strings.Join(attrs, ", "))
+			} else {
+				printf("\tn%d -> n%d;\n", edge.from, edge.to)
+			}
+		}
+		printf("}\n")
+	case Mermaid:
+		printf("---\ntitle: \"%s\"\n---\nflowchart LR\n", mermaidQuote(d.title))
+		for i, node := range d.nodes {
+			shape := diagramShapes[node.kind]
+			printf("\tn%d%s%s%s\n", i, shape.open, mermaidQuote(node.label), shape.close)
+		}
+		for _, edge := range d.edges {
+			arrow := "-->"
+			if edge.dashed {
+				arrow = "-.->"
+			}
+			if edge.label != "" {
+				arrow += "|\"" + mermaidQuote(edge.label) + "\"|"
+			}
+			printf("\tn%d %s n%d\n", edge.from, arrow, edge.to)
+		}
+	default:
+		return fmt.Errorf("invalid format %s", format)
+	}
+	return err
+}
+
+func dotQuote(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text) + `"`
+}
+
+// mermaidQuote escapes the text of a quoted Mermaid label with entity codes
+func mermaidQuote(text string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ", "|", "#124;").Replace(text)
+}
@@ -53,84 +53,83 @@ func getDRS(schemedEntity string, linkroleURI string, h *hydratables.Hydratable)
 	links := make([]DRSLink, 0, 20)
 	hrefs := make(map[string]int)
 
-	for _, definition := range h.DefinitionLinkbases {
-		var definitionLinks []hydratables.DefinitionLink
-		for _, roleRef := range definition.RoleRefs {
-			if linkroleURI == roleRef.RoleURI {
-				definitionLinks = definition.DefinitionLinks
-				break
-			}
+	definition := h.BaseSets.Definition
+	var definitionLinks []hydratables.DefinitionLink
+	for _, roleRef := range definition.RoleRefs {
+		if linkroleURI == roleRef.RoleURI {
+			definitionLinks = definition.DefinitionLinks
+			break
 		}
-		for _, definitionLink := range definitionLinks {
-			if definitionLink.Role == linkroleURI {
-				arcs := definitionLink.DefinitionArcs
-				for _, arc := range arcs {
-					var to string
-					if arc.TargetRole != "" {
-						to = mapDLocatorToHref(arc.TargetRole, &definition, arc.To)
-					} else {
-						to = mapDLocatorToHref(linkroleURI, &definition, arc.To)
+	}
+	for _, definitionLink := range definitionLinks {
+		if definitionLink.Role == linkroleURI {
+			arcs := definitionLink.DefinitionArcs
+			for _, arc := range arcs {
+				var to string
+				if arc.TargetRole != "" {
+					to = mapDLocatorToHref(arc.TargetRole, &definition, arc.To)
+				} else {
+					to = mapDLocatorToHref(linkroleURI, &definition, arc.To)
+				}
+				hrefs[to]++
+				from := mapDLocatorToHref(linkroleURI, &definition, arc.From)
+				hrefs[from]++
+				var (
+					hc *HypercubeConnection
+					hd *HypercubeDimensionConnection
+					dd *DimensionDomainConnection
+					dm *DomainMemberConnection
+				)
+				order := strconv.FormatFloat(arc.Order, 'f', -1, 64)
+				switch arc.Arcrole {
+				case attr.DomainMemberArcrole:
+					dm = &DomainMemberConnection{
+						Order:    order,
+						Usable:   arc.Usable,
+						External: arc.TargetRole,
+					}
+				case attr.HypercubeDimensionArcrole:
+					hd = &HypercubeDimensionConnection{
+						Order:    order,
+						External: arc.TargetRole,
+					}
+				case attr.DimensionDefaultArcrole:
+					dd = &DimensionDomainConnection{
+						Order:    order,
+						Default:  true,
+						Usable:   true,
+						External: arc.TargetRole,
+					}
+				case attr.DimensionDomainArcrole:
+					dd = &DimensionDomainConnection{
+						Order:    order,
+						Default:  false,
+						Usable:   arc.Usable,
+						External: arc.TargetRole,
+					}
+				case attr.HasInclusiveHypercubeArcrole:
+					hc = &HypercubeConnection{
+						IsClosed:       arc.Closed,
+						ContextElement: arc.ContextElement,
+						IsInclusive:    true,
+						External:       arc.TargetRole,
 					}
-					hrefs[to]++
-					from := mapDLocatorToHref(linkroleURI, &definition, arc.From)
-					hrefs[from]++
-					var (
-						hc *HypercubeConnection
-						hd *HypercubeDimensionConnection
-						dd *DimensionDomainConnection
-						dm *DomainMemberConnection
-					)
-					order := strconv.FormatFloat(arc.Order, 'f', -1, 64)
-					switch arc.Arcrole {
-					case attr.DomainMemberArcrole:
-						dm = &DomainMemberConnection{
-							Order:    order,
-							Usable:   arc.Usable,
-							External: arc.TargetRole,
-						}
-					case attr.HypercubeDimensionArcrole:
-						hd = &HypercubeDimensionConnection{
-							Order:    order,
-							External: arc.TargetRole,
-						}
-					case attr.DimensionDefaultArcrole:
-						dd = &DimensionDomainConnection{
-							Order:    order,
-							Default:  true,
-							Usable:   true,
-							External: arc.TargetRole,
-						}
-					case attr.DimensionDomainArcrole:
-						dd = &DimensionDomainConnection{
-							Order:    order,
-							Default:  true,
-							Usable:   arc.Usable,
-							External: arc.TargetRole,
-						}
-					case attr.HasInclusiveHypercubeArcrole:
-						hc = &HypercubeConnection{
-							IsClosed:       arc.Closed,
-							ContextElement: arc.ContextElement,
-							IsInclusive:    true,
-							External:       arc.TargetRole,
-						}
-					case attr.HasExclusiveHypercubeArcrole:
-						hc = &HypercubeConnection{
-							IsClosed:       arc.Closed,
-							ContextElement: arc.ContextElement,
-							IsInclusive:    false,
-							External:       arc.TargetRole,
-						}
+				case attr.HasExclusiveHypercubeArcrole:
+					hc = &HypercubeConnection{
+						IsClosed:       arc.Closed,
+						ContextElement: arc.ContextElement,
+						IsInclusive:    false,
+						External:       arc.TargetRole,
 					}
-					links = append(links, DRSLink{
-						SourceHref:                   from,
-						TargetHref:                   to,
-						DomainMemberConnection:       dm,
-						HypercubeConnection:          hc,
-						HypercubeDimensionConnection: hd,
-						DimensionDomainConnection:    dd,
-					})
 				}
+				links = append(links, DRSLink{
+					SourceHref:                   from,
+					TargetHref:                   to,
+					DomainMemberConnection:       dm,
+					HypercubeConnection:          hc,
+					HypercubeDimensionConnection: hd,
+					DimensionDomainConnection:    dd,
+				})
 			}
 		}
 	}
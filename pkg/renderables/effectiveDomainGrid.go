@@ -19,6 +19,7 @@ type RootDomain struct {
 	FactualQuadrant     FactualQuadrant
 	FootnoteGrid        [][][]int
 	Footnotes           []string
+	ProvenanceGrid      [][]*CellProvenance
 	EffectiveDomainGrid [][]EffectiveDomain
 	EffectiveDimensions []EffectiveDimension
 	DRSNodes            []DRSNode `json:",omitempty"`
@@ -58,98 +59,97 @@ func dArcs(dArcs []hydratables.DefinitionArc) []myarcs.Arc {
 }
 
 func getRootDomains(schemedEntity string, linkroleURI string, h *hydratables.Hydratable,
-	factFinder FactFinder, conceptFinder ConceptFinder, measurementFinder MeasurementFinder) ([]RootDomain, []LabelRole, []Lang) {
+	factFinder FactFinder, conceptFinder ConceptFinder, measurementFinder MeasurementFinder, renderers *FactRenderers) ([]RootDomain, []LabelRole, []Lang) {
 	ret := []RootDomain{}
 	labelRoles := []LabelRole{}
 	langs := []Lang{}
 	labelPacks := make([]LabelPack, 0, 100)
-	for _, definition := range h.DefinitionLinkbases {
-		var definitionLinks []hydratables.DefinitionLink
-		for _, roleRef := range definition.RoleRefs {
-			if linkroleURI == roleRef.RoleURI {
-				definitionLinks = definition.DefinitionLinks
-				break
-			}
+	definition := h.BaseSets.Definition
+	var definitionLinks []hydratables.DefinitionLink
+	for _, roleRef := range definition.RoleRefs {
+		if linkroleURI == roleRef.RoleURI {
+			definitionLinks = definition.DefinitionLinks
+			break
 		}
-		for _, definitionLink := range definitionLinks {
-			if definitionLink.Role == linkroleURI {
-				arcs := definitionLink.DefinitionArcs
-				indentedItems := make([]PrimaryItem, 0, len(arcs))
-				var makeIndents func(node *myarcs.RArc, level int)
-				makeIndents = func(node *myarcs.RArc, level int) {
-					if len(node.Children) <= 0 {
-						return
-					}
-					sort.SliceStable(node.Children, func(p, q int) bool {
-						return node.Children[p].Order < node.Children[q].Order
+	}
+	for _, definitionLink := range definitionLinks {
+		if definitionLink.Role == linkroleURI {
+			arcs := definitionLink.DefinitionArcs
+			indentedItems := make([]PrimaryItem, 0, len(arcs))
+			var makeIndents func(node *myarcs.RArc, level int)
+			makeIndents = func(node *myarcs.RArc, level int) {
+				if len(node.Children) <= 0 {
+					return
+				}
+				sort.SliceStable(node.Children, func(p, q int) bool {
+					return node.Children[p].Order < node.Children[q].Order
+				})
+				for _, c := range node.Children {
+					href := mapDLocatorToHref(linkroleURI, &definition, c.Locator)
+					piLabel := GetLabel(h, href)
+					labelPacks = append(labelPacks, piLabel)
+					indentedItems = append(indentedItems, PrimaryItem{
+						Href:  href,
+						Label: piLabel,
+						Level: level,
 					})
-					for _, c := range node.Children {
-						href := mapDLocatorToHref(linkroleURI, &definition, c.Locator)
-						piLabel := GetLabel(h, href)
-						labelPacks = append(labelPacks, piLabel)
-						indentedItems = append(indentedItems, PrimaryItem{
-							Href:  href,
-							Label: piLabel,
-							Level: level,
-						})
-						makeIndents(c, level+1)
-					}
+					makeIndents(c, level+1)
 				}
-				dArcs := dArcs(arcs)
-				domainMemberNetwork := graph.Tree(dArcs, attr.DomainMemberArcrole)
-				effectiveDimensions, effectiveDimensionHrefs, edLabelRoles, edLangs := getEffectiveDimensions(linkroleURI, arcs, h)
-				labelRoles = append(labelRoles, edLabelRoles...)
-				langs = append(langs, edLangs...)
-				dimensionDomainNetwork := graph.Tree(dArcs, attr.DimensionDomainArcrole)
-				defaultDimensionsNetwork := graph.Tree(dArcs, attr.DimensionDefaultArcrole)
-				primaryItemNetwork, explicitDomainNetwork :=
-					getPrimaryItemNetworkAndExplicitDomainNetwork(domainMemberNetwork, dimensionDomainNetwork,
-						defaultDimensionsNetwork)
-				exclusiveHypercubeNetwork := graph.Tree(dArcs, attr.HasExclusiveHypercubeArcrole)
-				inclusiveHypercubeNetwork := graph.Tree(dArcs, attr.HasInclusiveHypercubeArcrole)
-				hypercubeDimensionNetwork := graph.Tree(dArcs, attr.HypercubeDimensionArcrole)
-				for _, root := range primaryItemNetwork.Children {
-					indentedItems = make([]PrimaryItem, 0, len(root.Children))
-					makeIndents(root, 0)
-					rootHref := mapDLocatorToHref(linkroleURI, &definition, root.Locator)
-					primaryItemHrefs := []string{}
-					primaryItemHrefs = append(primaryItemHrefs, rootHref)
-					for _, indentedItem := range indentedItems {
-						primaryItemHrefs = append(primaryItemHrefs, indentedItem.Href)
-					}
-					locToHref := func(loc string) string {
-						return mapDLocatorToHref(linkroleURI, &definition, loc)
-					}
-					edGrid, edLabels := getEffectiveDomainGrid(primaryItemHrefs, effectiveDimensionHrefs,
-						dimensionDomainNetwork, primaryItemNetwork,
-						explicitDomainNetwork, exclusiveHypercubeNetwork, inclusiveHypercubeNetwork,
-						hypercubeDimensionNetwork, defaultDimensionsNetwork, locToHref, h)
-					labelPacks = append(labelPacks, edLabels...)
-					relevantContexts, segmentTypedDomainArcs, scenarioTypedDomainTrees, contextualLabelPack :=
-						getRelevantContexts(schemedEntity, h, primaryItemHrefs)
-					labelPacks = append(labelPacks, contextualLabelPack...)
-					rdLabelPack := GetLabel(h, rootHref)
-					labelPacks = append(labelPacks, rdLabelPack)
-					memberGrid, voidQuadrant := getMemberGridAndVoidQuadrant(relevantContexts, segmentTypedDomainArcs, scenarioTypedDomainTrees)
-					rootDomain := RootDomain{
-						PrimaryItems:         indentedItems,
-						Href:                 rootHref,
-						Label:                rdLabelPack,
-						PeriodHeaders:        getPeriodHeaders(relevantContexts),
-						ContextualMemberGrid: memberGrid,
-						VoidQuadrant:         voidQuadrant,
-						EffectiveDimensions:  effectiveDimensions,
-						EffectiveDomainGrid:  edGrid,
-					}
-					reduced := reduce(labelPacks)
-					if reduced != nil {
-						dLabelRoles, dLangs := destruct(*reduced)
-						labelRoles = append(labelRoles, dLabelRoles...)
-						langs = append(langs, dLangs...)
-					}
-					rootDomain = injectFactualQuadrant(rootDomain, relevantContexts, factFinder, conceptFinder, measurementFinder, langs)
-					ret = append(ret, rootDomain)
+			}
+			dArcs := dArcs(arcs)
+			domainMemberNetwork := graph.Tree(dArcs, attr.DomainMemberArcrole)
+			effectiveDimensions, effectiveDimensionHrefs, edLabelRoles, edLangs := getEffectiveDimensions(linkroleURI, arcs, h)
+			labelRoles = append(labelRoles, edLabelRoles...)
+			langs = append(langs, edLangs...)
+			dimensionDomainNetwork := graph.Tree(dArcs, attr.DimensionDomainArcrole)
+			defaultDimensionsNetwork := graph.Tree(dArcs, attr.DimensionDefaultArcrole)
+			primaryItemNetwork, explicitDomainNetwork :=
+				getPrimaryItemNetworkAndExplicitDomainNetwork(domainMemberNetwork, dimensionDomainNetwork,
+					defaultDimensionsNetwork)
+			exclusiveHypercubeNetwork := graph.Tree(dArcs, attr.HasExclusiveHypercubeArcrole)
+			inclusiveHypercubeNetwork := graph.Tree(dArcs, attr.HasInclusiveHypercubeArcrole)
+			hypercubeDimensionNetwork := graph.Tree(dArcs, attr.HypercubeDimensionArcrole)
+			for _, root := range primaryItemNetwork.Children {
+				indentedItems = make([]PrimaryItem, 0, len(root.Children))
+				makeIndents(root, 0)
+				rootHref := mapDLocatorToHref(linkroleURI, &definition, root.Locator)
+				primaryItemHrefs := []string{}
+				primaryItemHrefs = append(primaryItemHrefs, rootHref)
+				for _, indentedItem := range indentedItems {
+					primaryItemHrefs = append(primaryItemHrefs, indentedItem.Href)
+				}
+				locToHref := func(loc string) string {
+					return mapDLocatorToHref(linkroleURI, &definition, loc)
+				}
+				edGrid, edLabels := getEffectiveDomainGrid(primaryItemHrefs, effectiveDimensionHrefs,
+					dimensionDomainNetwork, primaryItemNetwork,
+					explicitDomainNetwork, exclusiveHypercubeNetwork, inclusiveHypercubeNetwork,
+					hypercubeDimensionNetwork, defaultDimensionsNetwork, locToHref, h)
+				labelPacks = append(labelPacks, edLabels...)
+				relevantContexts, segmentTypedDomainArcs, scenarioTypedDomainTrees, contextualLabelPack :=
+					getRelevantContexts(schemedEntity, h, primaryItemHrefs)
+				labelPacks = append(labelPacks, contextualLabelPack...)
+				rdLabelPack := GetLabel(h, rootHref)
+				labelPacks = append(labelPacks, rdLabelPack)
+				memberGrid, voidQuadrant := getMemberGridAndVoidQuadrant(relevantContexts, segmentTypedDomainArcs, scenarioTypedDomainTrees)
+				rootDomain := RootDomain{
+					PrimaryItems:         indentedItems,
+					Href:                 rootHref,
+					Label:                rdLabelPack,
+					PeriodHeaders:        getPeriodHeaders(relevantContexts),
+					ContextualMemberGrid: memberGrid,
+					VoidQuadrant:         voidQuadrant,
+					EffectiveDimensions:  effectiveDimensions,
+					EffectiveDomainGrid:  edGrid,
 				}
+				reduced := reduce(labelPacks)
+				if reduced != nil {
+					dLabelRoles, dLangs := destruct(*reduced)
+					labelRoles = append(labelRoles, dLabelRoles...)
+					langs = append(langs, dLangs...)
+				}
+				rootDomain = injectFactualQuadrant(rootDomain, relevantContexts, factFinder, conceptFinder, measurementFinder, langs, renderers)
+				ret = append(ret, rootDomain)
 			}
 		}
 	}
@@ -162,22 +162,21 @@ func getEffectiveDimensions(linkroleURI string,
 	effectiveDimensionMap := make(map[string]bool)
 	effectiveDimensions := make([]EffectiveDimension, 0, len(arcs))
 	effectiveDimensionHrefs := make([]string, 0, len(arcs))
-	for _, definition := range h.DefinitionLinkbases {
-		for _, arc := range arcs {
-			if arc.Arcrole == attr.HypercubeDimensionArcrole {
-				dim := mapDLocatorToHref(linkroleURI, &definition, arc.To)
-				if effectiveDimensionMap[dim] {
-					continue
-				}
-				effectiveDimensionMap[dim] = true
-				effectiveDimensionHrefs = append(effectiveDimensionHrefs, dim)
-				dimLabelPack := GetLabel(h, dim)
-				labelPacks = append(labelPacks, dimLabelPack)
-				effectiveDimensions = append(effectiveDimensions, EffectiveDimension{
-					Href:  dim,
-					Label: dimLabelPack,
-				})
+	definition := h.BaseSets.Definition
+	for _, arc := range arcs {
+		if arc.Arcrole == attr.HypercubeDimensionArcrole {
+			dim := mapDLocatorToHref(linkroleURI, &definition, arc.To)
+			if effectiveDimensionMap[dim] {
+				continue
 			}
+			effectiveDimensionMap[dim] = true
+			effectiveDimensionHrefs = append(effectiveDimensionHrefs, dim)
+			dimLabelPack := GetLabel(h, dim)
+			labelPacks = append(labelPacks, dimLabelPack)
+			effectiveDimensions = append(effectiveDimensions, EffectiveDimension{
+				Href:  dim,
+				Label: dimLabelPack,
+			})
 		}
 	}
 	reduced := reduce(labelPacks)
@@ -216,17 +215,18 @@ func getPrimaryItemNetworkAndExplicitDomainNetwork(domainMemberNetwork *myarcs.R
 
 func injectFactualQuadrant(incompleteRootDomain RootDomain, relevantContexts []relevantContext,
 	factFinder FactFinder, conceptFinder ConceptFinder, measurementFinder MeasurementFinder,
-	langs []Lang) RootDomain {
+	langs []Lang, renderers *FactRenderers) RootDomain {
 	hrefs := make([]string, 0, len(incompleteRootDomain.PrimaryItems)+1)
 	hrefs = append(hrefs, incompleteRootDomain.Href)
 	for _, primaryItem := range incompleteRootDomain.PrimaryItems {
 		hrefs = append(hrefs, primaryItem.Href)
 	}
 	factualQuadrant, footnoteGrid, footnotes := getFactualQuadrant(hrefs, relevantContexts, factFinder, conceptFinder,
-		measurementFinder, langs)
+		measurementFinder, langs, renderers)
 	incompleteRootDomain.FactualQuadrant = factualQuadrant
 	incompleteRootDomain.FootnoteGrid = footnoteGrid
 	incompleteRootDomain.Footnotes = footnotes
+	incompleteRootDomain.ProvenanceGrid = getProvenanceGrid(hrefs, relevantContexts, factFinder)
 	return incompleteRootDomain
 }
 
@@ -0,0 +1,17 @@
+package renderables
+
+import (
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+)
+
+func renderEnumeration(fact *hydratables.Fact, lf LabelFinder, lang Lang) *FactExpression {
+	labels := make([]string, 0, len(fact.Enumerations))
+	for _, href := range fact.Enumerations {
+		labels = append(labels, findLabel(lf, href).Resolve(Default, lang))
+	}
+	return &FactExpression{
+		Core: strings.Join(labels, ", "),
+	}
+}
@@ -18,7 +18,7 @@ type Expressable struct {
 	Footnotes  []string
 }
 
-func MarshalExpressable(name string, contextref string, h *hydratables.Hydratable) ([]byte, error) {
+func MarshalExpressable(name string, contextref string, h *hydratables.Hydratable, options ...RenderOption) ([]byte, error) {
 	xmlname, found := h.Document.NamespaceMap[name]
 	if !found {
 		return nil, nil
@@ -41,8 +41,9 @@ func MarshalExpressable(name string, contextref string, h *hydratables.Hydratabl
 	if hydratedFact == nil {
 		return nil, nil
 	}
+	renderers := NewFactRenderers(options...)
 	context := getContext(extracted, contextref)
-	period := periodMultilingualString(labels, context)
+	period := periodMultilingualString(labels, context, renderers)
 	entity := stringify(&Entity{
 		Scheme:   context.Entity.Identifier.Scheme,
 		CharData: context.Entity.Identifier.CharData,
@@ -75,7 +76,7 @@ func MarshalExpressable(name string, contextref string, h *hydratables.Hydratabl
 			VoidQuadrant:         voidQuadrant,
 			ContextualMemberGrid: memberGrid,
 		},
-		Expression: render(hydratedFact, h, h, []Lang{PureLabel}),
+		Expression: render(hydratedFact, h, h, []Lang{PureLabel}, renderers),
 		Footnotes:  footnoteTexts,
 	})
 }
@@ -1,10 +1,11 @@
 package renderables
 
 import (
+	"encoding/xml"
 	"fmt"
 	"math/big"
+	"strings"
 
-	"ecksbee.com/telefacts/pkg/attr"
 	"ecksbee.com/telefacts/pkg/hydratables"
 	"github.com/joshuanario/digits"
 )
@@ -15,10 +16,10 @@ type FactFinder interface {
 }
 
 type MeasurementFinder interface {
-	FindMeasurement(unitRef string) (*hydratables.Measurement, *hydratables.Measurement)
+	FindMeasurement(unitRef string) ([]hydratables.Measurement, []hydratables.Measurement)
 }
 
-func render(fact *hydratables.Fact, cf ConceptFinder, mf MeasurementFinder, langs []Lang) *MultilingualFact {
+func render(fact *hydratables.Fact, cf ConceptFinder, mf MeasurementFinder, langs []Lang, renderers *FactRenderers) *MultilingualFact {
 	ret := MultilingualFact{}
 	if fact == nil {
 		ret[PureLabel] = FactExpression{}
@@ -36,25 +37,87 @@ func render(fact *hydratables.Fact, cf ConceptFinder, mf MeasurementFinder, lang
 		}
 		return &ret
 	}
+	if lf, ok := cf.(LabelFinder); ok && len(fact.Enumerations) > 0 {
+		for _, lang := range langs {
+			ret[lang] = *renderEnumeration(fact, lf, lang)
+		}
+		return &ret
+	}
+	conceptTypes := []xml.Name{}
+	var numeric *NumericValue
+	if _, concept, err := cf.HashQuery(fact.Href); err == nil && concept != nil {
+		conceptTypes = concept.TypeHierarchy
+		numeric = numericValue(fact, concept, mf)
+	}
 	for _, lang := range langs {
-		switch lang {
-		case English:
-			ret[lang] = *renderEnglishFact(fact, cf, mf)
-		case Español:
-			ret[lang] = *renderSpanishFact(fact, cf, mf)
-		case PureLabel:
-			ret[lang] = *renderEnglishFact(fact, cf, mf)
-		default:
+		renderer, found := renderers.find(lang, conceptTypes)
+		if !found {
 			continue
 		}
+		expression := *renderer.Render(fact, cf, mf)
+		expression.Numeric = numeric
+		ret[lang] = expression
+	}
+	return &ret
+}
+
+// numericValue is the value of a fact of a numeric concept reported with
+// decimals, or nil for any other fact
+func numericValue(fact *hydratables.Fact, concept *hydratables.Concept, mf MeasurementFinder) *NumericValue {
+	if fact.Precision == hydratables.Precisionless || !concept.IsNumeric() ||
+		fact.Value == nil || fact.Value.Decimal == nil {
+		return nil
+	}
+	lexicalDecimals := 0
+	if _, fraction, found := strings.Cut(fact.Value.Text, "."); found {
+		lexicalDecimals = len(fraction)
+	}
+	decimals := 0
+	if fact.Precision == hydratables.Exact {
+		decimals = lexicalDecimals
+	} else if fact.Precision > 0 {
+		decimals = int(fact.Precision)
+	}
+	number := fact.Value.Decimal.FloatString(lexicalDecimals)
+	if strings.Contains(number, ".") {
+		number = strings.TrimRight(strings.TrimRight(number, "0"), ".")
+	}
+	isPercent := concept.IsPercent()
+	numerators, denominators := mf.FindMeasurement(fact.UnitRef)
+	unit := FactExpression{}
+	renderUnit(&unit, numerators, denominators, isPercent)
+	return &NumericValue{
+		Number:   number,
+		Decimals: decimals,
+		Percent:  isPercent,
+		Prefix:   strings.TrimSpace(unit.Head),
+		Suffix:   strings.TrimSpace(strings.TrimPrefix(unit.Tail, "%")),
+	}
+}
+
+// negateFact returns a copy of a numeric fact with its sign flipped, for rows
+// presented with a negated label role
+func negateFact(fact *hydratables.Fact) *hydratables.Fact {
+	if fact == nil || fact.IsNil || fact.Value == nil || fact.Value.Decimal == nil {
+		return fact
+	}
+	text := strings.TrimPrefix(strings.TrimSpace(fact.XMLInner), "+")
+	if strings.HasPrefix(text, "-") {
+		text = text[1:]
+	} else if fact.Value.Decimal.Sign() != 0 {
+		text = "-" + text
 	}
+	value := *fact.Value
+	value.Text = text
+	value.Decimal = new(big.Rat).Neg(fact.Value.Decimal)
+	ret := *fact
+	ret.XMLInner = text
+	ret.Value = &value
 	return &ret
 }
 
 func SigFigs(value string, precision hydratables.Precision, concept *hydratables.Concept, g rune) (*FactExpression, error) {
-	isPercent := concept.Type.Space == attr.NUM &&
-		concept.Type.Local == attr.PercentItemType
-	if isPercent {
+	if concept.IsPercent() {
 		return renderPercent(value, precision, g)
 	}
 	return renderNumeric(value, precision, g)
@@ -9,6 +9,18 @@ type FactExpression struct {
 	Core      string
 	Tail      string
 	InnerHtml string
+	Numeric   *NumericValue `json:",omitempty"`
+}
+
+// NumericValue is the value of a numeric fact, for the exports writing it as
+// a number: Number in decimal notation, the Decimals it is reported to, and
+// the currency symbol in Prefix and the other measures in Suffix
+type NumericValue struct {
+	Number   string
+	Decimals int
+	Percent  bool
+	Prefix   string
+	Suffix   string
 }
 
 type MultilingualFact map[Lang]FactExpression
@@ -17,7 +29,7 @@ type FactualQuadrant [][]*MultilingualFact
 
 func getFactualQuadrant(hrefs []string, relevantContexts []relevantContext,
 	factFinder FactFinder, conceptFinder ConceptFinder, measurementFinder MeasurementFinder,
-	langs []Lang) (FactualQuadrant, [][][]int, []string) {
+	langs []Lang, renderers *FactRenderers) (FactualQuadrant, [][][]int, []string) {
 	rowCount := len(hrefs)
 	colCount := len(relevantContexts)
 	if rowCount <= 0 || colCount <= 0 {
@@ -41,7 +53,7 @@ func getFactualQuadrant(hrefs []string, relevantContexts []relevantContext,
 					idMap[footnote.ID] = footnote
 				}
 			}
-			row[j] = render(fact, conceptFinder, measurementFinder, langs)
+			row[j] = render(fact, conceptFinder, measurementFinder, langs, renderers)
 			footnoteRow[j] = footnotes
 		}
 		ret[i] = row
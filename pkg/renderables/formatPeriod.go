@@ -3,21 +3,23 @@ package renderables
 import (
 	"strings"
 	"time"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
 )
 
-func formatPeriod(p PGrid, d DGrid, c CGrid, langs []Lang) (PGrid, DGrid, CGrid) {
-	p.PeriodHeaders = formatRelevantPeriod(p.PeriodHeaders, langs)
+func formatPeriod(p PGrid, d DGrid, c CGrid, langs []Lang, renderers *FactRenderers) (PGrid, DGrid, CGrid) {
+	p.PeriodHeaders = formatRelevantPeriod(p.PeriodHeaders, langs, renderers)
 	for _, rootDomain := range d.RootDomains {
-		rootDomain.PeriodHeaders = formatRelevantPeriod(rootDomain.PeriodHeaders, langs)
+		rootDomain.PeriodHeaders = formatRelevantPeriod(rootDomain.PeriodHeaders, langs, renderers)
 	}
 	for _, summationItem := range c.SummationItems {
-		summationItem.PeriodHeaders = formatRelevantPeriod(summationItem.PeriodHeaders, langs)
+		summationItem.PeriodHeaders = formatRelevantPeriod(summationItem.PeriodHeaders, langs, renderers)
 	}
 
 	return p, d, c
 }
 
-func formatRelevantPeriod(periodHeaders PeriodHeaders, langs []Lang) PeriodHeaders {
+func formatRelevantPeriod(periodHeaders PeriodHeaders, langs []Lang, renderers *FactRenderers) PeriodHeaders {
 	ret := make(PeriodHeaders, len(periodHeaders))
 	for i, ctx := range periodHeaders {
 		if _, foundPure := ctx[PureLabel]; !foundPure {
@@ -29,7 +31,7 @@ func formatRelevantPeriod(periodHeaders PeriodHeaders, langs []Lang) PeriodHeade
 				if lang == PureLabel {
 					continue
 				}
-				ctx[lang] = formatDate(lang, pureData)
+				ctx[lang] = formatDate(lang, pureData, renderers)
 			}
 		}
 		ret[i] = ctx
@@ -37,7 +39,31 @@ func formatRelevantPeriod(periodHeaders PeriodHeaders, langs []Lang) PeriodHeade
 	return periodHeaders
 }
 
-const iso8601 = "2006-01-02"
+const forever = "forever"
+
+type periodDate struct {
+	time.Time
+	HasClock bool
+}
+
+// parsePeriodDate reads a period value as the calendar date a reader expects,
+// so that an end date of 2021-01-01T00:00:00 is shown as 2020-12-31
+func parsePeriodDate(charData string, isEnd bool) (*periodDate, error) {
+	t, isDate, err := hydratables.ParsePeriodDate(charData, isEnd)
+	if err != nil {
+		return nil, err
+	}
+	isMidnight := t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+	if isEnd && isMidnight {
+		return &periodDate{
+			Time: t.AddDate(0, 0, -1),
+		}, nil
+	}
+	return &periodDate{
+		Time:     t,
+		HasClock: !isDate && !isMidnight,
+	}, nil
+}
 
 func defaultDate(start string, end string) string {
 	isDuration := start != ""
@@ -47,7 +73,7 @@ func defaultDate(start string, end string) string {
 	return end
 }
 
-func formatDate(lang Lang, date string) string {
+func formatDate(lang Lang, date string, renderers *FactRenderers) string {
 	i := strings.IndexRune(date, '/')
 	var start, end string
 	if i < 0 {
@@ -56,16 +82,14 @@ func formatDate(lang Lang, date string) string {
 		start = date[:i]
 		end = date[i+1:]
 	}
-	switch lang {
-	case PureLabel:
+	if lang == PureLabel {
 		return date
-	case English:
-		return formatEnglishDates(start, end)
-	case Español:
-		return formatSpanishDates(start, end)
-	default:
+	}
+	formatter, found := renderers.formatter(lang)
+	if !found {
 		return date
 	}
+	return formatter.formatDates(start, end)
 }
 
 func monthCount(start time.Time, end time.Time) int {
@@ -0,0 +1,160 @@
+package renderables
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"github.com/klauspost/lctime"
+)
+
+// Formatter writes fact values and period headers in the conventions of a
+// locale
+type Formatter struct {
+	GroupSeparator   string
+	DecimalSeparator string
+	IndianGrouping   bool
+	Forever          string
+	FormatDate       func(date time.Time, hasClock bool) (string, error)
+	AsOf             func(date string) string
+	MonthsEnded      func(months int, date string) string
+}
+
+// defaultFormatters are the formatters of FactRenderers, unless replaced with
+// WithFormatter
+var defaultFormatters = map[Lang]Formatter{
+	English:  englishFormatter,
+	Deutsch:  germanFormatter,
+	Français: frenchFormatter,
+	Español:  spanishFormatter,
+	Hindi:    hindiFormatter,
+}
+
+func strftimeDate(locale string, layout string) func(time.Time, bool) (string, error) {
+	return func(date time.Time, hasClock bool) (string, error) {
+		strf := layout
+		if hasClock {
+			strf += " %H:%M"
+		}
+		return lctime.StrftimeLoc(locale, strf, date)
+	}
+}
+
+func (formatter Formatter) formatDates(start string, end string) string {
+	if end == forever {
+		return formatter.Forever
+	}
+	isDuration := start != ""
+	endTime, err := parsePeriodDate(end, true)
+	if err != nil {
+		return defaultDate(start, end)
+	}
+	date, err := formatter.FormatDate(endTime.Time, endTime.HasClock)
+	if err != nil {
+		return defaultDate(start, end)
+	}
+	if isDuration {
+		startTime, err := parsePeriodDate(start, false)
+		if err != nil {
+			return defaultDate(start, end)
+		}
+		months := monthCount(startTime.Time, endTime.Time)
+		if months < 1 {
+			return defaultDate(start, end)
+		}
+		return formatter.MonthsEnded(months, date)
+	}
+	return formatter.AsOf(date)
+}
+
+func (formatter Formatter) Render(fact *hydratables.Fact, cf ConceptFinder, mf MeasurementFinder) *FactExpression {
+	_, concept, err := cf.HashQuery(fact.Href)
+	if err != nil {
+		return &FactExpression{
+			Core: "error",
+		}
+	}
+	textBlock := renderTextBlock(fact, cf, mf)
+	if textBlock != nil {
+		return textBlock
+	}
+	if fact.Precision == hydratables.Precisionless {
+		return &FactExpression{
+			Core: fact.XMLInner,
+		}
+	}
+	isPercent := concept.IsPercent()
+	numerators, denominators := mf.FindMeasurement(fact.UnitRef)
+	sigFig, err := SigFigs(fact.XMLInner, fact.Precision, concept, ',')
+	if err != nil {
+		return &FactExpression{
+			Core: fact.XMLInner,
+		}
+	}
+	formatter.localizeDigits(sigFig)
+	renderUnit(sigFig, numerators, denominators, isPercent)
+
+	return &FactExpression{
+		Head: sigFig.Head,
+		Core: sigFig.Core,
+		Tail: sigFig.Tail,
+	}
+}
+
+// localizeDigits regroups a number rendered with comma grouping and a decimal
+// point, keeping the significant digits in Core and the rest in Tail
+func (formatter Formatter) localizeDigits(sigFig *FactExpression) {
+	if formatter.GroupSeparator == "," && formatter.DecimalSeparator == "." && !formatter.IndianGrouping {
+		return
+	}
+	coreDigits := 0
+	for _, r := range sigFig.Core {
+		if unicode.IsDigit(r) {
+			coreDigits++
+		}
+	}
+	coreEndsWithDecimal := strings.HasSuffix(sigFig.Core, ".")
+	number := sigFig.Core + sigFig.Tail
+	closing := ""
+	if strings.HasSuffix(number, ")") {
+		closing = ")"
+		number = strings.TrimSuffix(number, ")")
+	}
+	integer, fraction, hasDecimal := strings.Cut(number, ".")
+	integer = strings.ReplaceAll(integer, ",", "")
+	localized := formatter.group(integer)
+	if hasDecimal {
+		localized += formatter.DecimalSeparator + fraction
+	}
+	localized += closing
+	i := 0
+	for n := 0; n < coreDigits && i < len(localized); {
+		r := []rune(localized[i:])[0]
+		if unicode.IsDigit(r) {
+			n++
+		}
+		i += len(string(r))
+	}
+	if coreEndsWithDecimal && strings.HasPrefix(localized[i:], formatter.DecimalSeparator) {
+		i += len(formatter.DecimalSeparator)
+	}
+	sigFig.Core = localized[:i]
+	sigFig.Tail = localized[i:]
+}
+
+// group separates the integer digits in thousands, or for Indian grouping in
+// a thousand followed by lakhs and crores, e.g. 1,23,45,678
+func (formatter Formatter) group(integer string) string {
+	groups := make([]string, 0, len(integer)/2+1)
+	size := 3
+	for len(integer) > size {
+		groups = append([]string{integer[len(integer)-size:]}, groups...)
+		integer = integer[:len(integer)-size]
+		if formatter.IndianGrouping {
+			size = 2
+		}
+	}
+	groups = append([]string{integer}, groups...)
+	return strings.Join(groups, formatter.GroupSeparator)
+}
@@ -0,0 +1,18 @@
+package renderables
+
+import (
+	"strconv"
+)
+
+var frenchFormatter = Formatter{
+	GroupSeparator:   "\u202f",
+	DecimalSeparator: ",",
+	Forever:          "permanent",
+	FormatDate:       strftimeDate("fr_FR", "%d %B %Y"),
+	AsOf: func(date string) string {
+		return "au " + date
+	},
+	MonthsEnded: func(months int, date string) string {
+		return strconv.Itoa(months) + " mois clos le " + date
+	},
+}
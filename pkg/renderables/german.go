@@ -0,0 +1,21 @@
+package renderables
+
+import (
+	"strconv"
+)
+
+var germanFormatter = Formatter{
+	GroupSeparator:   ".",
+	DecimalSeparator: ",",
+	Forever:          "unbefristet",
+	FormatDate:       strftimeDate("de_DE", "%d. %B %Y"),
+	AsOf: func(date string) string {
+		return "zum " + date
+	},
+	MonthsEnded: func(months int, date string) string {
+		if months == 1 {
+			return "1 Monat bis zum " + date
+		}
+		return strconv.Itoa(months) + " Monate bis zum " + date
+	},
+}
@@ -0,0 +1,204 @@
+package renderables
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// gridSection is a table of a grid, headed by its root domain or summation
+// item in the DGrid and CGrid. The rows of the sections are labelled with
+// the labelRole, or with the preferred labels when it is empty
+type gridSection struct {
+	heading string
+	grid
+}
+
+func pGridSections(pGrid PGrid, lang Lang, labelRole LabelRole) []gridSection {
+	rowLabels := make([]rowLabel, 0, len(pGrid.IndentedLabels))
+	for _, indentedLabel := range pGrid.IndentedLabels {
+		preferredLabel := labelRole
+		if preferredLabel == "" {
+			preferredLabel = indentedLabel.PreferredLabel
+		}
+		if preferredLabel == "" {
+			preferredLabel = Default
+		}
+		rowLabels = append(rowLabels, rowLabel{
+			text:   indentedLabel.Label.Resolve(preferredLabel, lang),
+			indent: indentedLabel.Indentation,
+		})
+	}
+	return []gridSection{{
+		grid: grid{
+			rowLabels:            rowLabels,
+			PeriodHeaders:        pGrid.PeriodHeaders,
+			ContextualMemberGrid: pGrid.ContextualMemberGrid,
+			VoidQuadrant:         pGrid.VoidQuadrant,
+			FactualQuadrant:      pGrid.FactualQuadrant,
+			FootnoteGrid:         pGrid.FootnoteGrid,
+			Footnotes:            pGrid.Footnotes,
+		},
+	}}
+}
+
+func dGridSections(dGrid DGrid, lang Lang, labelRole LabelRole) []gridSection {
+	if labelRole == "" {
+		labelRole = Default
+	}
+	ret := make([]gridSection, 0, len(dGrid.RootDomains))
+	for _, rootDomain := range dGrid.RootDomains {
+		rowLabels := make([]rowLabel, 0, len(rootDomain.PrimaryItems))
+		for _, primaryItem := range rootDomain.PrimaryItems {
+			rowLabels = append(rowLabels, rowLabel{
+				text:   primaryItem.Label.Resolve(labelRole, lang),
+				indent: primaryItem.Level,
+			})
+		}
+		ret = append(ret, gridSection{
+			heading: rootDomain.Label.Resolve(Default, lang),
+			grid: grid{
+				rowLabels:            rowLabels,
+				PeriodHeaders:        rootDomain.PeriodHeaders,
+				ContextualMemberGrid: rootDomain.ContextualMemberGrid,
+				VoidQuadrant:         rootDomain.VoidQuadrant,
+				FactualQuadrant:      rootDomain.FactualQuadrant,
+				FootnoteGrid:         rootDomain.FootnoteGrid,
+				Footnotes:            rootDomain.Footnotes,
+			},
+		})
+	}
+	return ret
+}
+
+func cGridSections(cGrid CGrid, lang Lang, labelRole LabelRole) []gridSection {
+	totalLabel := labelRole
+	if labelRole == "" {
+		labelRole = Default
+		totalLabel = Total
+	}
+	ret := make([]gridSection, 0, len(cGrid.SummationItems))
+	for _, summationItem := range cGrid.SummationItems {
+		rowLabels := make([]rowLabel, 0, len(summationItem.ContributingConcepts)+1)
+		for _, contributingConcept := range summationItem.ContributingConcepts {
+			rowLabels = append(rowLabels, rowLabel{
+				text:   contributingConcept.Sign + " " + contributingConcept.Label.Resolve(labelRole, lang),
+				indent: 1,
+			})
+		}
+		rowLabels = append(rowLabels, rowLabel{
+			text: summationItem.Label.Resolve(totalLabel, lang),
+			bold: true,
+		})
+		ret = append(ret, gridSection{
+			heading: summationItem.Label.Resolve(Default, lang),
+			grid: grid{
+				rowLabels:            rowLabels,
+				PeriodHeaders:        summationItem.PeriodHeaders,
+				ContextualMemberGrid: summationItem.ContextualMemberGrid,
+				VoidQuadrant:         summationItem.VoidQuadrant,
+				FactualQuadrant:      summationItem.FactualQuadrant,
+				FootnoteGrid:         summationItem.FootnoteGrid,
+				Footnotes:            summationItem.Footnotes,
+			},
+		})
+	}
+	return ret
+}
+
+type rowLabel struct {
+	text   string
+	indent int
+	bold   bool
+}
+
+type grid struct {
+	rowLabels []rowLabel
+	PeriodHeaders
+	ContextualMemberGrid
+	VoidQuadrant
+	FactualQuadrant FactualQuadrant
+	FootnoteGrid    [][][]int
+	Footnotes       []string
+}
+
+func factText(fact *MultilingualFact, lang Lang) string {
+	expression, found := (*fact)[lang]
+	if !found {
+		expression = (*fact)[PureLabel]
+	}
+	if expression.InnerHtml != "" {
+		return plainText(expression.InnerHtml)
+	}
+	return xmlText(expression.Head + expression.Core + expression.Tail)
+}
+
+// factNumeric is the value of a numeric fact, whatever the language of the
+// expression it is carried on
+func factNumeric(fact *MultilingualFact) *NumericValue {
+	for _, expression := range *fact {
+		if expression.Numeric != nil {
+			return expression.Numeric
+		}
+	}
+	return nil
+}
+
+// numberFormat is the spreadsheet number format of a numeric fact, with the
+// decimals it is reported to and its currency, percent sign and unit
+func numberFormat(numeric *NumericValue) string {
+	decimals := numeric.Decimals
+	if numeric.Percent {
+		decimals -= 2
+	}
+	ret := "#,##0"
+	if decimals > 0 {
+		ret += "." + strings.Repeat("0", decimals)
+	}
+	if numeric.Percent {
+		ret += "%"
+	}
+	if numeric.Prefix != "" {
+		ret = quoteNumFmt(numeric.Prefix+" ") + ret
+	}
+	if numeric.Suffix != "" {
+		ret += quoteNumFmt(" " + numeric.Suffix)
+	}
+	return ret + ";(" + ret + ")"
+}
+
+func quoteNumFmt(literal string) string {
+	return `"` + strings.ReplaceAll(literal, `"`, "") + `"`
+}
+
+// plainText flattens markup into a line of text, with the cells of tables
+// separated by pipes, their rows by slashes and other blocks by spaces
+func plainText(innerHtml string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(innerHtml))
+	var sb strings.Builder
+	for {
+		tt := tokenizer.Next()
+		token := tokenizer.Token()
+		switch tt {
+		case html.ErrorToken:
+			ret := strings.Join(strings.Fields(sb.String()), " ")
+			ret = strings.ReplaceAll(ret, "| /", "/")
+			return strings.TrimSuffix(strings.TrimSuffix(ret, " /"), " |")
+		case html.TextToken:
+			sb.WriteString(token.Data)
+		case html.SelfClosingTagToken, html.StartTagToken:
+			if token.Data == "br" {
+				sb.WriteString(" ")
+			}
+		case html.EndTagToken:
+			switch token.Data {
+			case "th", "td":
+				sb.WriteString(" | ")
+			case "tr":
+				sb.WriteString(" / ")
+			case "p", "div", "li", "h1", "h2", "h3", "h4", "h5", "h6":
+				sb.WriteString(" ")
+			}
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package renderables
+
+import (
+	"strconv"
+)
+
+var hindiFormatter = Formatter{
+	GroupSeparator:   ",",
+	DecimalSeparator: ".",
+	IndianGrouping:   true,
+	Forever:          "स्थायी",
+	FormatDate:       strftimeDate("hi_IN", "%d %B %Y"),
+	AsOf: func(date string) string {
+		return date + " तक"
+	},
+	MonthsEnded: func(months int, date string) string {
+		if months == 1 {
+			return date + " को समाप्त 1 महीना"
+		}
+		return date + " को समाप्त " + strconv.Itoa(months) + " महीने"
+	},
+}
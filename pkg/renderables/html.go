@@ -0,0 +1,401 @@
+package renderables
+
+import (
+	"bytes"
+	"html/template"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+
+	"golang.org/x/net/html"
+)
+
+const reportCSS = `body{font-family:sans-serif;font-size:10pt;margin:2em;color:#222}
+h1{font-size:14pt;margin-bottom:0}h2{font-size:12pt;margin-top:2em;border-bottom:1px solid #999}
+h3{font-size:10pt}nav{margin:.5em 0}nav a{margin-right:.75em}nav a.selected{font-weight:bold;text-decoration:none;color:#222}
+table{border-collapse:collapse;margin-bottom:1em}th,td{border:1px solid #ccc;padding:2px 6px;vertical-align:top}
+th{background:#f2f2f2}td.numeric{text-align:right;white-space:nowrap}tr.total td{font-weight:bold}
+sup{font-size:7pt}ol.footnotes{font-size:9pt}
+@media print{nav{display:none}body{margin:0}h2{page-break-before:always}h2:first-of-type{page-break-before:auto}table{page-break-inside:auto}tr{page-break-inside:avoid}}`
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}} - {{.Subject}}</title>
+<style>` + reportCSS + `</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Subject}}</p>
+{{if .Langs}}<nav>{{range .Langs}}<a href="{{.Href}}"{{if .Selected}} class="selected"{{end}}>{{.Text}}</a>{{end}}</nav>{{end}}
+{{if .LabelRoles}}<nav>{{range .LabelRoles}}<a href="{{.Href}}"{{if .Selected}} class="selected"{{end}}>{{.Text}}</a>{{end}}</nav>{{end}}
+{{range .Networks}}<h2>{{.Name}}</h2>
+{{range .Sections}}{{if .Heading}}<h3>{{.Heading}}</h3>
+{{end}}<table>
+<thead>
+{{range .MemberRows}}<tr><th style="padding-left:{{.Indent}}em">{{.Label}}</th>{{range .Members}}<th>{{.}}</th>{{end}}</tr>
+{{end}}<tr><th></th>{{range .PeriodHeaders}}<th>{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{$id := .ID}}{{range .Rows}}<tr{{if .Bold}} class="total"{{end}}><td style="padding-left:{{.Indent}}em">{{.Label}}</td>{{range .Cells}}<td{{if .Numeric}} class="numeric"{{end}}>{{.Text}}{{range .Markers}}<sup><a href="#{{$id}}-{{.}}">{{.}}</a></sup>{{end}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+{{if .Footnotes}}<ol class="footnotes">{{range .Footnotes}}<li id="{{$id}}-{{.Marker}}">{{.Text}}</li>{{end}}</ol>
+{{end}}{{end}}{{end}}</body>
+</html>
+`))
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.DocumentName}}</title>
+<style>` + reportCSS + `</style>
+</head>
+<body>
+<h1>{{.DocumentName}}</h1>
+{{range .Subjects}}<h2>{{.Name}}</h2>
+<ul>
+{{range .Networks}}<li><a href="{{.Href}}">{{.Text}}</a></li>
+{{end}}</ul>
+{{end}}</body>
+</html>
+`))
+
+type htmlLink struct {
+	Text     string
+	Href     string
+	Selected bool
+}
+
+type htmlMemberRow struct {
+	Label   string
+	Indent  int
+	Members []string
+}
+
+type htmlCell struct {
+	Text    template.HTML
+	Numeric bool
+	Markers []int
+}
+
+type htmlRow struct {
+	Label  string
+	Indent int
+	Bold   bool
+	Cells  []htmlCell
+}
+
+type htmlFootnote struct {
+	Marker int
+	Text   template.HTML
+}
+
+type htmlSection struct {
+	ID            string
+	Heading       string
+	MemberRows    []htmlMemberRow
+	PeriodHeaders []string
+	Rows          []htmlRow
+	Footnotes     []htmlFootnote
+}
+
+type htmlNetwork struct {
+	Name     string
+	Sections []htmlSection
+}
+
+type htmlReport struct {
+	Lang       string
+	Title      string
+	Subject    string
+	Langs      []htmlLink
+	LabelRoles []htmlLink
+	Networks   []htmlNetwork
+}
+
+// MarshalHTML renders the PGrid, DGrid and CGrid of a renderable as a
+// standalone, printable HTML report, labelled in the lang with the label
+// role, or with the preferred labels when labelRole is empty
+func MarshalHTML(slug string, h *hydratables.Hydratable, lang Lang, labelRole LabelRole, options ...RenderOption) ([]byte, error) {
+	r, err := GetRenderable(slug, h, options...)
+	if err != nil {
+		return nil, err
+	}
+	report := htmlReport{
+		Lang:    lang.Tag().String(),
+		Title:   r.RelationshipSet.Title,
+		Subject: r.Subject.Name,
+	}
+	switcher := func(l Lang, lr LabelRole) string {
+		query := url.Values{}
+		query.Set("lang", l.Tag().String())
+		if lr != "" {
+			query.Set("labelRole", string(lr))
+		}
+		return "?" + query.Encode()
+	}
+	for _, l := range r.Lang {
+		if l == PureLabel || l == BriefLabel {
+			continue
+		}
+		report.Langs = append(report.Langs, htmlLink{
+			Text:     string(l),
+			Href:     switcher(l, labelRole),
+			Selected: l == lang,
+		})
+	}
+	if len(r.LabelRoles) > 0 {
+		report.LabelRoles = append(report.LabelRoles, htmlLink{
+			Text:     "Preferred",
+			Href:     switcher(lang, ""),
+			Selected: labelRole == "",
+		})
+	}
+	for _, lr := range r.LabelRoles {
+		report.LabelRoles = append(report.LabelRoles, htmlLink{
+			Text:     string(lr),
+			Href:     switcher(lang, lr),
+			Selected: lr == labelRole,
+		})
+	}
+	networks := []struct {
+		name     string
+		sections []gridSection
+	}{
+		{"Presentation", pGridSections(r.PGrid, lang, labelRole)},
+		{"Definition", dGridSections(r.DGrid, lang, labelRole)},
+		{"Calculation", cGridSections(r.CGrid, lang, labelRole)},
+	}
+	for i, network := range networks {
+		htmlNetwork := htmlNetwork{
+			Name: network.name,
+		}
+		for j, section := range network.sections {
+			htmlNetwork.Sections = append(htmlNetwork.Sections,
+				newHTMLSection("fn"+strconv.Itoa(i+1)+"-"+strconv.Itoa(j+1), section, lang))
+		}
+		report.Networks = append(report.Networks, htmlNetwork)
+	}
+	var buf bytes.Buffer
+	err = reportTemplate.Execute(&buf, report)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func newHTMLSection(id string, section gridSection, lang Lang) htmlSection {
+	g := section.grid
+	ret := htmlSection{
+		ID:      id,
+		Heading: section.heading,
+	}
+	for i, voidCell := range g.VoidQuadrant {
+		if voidCell == nil {
+			continue
+		}
+		memberRow := htmlMemberRow{
+			Label:   voidCell.Dimension.Label.Resolve(Default, lang),
+			Indent:  voidCell.Indentation,
+			Members: make([]string, len(g.PeriodHeaders)),
+		}
+		if i < len(g.ContextualMemberGrid) {
+			for j, memberCell := range g.ContextualMemberGrid[i] {
+				if memberCell == nil || j >= len(memberRow.Members) {
+					continue
+				}
+				if memberCell.ExplicitMember != nil {
+					memberRow.Members[j] = memberCell.ExplicitMember.Label.Resolve(Default, lang)
+				} else {
+					memberRow.Members[j] = memberCell.TypedMember
+				}
+			}
+		}
+		ret.MemberRows = append(ret.MemberRows, memberRow)
+	}
+	for _, periodHeader := range g.PeriodHeaders {
+		header, found := periodHeader[lang]
+		if !found {
+			header = periodHeader[PureLabel]
+		}
+		ret.PeriodHeaders = append(ret.PeriodHeaders, header)
+	}
+	for i, label := range g.rowLabels {
+		row := htmlRow{
+			Label:  label.text,
+			Indent: label.indent,
+			Bold:   label.bold,
+			Cells:  make([]htmlCell, len(g.PeriodHeaders)),
+		}
+		if i < len(g.FactualQuadrant) {
+			for j, fact := range g.FactualQuadrant[i] {
+				if fact == nil || j >= len(row.Cells) {
+					continue
+				}
+				row.Cells[j].Text = factHTML(fact, lang)
+				row.Cells[j].Numeric = factNumeric(fact) != nil
+				if i < len(g.FootnoteGrid) && j < len(g.FootnoteGrid[i]) {
+					for _, k := range g.FootnoteGrid[i][j] {
+						if k < 1 || k > len(g.Footnotes) {
+							continue
+						}
+						row.Cells[j].Markers = append(row.Cells[j].Markers, k)
+					}
+				}
+			}
+		}
+		ret.Rows = append(ret.Rows, row)
+	}
+	for k, footnote := range g.Footnotes {
+		ret.Footnotes = append(ret.Footnotes, htmlFootnote{
+			Marker: k + 1,
+			Text:   sanitizeHTML(footnote),
+		})
+	}
+	return ret
+}
+
+// factHTML is the markup of a text block fact, sanitized, or else the
+// escaped text of the fact
+func factHTML(fact *MultilingualFact, lang Lang) template.HTML {
+	expression, found := (*fact)[lang]
+	if !found {
+		expression = (*fact)[PureLabel]
+	}
+	if expression.InnerHtml != "" {
+		return sanitizeHTML(expression.InnerHtml)
+	}
+	return template.HTML(template.HTMLEscapeString(factText(fact, lang)))
+}
+
+// htmlElements are the elements kept by sanitizeHTML, with the attributes
+// kept on each
+var htmlElements = map[string][]string{
+	"table": nil, "thead": nil, "tbody": nil, "tfoot": nil, "caption": nil,
+	"tr": nil, "th": {"colspan", "rowspan"}, "td": {"colspan", "rowspan"},
+	"p": nil, "div": nil, "span": nil, "br": nil, "hr": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"ul": nil, "ol": nil, "li": nil, "dl": nil, "dt": nil, "dd": nil,
+	"b": nil, "strong": nil, "i": nil, "em": nil, "u": nil, "sub": nil, "sup": nil,
+	"small": nil, "pre": nil, "blockquote": nil,
+}
+
+// sanitizeHTML keeps the text and the layout elements of markup, whatever
+// their namespace prefix, dropping the other elements, the content of
+// scripts and styles, and every attribute but the spans of table cells. The
+// elements are balanced, so the markup cannot close the cell it is put in
+func sanitizeHTML(markup string) template.HTML {
+	tokenizer := html.NewTokenizer(strings.NewReader(markup))
+	var sb strings.Builder
+	open := []string{}
+	skip := 0
+	for {
+		tt := tokenizer.Next()
+		if tt == html.ErrorToken {
+			for i := len(open) - 1; i >= 0; i-- {
+				sb.WriteString("</" + open[i] + ">")
+			}
+			return template.HTML(sb.String())
+		}
+		token := tokenizer.Token()
+		name := token.Data
+		if i := strings.LastIndex(name, ":"); i > -1 {
+			name = name[i+1:]
+		}
+		switch tt {
+		case html.TextToken:
+			if skip == 0 {
+				sb.WriteString(html.EscapeString(token.Data))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if name == "script" || name == "style" {
+				if tt == html.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			attrs, found := htmlElements[name]
+			if !found || skip > 0 {
+				continue
+			}
+			sb.WriteString("<" + name)
+			for _, a := range token.Attr {
+				for _, key := range attrs {
+					if a.Namespace == "" && a.Key == key {
+						sb.WriteString(" " + key + `="` + html.EscapeString(a.Val) + `"`)
+					}
+				}
+			}
+			if tt == html.SelfClosingTagToken || name == "br" || name == "hr" {
+				sb.WriteString(" />")
+				continue
+			}
+			sb.WriteString(">")
+			open = append(open, name)
+		case html.EndTagToken:
+			if name == "script" || name == "style" {
+				if skip > 0 {
+					skip--
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != name {
+					continue
+				}
+				for len(open) > i {
+					sb.WriteString("</" + open[len(open)-1] + ">")
+					open = open[:len(open)-1]
+				}
+				break
+			}
+		}
+	}
+}
+
+// MarshalCatalogHTML renders the catalog as an HTML index page linking each
+// network of each subject to its report
+func MarshalCatalogHTML(h *hydratables.Hydratable) ([]byte, error) {
+	catalog := GetCatalog(h)
+	type subject struct {
+		Name     string
+		Networks []htmlLink
+	}
+	index := struct {
+		DocumentName string
+		Subjects     []subject
+	}{
+		DocumentName: catalog.DocumentName,
+	}
+	for _, s := range catalog.Subjects {
+		item := subject{
+			Name: s.Name,
+		}
+		networks := catalog.Networks[stringify(&s.Entity)]
+		for _, rset := range catalog.RelationshipSets {
+			slug, found := networks[rset.RoleURI]
+			if !found {
+				continue
+			}
+			item.Networks = append(item.Networks, htmlLink{
+				Text: rset.Title,
+				Href: slug + ".html",
+			})
+		}
+		index.Subjects = append(index.Subjects, item)
+	}
+	var buf bytes.Buffer
+	err := indexTemplate.Execute(&buf, index)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
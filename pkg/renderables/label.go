@@ -3,11 +3,18 @@ package renderables
 import (
 	"strings"
 
-	"ecksbee.com/telefacts/pkg/attr"
 	"ecksbee.com/telefacts/pkg/hydratables"
 )
 
+type LabelFinder interface {
+	FindLabels(href string) []hydratables.LabelLinkLabel
+}
+
 func GetLabel(h *hydratables.Hydratable, href string) LabelPack {
+	return findLabel(h, href)
+}
+
+func findLabel(lf LabelFinder, href string) LabelPack {
 	ret := LabelPack{}
 	ret[Default] = make(LanguagePack)
 	ret[Default][PureLabel] = href
@@ -15,64 +22,29 @@ func GetLabel(h *hydratables.Hydratable, href string) LabelPack {
 	if index > -1 {
 		ret[Default][BriefLabel] = href[index:]
 	}
-	ret = appendLabelModifiersFromHref(ret, h, href)
+	ret = appendLabelModifiersFromHref(ret, lf, href)
 	return ret
 }
 
-func appendLabelModifiersFromHref(labelPack LabelPack, h *hydratables.Hydratable, href string) LabelPack {
+func appendLabelModifiersFromHref(labelPack LabelPack, lf LabelFinder, href string) LabelPack {
 	ret := labelPack
-	for _, labels := range h.LabelLinkbases {
-		for _, labelLink := range labels.LabelLink {
-			for _, loc := range labelLink.Locs {
-				if loc.Href == href {
-					labelArcs := labelLink.LabelArcs
-					for _, labelArc := range labelArcs {
-						if labelArc.From == loc.Label && labelArc.Arcrole == attr.LabelArcrole {
-							for _, labelLinkLabel := range labelLink.Labels {
-								if labelLinkLabel.Label == labelArc.To {
-									charData := labelLinkLabel.CharData
-									switch labelLinkLabel.Role {
-									case attr.Label:
-										if _, found := ret[Default]; !found {
-											ret[Default] = make(LanguagePack)
-										}
-										ret = appendLanguage(&labelLinkLabel, Default, charData, ret)
-										break
-									case attr.TerseLabel:
-										if _, found := ret[Terse]; !found {
-											ret[Terse] = make(LanguagePack)
-										}
-										ret = appendLanguage(&labelLinkLabel, Terse, charData, ret)
-										break
-									case attr.VerboseLabel:
-										if _, found := ret[Verbose]; !found {
-											ret[Verbose] = make(LanguagePack)
-										}
-										ret = appendLanguage(&labelLinkLabel, Verbose, charData, ret)
-										break
-									default: //noop
-									}
-								}
-							}
-						}
-					}
-				}
-			}
+	for _, labelLinkLabel := range lf.FindLabels(href) {
+		labelRole, found := labelRoles[labelLinkLabel.Role]
+		if !found {
+			continue
+		}
+		if _, found := ret[labelRole]; !found {
+			ret[labelRole] = make(LanguagePack)
 		}
+		ret = appendLanguage(&labelLinkLabel, labelRole, labelLinkLabel.CharData, ret)
 	}
 	return ret
 }
 
 func appendLanguage(labelLinkLabel *hydratables.LabelLinkLabel, labelRole LabelRole, charData string, labelPack LabelPack) LabelPack {
-	switch labelLinkLabel.Lang {
-	case "en":
-	case "en-US":
-		labelPack[labelRole][English] = charData
-		break
-	case "es":
-		labelPack[labelRole][Español] = charData
-		break
-	default: //noop
+	if labelLinkLabel.Lang == "" {
+		return labelPack
 	}
+	labelPack[labelRole][NewLang(labelLinkLabel.Lang)] = charData
 	return labelPack
 }
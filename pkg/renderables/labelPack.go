@@ -1,11 +1,90 @@
 package renderables
 
+import (
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
 type LabelRole string
 type Lang string
 
 const Default = LabelRole("Default")
 const Terse = LabelRole("Terse")
 const Verbose = LabelRole("Verbose")
+const Total = LabelRole("Total")
+const PeriodStart = LabelRole("PeriodStart")
+const PeriodEnd = LabelRole("PeriodEnd")
+const Positive = LabelRole("Positive")
+const PositiveTerse = LabelRole("PositiveTerse")
+const PositiveVerbose = LabelRole("PositiveVerbose")
+const Negative = LabelRole("Negative")
+const NegativeTerse = LabelRole("NegativeTerse")
+const NegativeVerbose = LabelRole("NegativeVerbose")
+const Zero = LabelRole("Zero")
+const ZeroTerse = LabelRole("ZeroTerse")
+const ZeroVerbose = LabelRole("ZeroVerbose")
+const Net = LabelRole("Net")
+const Negated = LabelRole("Negated")
+const NegatedTerse = LabelRole("NegatedTerse")
+const NegatedTotal = LabelRole("NegatedTotal")
+const NegatedPeriodStart = LabelRole("NegatedPeriodStart")
+const NegatedPeriodEnd = LabelRole("NegatedPeriodEnd")
+const NegatedNet = LabelRole("NegatedNet")
+const Documentation = LabelRole("Documentation")
+const DefinitionGuidance = LabelRole("DefinitionGuidance")
+const DisclosureGuidance = LabelRole("DisclosureGuidance")
+const PresentationGuidance = LabelRole("PresentationGuidance")
+const MeasurementGuidance = LabelRole("MeasurementGuidance")
+const CommentaryGuidance = LabelRole("CommentaryGuidance")
+const ExampleGuidance = LabelRole("ExampleGuidance")
+
+var labelRoles = map[string]LabelRole{
+	attr.Label:                   Default,
+	attr.TerseLabel:              Terse,
+	attr.VerboseLabel:            Verbose,
+	attr.GenericLabel:            Default,
+	attr.GenericTerseLabel:       Terse,
+	attr.GenericVerboseLabel:     Verbose,
+	attr.TotalLabel:              Total,
+	attr.PeriodStartLabel:        PeriodStart,
+	attr.PeriodEndLabel:          PeriodEnd,
+	attr.PositiveLabel:           Positive,
+	attr.PositiveTerseLabel:      PositiveTerse,
+	attr.PositiveVerboseLabel:    PositiveVerbose,
+	attr.NegativeLabel:           Negative,
+	attr.NegativeTerseLabel:      NegativeTerse,
+	attr.NegativeVerboseLabel:    NegativeVerbose,
+	attr.ZeroLabel:               Zero,
+	attr.ZeroTerseLabel:          ZeroTerse,
+	attr.ZeroVerboseLabel:        ZeroVerbose,
+	attr.NetLabel:                Net,
+	attr.NegatedLabel:            Negated,
+	attr.NegatedTerseLabel:       NegatedTerse,
+	attr.NegatedTotalLabel:       NegatedTotal,
+	attr.NegatedPeriodStartLabel: NegatedPeriodStart,
+	attr.NegatedPeriodEndLabel:   NegatedPeriodEnd,
+	attr.NegatedNetLabel:         NegatedNet,
+	attr.Documentation:           Documentation,
+	attr.DefinitionGuidance:      DefinitionGuidance,
+	attr.DisclosureGuidance:      DisclosureGuidance,
+	attr.PresentationGuidance:    PresentationGuidance,
+	attr.MeasurementGuidance:     MeasurementGuidance,
+	attr.CommentaryGuidance:      CommentaryGuidance,
+	attr.ExampleGuidance:         ExampleGuidance,
+}
+
+// IsNegated reports whether facts labelled with the role are displayed with
+// their sign flipped
+func IsNegated(labelRole LabelRole) bool {
+	switch labelRole {
+	case Negated, NegatedTerse, NegatedTotal, NegatedPeriodStart, NegatedPeriodEnd, NegatedNet:
+		return true
+	}
+	return false
+}
 
 type LabelPack map[LabelRole]LanguagePack
 
@@ -27,6 +106,77 @@ func getLabel(labelPack LabelPack, labelRole LabelRole, lang Lang) string {
 	return labelPack[labelRole][lang]
 }
 
+// NewLang maps a BCP 47 language tag, such as an xml:lang value, to a Lang.
+// A tag whose region is the most likely one for its language, like en-US,
+// maps to the bare language.
+func NewLang(bcp47 string) Lang {
+	tag, err := language.Parse(bcp47)
+	if err != nil {
+		return Lang(strings.ToLower(bcp47) + " - " + bcp47)
+	}
+	base, _ := tag.Base()
+	region, confidence := tag.Region()
+	likelyRegion, _ := language.Make(base.String()).Region()
+	if confidence == language.Exact && region == likelyRegion {
+		script, scriptConfidence := tag.Script()
+		if scriptConfidence == language.Exact {
+			tag, _ = language.Compose(base, script)
+		} else {
+			tag, _ = language.Compose(base)
+		}
+	}
+	return Lang(strings.ToLower(tag.String()) + " - " + strings.ToLower(display.Self.Name(tag)))
+}
+
+// Tag is the BCP 47 language tag of the Lang, or und for PureLabel and
+// BriefLabel
+func (lang Lang) Tag() language.Tag {
+	i := strings.Index(string(lang), " - ")
+	if i < 0 {
+		return language.Und
+	}
+	tag, err := language.Parse(string(lang)[:i])
+	if err != nil {
+		return language.Und
+	}
+	return tag
+}
+
+// Fallbacks lists the Langs tried, in order, when a label is missing in the
+// Lang, e.g. fr-CA, fr then en
+func (lang Lang) Fallbacks() []Lang {
+	ret := []Lang{lang}
+	tag := lang.Tag()
+	for !tag.IsRoot() {
+		tag = tag.Parent()
+		if tag.IsRoot() {
+			break
+		}
+		parent := NewLang(tag.String())
+		if parent != ret[len(ret)-1] {
+			ret = append(ret, parent)
+		}
+	}
+	if ret[len(ret)-1] != English {
+		ret = append(ret, English)
+	}
+	return ret
+}
+
+// Resolve finds the label of the role in the Lang, falling back through the
+// parent languages, then English and finally the truncated href
+func (labelPack LabelPack) Resolve(labelRole LabelRole, lang Lang) string {
+	for _, fallback := range lang.Fallbacks() {
+		if label := getLabel(labelPack, labelRole, fallback); label != "" {
+			return label
+		}
+	}
+	if labelRole != Default {
+		return labelPack.Resolve(Default, lang)
+	}
+	return strings.TrimPrefix(getLabel(labelPack, Default, BriefLabel), "#")
+}
+
 func reduce(labelPacks []LabelPack) *LabelPack {
 	if len(labelPacks) <= 0 {
 		return nil
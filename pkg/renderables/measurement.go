@@ -0,0 +1,64 @@
+package renderables
+
+import (
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/hydratables"
+)
+
+func measurementSymbol(measurement hydratables.Measurement) string {
+	if measurement.Symbol != "" {
+		return measurement.Symbol
+	}
+	return measurement.UnitName
+}
+
+func measurementProduct(measurements []hydratables.Measurement) string {
+	symbols := make([]string, 0, len(measurements))
+	for i := 0; i < len(measurements); {
+		j := i
+		for j < len(measurements) && measurements[j].NSUnit == measurements[i].NSUnit &&
+			measurements[j].UnitID == measurements[i].UnitID {
+			j++
+		}
+		symbol := measurementSymbol(measurements[i])
+		if j-i > 1 {
+			symbol += hydratables.Superscript(j - i)
+		}
+		symbols = append(symbols, symbol)
+		i = j
+	}
+	return strings.Join(symbols, "·")
+}
+
+func isPureMeasurement(measurement hydratables.Measurement) bool {
+	return measurement.NSUnit == attr.XBRLI && measurement.UnitID == "pure"
+}
+
+// renderUnit places a lone currency symbol ahead of the number and writes the
+// remaining measures after it, over the product of the denominator measures
+func renderUnit(sigFig *FactExpression, numerators []hydratables.Measurement,
+	denominators []hydratables.Measurement, isPercent bool) {
+	rest := make([]hydratables.Measurement, 0, len(numerators))
+	for _, numerator := range numerators {
+		if isPercent && isPureMeasurement(numerator) {
+			continue
+		}
+		rest = append(rest, numerator)
+	}
+	if len(rest) == 1 && rest[0].NSUnit == attr.ISO4217 && rest[0].Symbol != "" {
+		sigFig.Head = rest[0].Symbol + " " + sigFig.Head
+		rest = rest[:0]
+	}
+	if isPercent {
+		sigFig.Tail += "%"
+	}
+	if len(rest) <= 0 && len(denominators) <= 0 {
+		return
+	}
+	sigFig.Tail += " " + measurementProduct(rest)
+	if len(denominators) > 0 {
+		sigFig.Tail += "/" + measurementProduct(denominators)
+	}
+}
@@ -10,9 +10,10 @@ import (
 )
 
 type IndentedLabel struct {
-	Href        string
-	Label       LabelPack
-	Indentation int
+	Href           string
+	Label          LabelPack
+	Indentation    int
+	PreferredLabel LabelRole
 }
 
 type PGrid struct {
@@ -23,11 +24,12 @@ type PGrid struct {
 	FactualQuadrant FactualQuadrant
 	FootnoteGrid    [][][]int
 	Footnotes       []string
+	ProvenanceGrid  [][]*CellProvenance
 }
 
 func pGrid(schemedEntity string, linkroleURI string, h *hydratables.Hydratable,
 	factFinder FactFinder, conceptFinder ConceptFinder,
-	measurementFinder MeasurementFinder) (PGrid, []LabelRole, []Lang, error) {
+	measurementFinder MeasurementFinder, renderers *FactRenderers) (PGrid, []LabelRole, []Lang, error) {
 	indentedLabels, labelPacks := getIndentedLabels(linkroleURI, h)
 	relevantContexts, segmentTypedDomainTrees, scenarioTypedDomainTrees, contextualLabelPacks :=
 		getPresentationContexts(schemedEntity, h, indentedLabels)
@@ -41,9 +43,13 @@ func pGrid(schemedEntity string, linkroleURI string, h *hydratables.Hydratable,
 		labelRoles, langs = destruct(*reduced)
 	}
 	factualQuadrant, footnoteGrid, footnotes := getPFactualQuadrant(indentedLabels,
-		relevantContexts, factFinder, conceptFinder, measurementFinder, langs)
+		relevantContexts, factFinder, conceptFinder, measurementFinder, langs, renderers)
 	memberGrid, voidQuadrant := getMemberGridAndVoidQuadrant(relevantContexts,
 		segmentTypedDomainTrees, scenarioTypedDomainTrees)
+	hrefs := make([]string, 0, len(indentedLabels))
+	for _, indentedLabel := range indentedLabels {
+		hrefs = append(hrefs, indentedLabel.Href)
+	}
 	return PGrid{
 		IndentedLabels:       indentedLabels,
 		PeriodHeaders:        getPeriodHeaders(relevantContexts),
@@ -52,6 +58,7 @@ func pGrid(schemedEntity string, linkroleURI string, h *hydratables.Hydratable,
 		FactualQuadrant:      factualQuadrant,
 		FootnoteGrid:         footnoteGrid,
 		Footnotes:            footnotes,
+		ProvenanceGrid:       getProvenanceGrid(hrefs, relevantContexts, factFinder),
 	}, labelRoles, langs, nil
 }
 
@@ -70,43 +77,43 @@ func pArcs(pArcs []hydratables.PresentationArc) []myarcs.Arc {
 
 func getIndentedLabels(linkroleURI string, h *hydratables.Hydratable) ([]IndentedLabel, []LabelPack) {
 	labelPacks := make([]LabelPack, 0, 100)
-	for _, presentation := range h.PresentationLinkbases {
-		var presentationLinks []hydratables.PresentationLink
-		for _, roleRef := range presentation.RoleRefs {
-			if linkroleURI == roleRef.RoleURI {
-				presentationLinks = presentation.PresentationLinks
-				break
-			}
+	presentation := h.BaseSets.Presentation
+	var presentationLinks []hydratables.PresentationLink
+	for _, roleRef := range presentation.RoleRefs {
+		if linkroleURI == roleRef.RoleURI {
+			presentationLinks = presentation.PresentationLinks
+			break
 		}
-		for _, presentationLink := range presentationLinks {
-			if presentationLink.Role == linkroleURI {
-				arcs := presentationLink.PresentationArcs
-				pArcs := pArcs(arcs)
-				root := graph.Tree(pArcs, attr.PresentationArcrole)
-				ret := make([]IndentedLabel, 0, len(arcs))
-				var makeIndents func(node *myarcs.RArc, level int)
-				makeIndents = func(node *myarcs.RArc, level int) {
-					if len(node.Children) <= 0 {
-						return
-					}
-					sort.SliceStable(node.Children, func(p, q int) bool {
-						return node.Children[p].Order < node.Children[q].Order
+	}
+	for _, presentationLink := range presentationLinks {
+		if presentationLink.Role == linkroleURI {
+			arcs := presentationLink.PresentationArcs
+			pArcs := pArcs(arcs)
+			root := graph.Tree(pArcs, attr.PresentationArcrole)
+			ret := make([]IndentedLabel, 0, len(arcs))
+			var makeIndents func(node *myarcs.RArc, level int)
+			makeIndents = func(node *myarcs.RArc, level int) {
+				if len(node.Children) <= 0 {
+					return
+				}
+				sort.SliceStable(node.Children, func(p, q int) bool {
+					return node.Children[p].Order < node.Children[q].Order
+				})
+				for _, c := range node.Children {
+					href := mapPLocatorToHref(linkroleURI, &presentation, c.Locator)
+					iLabel := GetLabel(h, href)
+					ret = append(ret, IndentedLabel{
+						Href:           href,
+						Label:          iLabel,
+						Indentation:    level,
+						PreferredLabel: preferredLabel(arcs, node.Locator, c.Locator),
 					})
-					for _, c := range node.Children {
-						href := mapPLocatorToHref(linkroleURI, &presentation, c.Locator)
-						iLabel := GetLabel(h, href)
-						ret = append(ret, IndentedLabel{
-							Href:        href,
-							Label:       iLabel,
-							Indentation: level,
-						})
-						labelPacks = append(labelPacks, iLabel)
-						makeIndents(c, level+1)
-					}
+					labelPacks = append(labelPacks, iLabel)
+					makeIndents(c, level+1)
 				}
-				makeIndents(root, 0)
-				return ret, labelPacks
 			}
+			makeIndents(root, 0)
+			return ret, labelPacks
 		}
 	}
 	return []IndentedLabel{}, labelPacks
@@ -125,13 +132,36 @@ func getPresentationContexts(schemedEntity string, h *hydratables.Hydratable,
 func getPFactualQuadrant(indentedLabels []IndentedLabel,
 	relevantContexts []relevantContext, factFinder FactFinder,
 	conceptFinder ConceptFinder, measurementFinder MeasurementFinder,
-	langs []Lang) (FactualQuadrant, [][][]int, []string) {
+	langs []Lang, renderers *FactRenderers) (FactualQuadrant, [][][]int, []string) {
 	hrefs := make([]string, 0, len(indentedLabels))
 	for _, indentedLabel := range indentedLabels {
 		hrefs = append(hrefs, indentedLabel.Href)
 	}
 	ret, footnoteGrid, footnotes := getFactualQuadrant(hrefs,
 		relevantContexts, factFinder, conceptFinder,
-		measurementFinder, langs)
+		measurementFinder, langs, renderers)
+	if len(ret) != len(indentedLabels) {
+		return ret, footnoteGrid, footnotes
+	}
+	for i, indentedLabel := range indentedLabels {
+		if !IsNegated(indentedLabel.PreferredLabel) {
+			continue
+		}
+		for j, relevantContext := range relevantContexts {
+			fact := factFinder.FindFact(indentedLabel.Href, relevantContext.ContextRef)
+			ret[i][j] = render(negateFact(fact), conceptFinder, measurementFinder, langs, renderers)
+		}
+	}
 	return ret, footnoteGrid, footnotes
 }
+
+func preferredLabel(arcs []hydratables.PresentationArc, from string, to string) LabelRole {
+	for _, arc := range arcs {
+		if arc.From == from && arc.To == to && arc.PreferredLabel != "" {
+			if labelRole, found := labelRoles[arc.PreferredLabel]; found {
+				return labelRole
+			}
+		}
+	}
+	return Default
+}
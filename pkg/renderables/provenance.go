@@ -0,0 +1,142 @@
+package renderables
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"github.com/antchfx/xmlquery"
+)
+
+type SourceFinder interface {
+	FindFactSource(fact *hydratables.Fact) *hydratables.FactSource
+}
+
+// CellProvenance traces a cell of a factual quadrant back to its fact, and
+// to the element that tags the fact in the source file
+type CellProvenance struct {
+	FactID     string
+	ElementID  string
+	ContextID  string
+	UnitID     string
+	SourceFile string
+}
+
+func getProvenance(fact *hydratables.Fact, factFinder FactFinder) *CellProvenance {
+	if fact == nil {
+		return nil
+	}
+	ret := CellProvenance{
+		FactID:    fact.ID,
+		ContextID: fact.ContextRef,
+		UnitID:    fact.UnitRef,
+	}
+	if sf, ok := factFinder.(SourceFinder); ok {
+		if source := sf.FindFactSource(fact); source != nil {
+			ret.ElementID = source.ElementID
+			ret.SourceFile = source.FileName
+		}
+	}
+	return &ret
+}
+
+func getProvenanceGrid(hrefs []string, relevantContexts []relevantContext, factFinder FactFinder) [][]*CellProvenance {
+	if len(hrefs) <= 0 || len(relevantContexts) <= 0 {
+		return nil
+	}
+	ret := make([][]*CellProvenance, len(hrefs))
+	for i, href := range hrefs {
+		ret[i] = make([]*CellProvenance, len(relevantContexts))
+		for j, relevantContext := range relevantContexts {
+			ret[i][j] = getProvenance(factFinder.FindFact(href, relevantContext.ContextRef), factFinder)
+		}
+	}
+	return ret
+}
+
+// SourceElement is the element that tags a fact in the inline document, as
+// an HTML fragment located by its XPath and the line of its id
+type SourceElement struct {
+	ElementID  string
+	Href       string
+	ContextID  string
+	UnitID     string
+	SourceFile string
+	HTML       string
+	XPath      string
+	Line       int
+}
+
+func MarshalSourceElement(elementID string, h *hydratables.Hydratable) ([]byte, error) {
+	if h.Folder == nil || h.Folder.Document == nil {
+		return nil, fmt.Errorf("no inline document")
+	}
+	node := h.Folder.Document.FactElement(elementID)
+	if node == nil {
+		return nil, fmt.Errorf("element not found")
+	}
+	ret := SourceElement{
+		ElementID:  elementID,
+		SourceFile: h.Folder.EntryFileName,
+		HTML:       node.OutputXML(true),
+		XPath:      xpath(node),
+		Line:       idLine(h.Folder.Document.Bytes, elementID),
+	}
+	for _, instance := range h.Instances {
+		for _, fact := range instance.Facts {
+			if fact.ID == elementID {
+				ret.Href = fact.Href
+				ret.ContextID = fact.ContextRef
+				ret.UnitID = fact.UnitRef
+			}
+		}
+	}
+	return json.Marshal(ret)
+}
+
+// xpath locates an element by the position of each of its ancestors among
+// their siblings of the same name, e.g. /html/body/div[2]/ix:nonFraction[1]
+func xpath(node *xmlquery.Node) string {
+	ret := ""
+	for n := node; n != nil && n.Type == xmlquery.ElementNode; n = n.Parent {
+		position := 1
+		for sibling := n.PrevSibling; sibling != nil; sibling = sibling.PrevSibling {
+			if sibling.Type == xmlquery.ElementNode && sibling.Data == n.Data && sibling.NamespaceURI == n.NamespaceURI {
+				position++
+			}
+		}
+		name := n.Data
+		if n.Prefix != "" {
+			name = n.Prefix + ":" + name
+		}
+		if n.Parent == nil || n.Parent.Type != xmlquery.ElementNode {
+			ret = "/" + name + ret
+			continue
+		}
+		ret = "/" + name + "[" + strconv.Itoa(position) + "]" + ret
+	}
+	return ret
+}
+
+// idLine is the 1-based line of the id attribute in the source, or 0 if
+// the attribute is not found verbatim
+func idLine(data []byte, id string) int {
+	for _, quote := range []string{`"`, `'`} {
+		attribute := []byte("id=" + quote + id + quote)
+		for offset := 0; ; {
+			i := bytes.Index(data[offset:], attribute)
+			if i < 0 {
+				break
+			}
+			i += offset
+			// the attribute is id itself, not one ending in id
+			if i > 0 && (data[i-1] == ' ' || data[i-1] == '\t' || data[i-1] == '\n' || data[i-1] == '\r') {
+				return bytes.Count(data[:i], []byte("\n")) + 1
+			}
+			offset = i + len(attribute)
+		}
+	}
+	return 0
+}
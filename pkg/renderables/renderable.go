@@ -23,6 +23,10 @@ type Entity struct {
 	CharData string
 }
 
+// TaxonomyEntity is the subject of a folder whose entry point is a taxonomy
+// schema; its grids have empty factual quadrants
+var TaxonomyEntity = Entity{}
+
 type Renderable struct {
 	RelationshipSet RelationshipSet
 	Subject         Subject
@@ -33,7 +37,18 @@ type Renderable struct {
 	CGrid           CGrid
 }
 
-func MarshalRenderable(slug string, h *hydratables.Hydratable) ([]byte, error) {
+func MarshalRenderable(slug string, h *hydratables.Hydratable, options ...RenderOption) ([]byte, error) {
+	ret, err := GetRenderable(slug, h, options...)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ret)
+}
+
+// GetRenderable builds the grids of the network and subject hashed into the
+// slug of the catalog
+func GetRenderable(slug string, h *hydratables.Hydratable, options ...RenderOption) (*Renderable, error) {
+	renderers := NewFactRenderers(options...)
 	schemedEntities := sortedEntities(h)
 	rsets := sortedRelationshipSets(h)
 	for _, schemedEntity := range schemedEntities {
@@ -55,7 +70,7 @@ func MarshalRenderable(slug string, h *hydratables.Hydratable) ([]byte, error) {
 				langs = make([]Lang, 0, 8)
 				go func(entity string, linkrole string) {
 					defer wg.Done()
-					localP, lr, ln, localError := pGrid(entity, linkrole, h, h, h, h)
+					localP, lr, ln, localError := pGrid(entity, linkrole, h, h, h, h, renderers)
 					if localError != nil {
 						err = localError
 						return
@@ -66,7 +81,7 @@ func MarshalRenderable(slug string, h *hydratables.Hydratable) ([]byte, error) {
 				}(eentity, llinkrole)
 				go func(entity string, linkrole string) {
 					defer wg.Done()
-					localD, lr, ln, localError := dGrid(entity, linkrole, h, h, h, h)
+					localD, lr, ln, localError := dGrid(entity, linkrole, h, h, h, h, renderers)
 					if localError != nil {
 						err = localError
 						return
@@ -77,7 +92,7 @@ func MarshalRenderable(slug string, h *hydratables.Hydratable) ([]byte, error) {
 				}(eentity, llinkrole)
 				go func(entity string, linkrole string) {
 					defer wg.Done()
-					localC, lr, ln, localError := cGrid(entity, linkrole, h, h, h, h)
+					localC, lr, ln, localError := cGrid(entity, linkrole, h, h, h, h, renderers)
 					if localError != nil {
 						err = localError
 						return
@@ -89,16 +104,10 @@ func MarshalRenderable(slug string, h *hydratables.Hydratable) ([]byte, error) {
 				wg.Wait()
 				langs = dedupLang(langs)
 				labelRoles = dedupLabelRole(labelRoles)
-				p, d, c = formatPeriod(p, d, c, langs)
-				name := schemedEntity.Scheme + "/" + schemedEntity.CharData
-				hydratedName, err := hydratables.EntityQuery(schemedEntity.Scheme,
-					schemedEntity.CharData)
-				if err == nil {
-					name = hydratedName
-				}
+				p, d, c = formatPeriod(p, d, c, langs, renderers)
 				ret := Renderable{
 					Subject: Subject{
-						Name: name,
+						Name: subjectName(h, schemedEntity),
 						Entity: struct {
 							Scheme   string
 							CharData string
@@ -120,7 +129,7 @@ func MarshalRenderable(slug string, h *hydratables.Hydratable) ([]byte, error) {
 				if err != nil {
 					return nil, err
 				}
-				return json.Marshal(ret)
+				return &ret, nil
 			}
 		}
 	}
@@ -0,0 +1,121 @@
+package renderables
+
+import (
+	"encoding/xml"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+)
+
+// FactRenderer expresses a fact in one language
+type FactRenderer interface {
+	Render(fact *hydratables.Fact, cf ConceptFinder, mf MeasurementFinder) *FactExpression
+}
+
+type FactRendererFunc func(fact *hydratables.Fact, cf ConceptFinder, mf MeasurementFinder) *FactExpression
+
+func (f FactRendererFunc) Render(fact *hydratables.Fact, cf ConceptFinder, mf MeasurementFinder) *FactExpression {
+	return f(fact, cf, mf)
+}
+
+type rendererKey struct {
+	lang        Lang
+	conceptType xml.Name
+}
+
+// FactRenderers chooses the renderer of a fact by language and concept type,
+// falling back to the Formatter of the language
+type FactRenderers struct {
+	renderers  map[rendererKey]FactRenderer
+	formatters map[Lang]Formatter
+}
+
+type RenderOption func(*FactRenderers)
+
+// WithFactRenderer renders the facts of a concept type in a language with
+// renderer; a zero conceptType matches every type
+func WithFactRenderer(lang Lang, conceptType xml.Name, renderer FactRenderer) RenderOption {
+	return func(r *FactRenderers) {
+		r.renderers[rendererKey{lang, conceptType}] = renderer
+	}
+}
+
+// WithFormatter formats the facts and periods of a language with formatter,
+// in place of its default formatter if any
+func WithFormatter(lang Lang, formatter Formatter) RenderOption {
+	return func(r *FactRenderers) {
+		r.formatters[lang] = formatter
+	}
+}
+
+func NewFactRenderers(options ...RenderOption) *FactRenderers {
+	ret := &FactRenderers{
+		renderers:  make(map[rendererKey]FactRenderer),
+		formatters: make(map[Lang]Formatter, len(defaultFormatters)),
+	}
+	for lang, formatter := range defaultFormatters {
+		ret.formatters[lang] = formatter
+	}
+	for _, option := range options {
+		option(ret)
+	}
+	return ret
+}
+
+// find prefers a renderer of the concept's own type over one of the types it
+// derives from, any of those over a renderer of every type, and that over the
+// formatter of the language. Pure values are in the default English format
+func (r *FactRenderers) find(lang Lang, conceptTypes []xml.Name) (FactRenderer, bool) {
+	if r == nil {
+		r = NewFactRenderers()
+	}
+	if lang == PureLabel {
+		for _, conceptType := range conceptTypes {
+			if renderer, found := r.renderers[rendererKey{lang, conceptType}]; found {
+				return renderer, true
+			}
+		}
+		if renderer, found := r.renderers[rendererKey{lang, xml.Name{}}]; found {
+			return renderer, true
+		}
+		return defaultFormatters[English], true
+	}
+	for _, l := range parentLangs(lang) {
+		for _, conceptType := range conceptTypes {
+			if renderer, found := r.renderers[rendererKey{l, conceptType}]; found {
+				return renderer, true
+			}
+		}
+		if renderer, found := r.renderers[rendererKey{l, xml.Name{}}]; found {
+			return renderer, true
+		}
+		if formatter, found := r.formatters[l]; found {
+			return formatter, true
+		}
+	}
+	return nil, false
+}
+
+// formatter looks up the formatter of a Lang or of its parent languages, so
+// that fr-CA is formatted as fr unless it has a formatter of its own
+func (r *FactRenderers) formatter(lang Lang) (Formatter, bool) {
+	if r == nil {
+		r = NewFactRenderers()
+	}
+	for _, l := range parentLangs(lang) {
+		if formatter, found := r.formatters[l]; found {
+			return formatter, true
+		}
+	}
+	return Formatter{}, false
+}
+
+// parentLangs lists a Lang and its parent languages, the nearest first
+func parentLangs(lang Lang) []Lang {
+	ret := []Lang{lang}
+	tag := lang.Tag()
+	for !tag.IsRoot() {
+		tag = tag.Parent()
+		ret = append(ret, NewLang(tag.String()))
+	}
+	return ret
+}
@@ -0,0 +1,319 @@
+package renderables
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+)
+
+// ConceptQuery is a full-text search for concepts; an empty Text lists every
+// concept that passes the filters
+type ConceptQuery struct {
+	Text       string
+	Type       string
+	Balance    string
+	PeriodType string
+	Limit      int
+}
+
+type ConceptHit struct {
+	Href       string
+	Namespace  string
+	Name       string
+	Type       string
+	Balance    string
+	PeriodType string
+	Abstract   bool
+	Label      LabelPack
+	Matches    []string
+	Score      int
+}
+
+type ConceptIndex struct {
+	entries []indexEntry
+}
+
+type indexEntry struct {
+	hit     ConceptHit
+	types   []string
+	matches []indexedText
+}
+
+type indexedText struct {
+	source string
+	text   string
+	tokens []string
+	weight int
+}
+
+const (
+	nameWeight          = 4
+	defaultLabelWeight  = 4
+	labelWeight         = 2
+	documentationWeight = 1
+	roleWeight          = 1
+)
+
+func MarshalConcepts(query ConceptQuery, h *hydratables.Hydratable) ([]byte, error) {
+	return json.Marshal(NewConceptIndex(h).Search(query))
+}
+
+// NewConceptIndex indexes the concepts declared in the folder schemas, the
+// concepts of the base set networks and the concepts of reported facts by
+// name, labels in every role and language, and the definitions of the roles
+// that present them
+func NewConceptIndex(h *hydratables.Hydratable) *ConceptIndex {
+	hrefs := conceptHrefs(h)
+	roles := presentingRoles(h)
+	ret := ConceptIndex{
+		entries: make([]indexEntry, 0, len(hrefs)),
+	}
+	for _, href := range hrefs {
+		_, concept, err := h.HashQuery(href)
+		if err != nil || concept == nil {
+			continue
+		}
+		labelPack := GetLabel(h, href)
+		entry := indexEntry{
+			hit: ConceptHit{
+				Href:       href,
+				Namespace:  concept.XMLName.Space,
+				Name:       concept.XMLName.Local,
+				Type:       concept.Type.Local,
+				Balance:    concept.Balance,
+				PeriodType: concept.PeriodType,
+				Abstract:   concept.Abstract,
+				Label:      labelPack,
+			},
+		}
+		for _, typ := range concept.TypeHierarchy {
+			entry.types = append(entry.types, typ.Local)
+		}
+		if len(entry.types) <= 0 {
+			entry.types = append(entry.types, concept.Type.Local)
+		}
+		entry.matches = append(entry.matches, newIndexedText("Name", concept.XMLName.Local, nameWeight))
+		for labelRole, languagePack := range labelPack {
+			weight := labelWeight
+			switch labelRole {
+			case Default:
+				weight = defaultLabelWeight
+			case Documentation:
+				weight = documentationWeight
+			}
+			for lang, label := range languagePack {
+				if lang == PureLabel || lang == BriefLabel {
+					continue
+				}
+				entry.matches = append(entry.matches, newIndexedText(string(labelRole), label, weight))
+			}
+		}
+		for _, rset := range roles[href] {
+			entry.matches = append(entry.matches, newIndexedText(rset.RoleURI, rset.Title, roleWeight))
+		}
+		ret.entries = append(ret.entries, entry)
+	}
+	return &ret
+}
+
+func conceptHrefs(h *hydratables.Hydratable) []string {
+	hrefs := make([]string, 0, 1000)
+	for _, schema := range h.Schemas {
+		for _, concept := range schema.Element {
+			if concept.ID == "" {
+				continue
+			}
+			hrefs = append(hrefs, schema.FileName+"#"+concept.ID)
+		}
+	}
+	for _, link := range h.BaseSets.Presentation.PresentationLinks {
+		for _, loc := range link.Locs {
+			hrefs = append(hrefs, loc.Href)
+		}
+	}
+	for _, link := range h.BaseSets.Definition.DefinitionLinks {
+		for _, loc := range link.Locs {
+			hrefs = append(hrefs, loc.Href)
+		}
+	}
+	for _, link := range h.BaseSets.Calculation.CalculationLinks {
+		for _, loc := range link.Locs {
+			hrefs = append(hrefs, loc.Href)
+		}
+	}
+	for _, instance := range h.Instances {
+		for _, fact := range instance.Facts {
+			hrefs = append(hrefs, fact.Href)
+		}
+	}
+	ret := dedup(hrefs)
+	sort.Strings(ret)
+	return ret
+}
+
+// presentingRoles maps the concepts to the relationship sets of the
+// presentation networks they appear in
+func presentingRoles(h *hydratables.Hydratable) map[string][]RelationshipSet {
+	titles := map[string]RelationshipSet{}
+	for _, rset := range sortedRelationshipSets(h) {
+		titles[rset.RoleURI] = rset
+	}
+	ret := map[string][]RelationshipSet{}
+	for _, link := range h.BaseSets.Presentation.PresentationLinks {
+		rset, found := titles[link.Role]
+		if !found || rset.Title == "" {
+			continue
+		}
+		for _, loc := range link.Locs {
+			ret[loc.Href] = append(ret[loc.Href], rset)
+		}
+	}
+	return ret
+}
+
+func newIndexedText(source string, text string, weight int) indexedText {
+	return indexedText{
+		source: source,
+		text:   strings.ToLower(text),
+		tokens: tokenize(text),
+		weight: weight,
+	}
+}
+
+// tokenize splits text into lower case words, breaking camel case names
+// such as AccountsPayableCurrent into accounts, payable and current
+func tokenize(text string) []string {
+	ret := make([]string, 0, 8)
+	var token []rune
+	flush := func() {
+		if len(token) > 0 {
+			ret = append(ret, strings.ToLower(string(token)))
+			token = token[:0]
+		}
+	}
+	runes := []rune(text)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 && len(token) > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		token = append(token, r)
+	}
+	flush()
+	return ret
+}
+
+// Search ranks the concepts that match every word of the query text, a word
+// matching a whole indexed word scoring twice a prefix match, with a bonus
+// when a name or label is, or contains, the whole text
+func (index *ConceptIndex) Search(query ConceptQuery) []ConceptHit {
+	words := tokenize(query.Text)
+	phrase := strings.ToLower(strings.TrimSpace(query.Text))
+	ret := make([]ConceptHit, 0, 100)
+	for _, entry := range index.entries {
+		if !entry.filter(query) {
+			continue
+		}
+		score, matches, matched := entry.score(words, phrase)
+		if !matched {
+			continue
+		}
+		hit := entry.hit
+		hit.Score = score
+		hit.Matches = matches
+		ret = append(ret, hit)
+	}
+	sort.SliceStable(ret, func(p, q int) bool {
+		if ret[p].Score == ret[q].Score {
+			return ret[p].Href < ret[q].Href
+		}
+		return ret[p].Score > ret[q].Score
+	})
+	if query.Limit > 0 && len(ret) > query.Limit {
+		ret = ret[:query.Limit]
+	}
+	return ret
+}
+
+func (entry *indexEntry) filter(query ConceptQuery) bool {
+	if query.Balance != "" && !strings.EqualFold(entry.hit.Balance, query.Balance) {
+		return false
+	}
+	if query.PeriodType != "" && !strings.EqualFold(entry.hit.PeriodType, query.PeriodType) {
+		return false
+	}
+	if query.Type == "" {
+		return true
+	}
+	typ := query.Type
+	if i := strings.LastIndex(typ, ":"); i > -1 {
+		typ = typ[i+1:]
+	}
+	for _, t := range entry.types {
+		if strings.EqualFold(t, typ) {
+			return true
+		}
+	}
+	return false
+}
+
+func (entry *indexEntry) score(words []string, phrase string) (int, []string, bool) {
+	if len(words) <= 0 {
+		return 0, []string{}, true
+	}
+	score := 0
+	sources := map[string]bool{}
+	for _, word := range words {
+		best := 0
+		bestSource := ""
+		for _, text := range entry.matches {
+			for _, token := range text.tokens {
+				points := 0
+				if token == word {
+					points = 2 * text.weight
+				} else if strings.HasPrefix(token, word) {
+					points = text.weight
+				}
+				if points > best {
+					best = points
+					bestSource = text.source
+				}
+			}
+		}
+		if best <= 0 {
+			return 0, nil, false
+		}
+		score += best
+		sources[bestSource] = true
+	}
+	bonus := 0
+	for _, text := range entry.matches {
+		points := 0
+		if text.text == phrase {
+			points = 2 * text.weight
+		} else if len(words) > 1 && strings.Contains(text.text, phrase) {
+			points = text.weight
+		}
+		if points > bonus {
+			bonus = points
+			sources[text.source] = true
+		}
+	}
+	score += bonus
+	matches := make([]string, 0, len(sources))
+	for source := range sources {
+		matches = append(matches, source)
+	}
+	sort.Strings(matches)
+	return score, matches, true
+}
@@ -0,0 +1,697 @@
+package renderables
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/hydratables"
+)
+
+// TableHeader is a header of a table grid, spanning the columns of the
+// nodes under it on the x axis, or indented on the y axis
+type TableHeader struct {
+	Href        string
+	Label       LabelPack
+	Span        int
+	Indentation int
+	IsAbstract  bool
+}
+
+// TGrid is a z slice of a table of the Table Linkbases, with the x axis laid
+// out as levels of ColumnHeaders and the y axis as a header of each y
+// breakdown in RowHeaders
+type TGrid struct {
+	Href            string
+	Label           LabelPack
+	ZHeaders        []LabelPack
+	ColumnHeaders   [][]TableHeader
+	RowHeaders      [][]TableHeader
+	FactualQuadrant FactualQuadrant
+	FootnoteGrid    [][][]int
+	Footnotes       []string
+	ProvenanceGrid  [][]*CellProvenance
+}
+
+type TableSummary struct {
+	Slug  string
+	Href  string
+	Label LabelPack
+}
+
+// MarshalTables lists the tables of the Table Linkbases with the slugs of
+// their grids
+func MarshalTables(h *hydratables.Hydratable) ([]byte, error) {
+	tables := h.Tables()
+	ret := make([]TableSummary, 0, len(tables))
+	for _, table := range tables {
+		ret = append(ret, TableSummary{
+			Slug:  hash("", table.LinkRole, table.Href),
+			Href:  table.Href,
+			Label: GetLabel(h, table.Href),
+		})
+	}
+	return json.Marshal(ret)
+}
+
+func MarshalTGrids(slug string, h *hydratables.Hydratable, options ...RenderOption) ([]byte, error) {
+	ret, err := GetTGrids(slug, h, options...)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ret)
+}
+
+// GetTGrids lays out the table hashed into the slug, a grid for each
+// combination of the z axis, with the facts of the instances placed by the
+// aspects of their cells
+func GetTGrids(slug string, h *hydratables.Hydratable, options ...RenderOption) ([]TGrid, error) {
+	for _, table := range h.Tables() {
+		if slug == hash("", table.LinkRole, table.Href) {
+			return tGrids(table, h, NewFactRenderers(options...)), nil
+		}
+	}
+	return nil, fmt.Errorf("object not found")
+}
+
+// tableAspects are the aspect values of a header or cell; a dimension of an
+// empty member is absent
+type tableAspects struct {
+	concept    string
+	entity     string
+	dimensions map[string]string
+	period     *hydratables.Period
+}
+
+func (aspects tableAspects) merge(other tableAspects) tableAspects {
+	ret := tableAspects{
+		concept:    aspects.concept,
+		entity:     aspects.entity,
+		dimensions: map[string]string{},
+		period:     aspects.period,
+	}
+	for dimension, member := range aspects.dimensions {
+		ret.dimensions[dimension] = member
+	}
+	if other.concept != "" {
+		ret.concept = other.concept
+	}
+	if other.entity != "" {
+		ret.entity = other.entity
+	}
+	for dimension, member := range other.dimensions {
+		ret.dimensions[dimension] = member
+	}
+	if other.period != nil {
+		ret.period = other.period
+	}
+	return ret
+}
+
+// headerNode is a node of the expanded tree of a breakdown
+type headerNode struct {
+	href     string
+	label    LabelPack
+	abstract bool
+	aspects  tableAspects
+	children []*headerNode
+}
+
+// axisEntry is a column, row or z slice, with the header of each of its
+// breakdowns from the root, and the depth of its header in the last one
+type axisEntry struct {
+	paths    [][]*headerNode
+	abstract bool
+	aspects  tableAspects
+}
+
+type tableLayout struct {
+	h          *hydratables.Hydratable
+	facts      []tableFact
+	labelPacks []LabelPack
+}
+
+// tableFact is a fact with the aspects of its context, the entity and
+// period keyed for matching
+type tableFact struct {
+	fact       *hydratables.Fact
+	members    map[string]string
+	entity     string
+	identifier string
+	period     hydratables.Period
+	periodKey  string
+}
+
+func tGrids(table hydratables.Table, h *hydratables.Hydratable, renderers *FactRenderers) []TGrid {
+	layout := tableLayout{
+		h:     h,
+		facts: tableFacts(h),
+	}
+	label := GetLabel(h, table.Href)
+	layout.labelPacks = append(layout.labelPacks, label)
+	xs := layout.axis(table.Breakdowns["x"])
+	ys := layout.axis(table.Breakdowns["y"])
+	zs := layout.axis(table.Breakdowns["z"])
+	var langs []Lang
+	if reduced := reduce(layout.labelPacks); reduced != nil {
+		_, langs = destruct(*reduced)
+	}
+	defaults := dimensionDefaults(h)
+	columns := make([]axisEntry, 0, len(xs))
+	for _, x := range xs {
+		if !x.abstract {
+			columns = append(columns, x)
+		}
+	}
+	columnHeaders := layout.columnHeaders(columns)
+	rowHeaders := make([][]TableHeader, 0, len(ys))
+	for _, y := range ys {
+		headers := make([]TableHeader, 0, len(y.paths))
+		for _, path := range y.paths {
+			node := path[len(path)-1]
+			headers = append(headers, TableHeader{
+				Href:        node.href,
+				Label:       node.label,
+				Span:        1,
+				Indentation: len(path) - 1,
+				IsAbstract:  node.abstract,
+			})
+		}
+		rowHeaders = append(rowHeaders, headers)
+	}
+	ret := make([]TGrid, 0, len(zs))
+	for _, z := range zs {
+		if z.abstract {
+			continue
+		}
+		zHeaders := make([]LabelPack, 0, len(z.paths))
+		for _, path := range z.paths {
+			zHeaders = append(zHeaders, path[len(path)-1].label)
+		}
+		factualQuadrant := make(FactualQuadrant, len(ys))
+		provenanceGrid := make([][]*CellProvenance, len(ys))
+		footnoteGrid := make([][][]int, len(ys))
+		footnotes := make([]string, 0)
+		footnoteIndex := map[string]int{}
+		for i, y := range ys {
+			factualQuadrant[i] = make([]*MultilingualFact, len(columns))
+			provenanceGrid[i] = make([]*CellProvenance, len(columns))
+			footnoteGrid[i] = make([][]int, len(columns))
+			for j, x := range columns {
+				footnoteGrid[i][j] = []int{}
+				if y.abstract {
+					continue
+				}
+				fact := layout.findFact(z.aspects.merge(y.aspects).merge(x.aspects), defaults)
+				if fact == nil {
+					continue
+				}
+				factualQuadrant[i][j] = render(fact, h, h, langs, renderers)
+				provenanceGrid[i][j] = getProvenance(fact, h)
+				for _, footnote := range h.GetFootnotes(fact) {
+					if footnote == nil {
+						continue
+					}
+					k, found := footnoteIndex[footnote.ID]
+					if !found {
+						footnotes = append(footnotes, footnote.InnerHtml)
+						k = len(footnotes)
+						footnoteIndex[footnote.ID] = k
+					}
+					footnoteGrid[i][j] = append(footnoteGrid[i][j], k)
+				}
+			}
+		}
+		ret = append(ret, TGrid{
+			Href:            table.Href,
+			Label:           label,
+			ZHeaders:        zHeaders,
+			ColumnHeaders:   columnHeaders,
+			RowHeaders:      rowHeaders,
+			FactualQuadrant: factualQuadrant,
+			FootnoteGrid:    footnoteGrid,
+			Footnotes:       footnotes,
+			ProvenanceGrid:  provenanceGrid,
+		})
+	}
+	return ret
+}
+
+// axis is the product of the breakdowns of an axis, a single entry without
+// aspects when it has none. Abstract headers are entries of their own only
+// in the last breakdown.
+func (layout *tableLayout) axis(breakdowns []hydratables.Breakdown) []axisEntry {
+	ret := []axisEntry{{
+		aspects: tableAspects{
+			dimensions: map[string]string{},
+		},
+	}}
+	for _, breakdown := range breakdowns {
+		roots := layout.expand(breakdown.Nodes, tableAspects{
+			dimensions: map[string]string{},
+		})
+		entries := make([]axisEntry, 0)
+		var flatten func(node *headerNode, path []*headerNode)
+		flatten = func(node *headerNode, path []*headerNode) {
+			path = append(append([]*headerNode{}, path...), node)
+			self := axisEntry{
+				paths:    [][]*headerNode{path},
+				abstract: node.abstract,
+				aspects:  node.aspects,
+			}
+			if breakdown.ParentChildOrder != "children-first" {
+				entries = append(entries, self)
+			}
+			for _, child := range node.children {
+				flatten(child, path)
+			}
+			if breakdown.ParentChildOrder == "children-first" {
+				entries = append(entries, self)
+			}
+		}
+		for _, root := range roots {
+			flatten(root, nil)
+		}
+		product := make([]axisEntry, 0, len(ret)*len(entries))
+		for _, outer := range ret {
+			if outer.abstract {
+				continue
+			}
+			for _, inner := range entries {
+				product = append(product, axisEntry{
+					paths:    append(append([][]*headerNode{}, outer.paths...), inner.paths...),
+					abstract: inner.abstract,
+					aspects:  outer.aspects.merge(inner.aspects),
+				})
+			}
+		}
+		ret = product
+	}
+	return ret
+}
+
+// expand resolves the definition nodes of a breakdown into header nodes,
+// with the aspects of their ancestors
+func (layout *tableLayout) expand(nodes []hydratables.DefinitionNode, inherited tableAspects) []*headerNode {
+	ret := make([]*headerNode, 0, len(nodes))
+	for _, node := range nodes {
+		switch node.Kind {
+		case "ruleNode":
+			aspects := inherited.merge(tableAspects{
+				concept:    node.Concept,
+				dimensions: node.ExplicitDimensions,
+				period:     node.Period,
+			})
+			if node.Merge {
+				ret = append(ret, layout.expand(node.Children, aspects)...)
+				continue
+			}
+			ret = append(ret, &headerNode{
+				href:     node.Href,
+				label:    layout.label(node.Href),
+				abstract: node.Abstract,
+				aspects:  aspects,
+				children: layout.expand(node.Children, aspects),
+			})
+		case "conceptRelationshipNode":
+			ret = append(ret, layout.conceptRelationships(node, inherited)...)
+		case "dimensionRelationshipNode":
+			ret = append(ret, layout.dimensionRelationships(node, inherited)...)
+		case "aspectNode":
+			ret = append(ret, layout.aspectValues(node, inherited)...)
+		}
+	}
+	return ret
+}
+
+func (layout *tableLayout) label(href string) LabelPack {
+	ret := GetLabel(layout.h, href)
+	layout.labelPacks = append(layout.labelPacks, ret)
+	return ret
+}
+
+// textLabel labels an aspect value, such as a typed member or a period,
+// that has no labels of its own
+func textLabel(text string) LabelPack {
+	return LabelPack{
+		Default: LanguagePack{
+			PureLabel:  text,
+			BriefLabel: text,
+		},
+	}
+}
+
+// navigate walks relationships from the sources along the formula axis, for
+// the generations or for all of them when zero. The virtual source, e.g.
+// xfi:root, is never a node itself.
+func navigate(children func(href string) []string, sources []string, virtual string, formulaAxis string, generations int,
+	newNode func(href string) *headerNode) []*headerNode {
+	if formulaAxis == "" {
+		formulaAxis = "descendant-or-self"
+	}
+	recursive := strings.HasPrefix(formulaAxis, "descendant")
+	var descend func(href string, generation int, visited map[string]bool) []*headerNode
+	descend = func(href string, generation int, visited map[string]bool) []*headerNode {
+		ret := make([]*headerNode, 0)
+		if generations > 0 && generation > generations {
+			return ret
+		}
+		for _, child := range children(href) {
+			if visited[child] {
+				continue
+			}
+			node := newNode(child)
+			if recursive {
+				visited[child] = true
+				node.children = descend(child, generation+1, visited)
+				delete(visited, child)
+			}
+			ret = append(ret, node)
+		}
+		return ret
+	}
+	ret := make([]*headerNode, 0)
+	for _, source := range sources {
+		switch formulaAxis {
+		case "descendant-or-self", "child-or-self":
+			if source == virtual {
+				ret = append(ret, descend(source, 1, map[string]bool{})...)
+				continue
+			}
+			node := newNode(source)
+			node.children = descend(source, 1, map[string]bool{
+				source: true,
+			})
+			ret = append(ret, node)
+		case "descendant", "child":
+			ret = append(ret, descend(source, 1, map[string]bool{
+				source: true,
+			})...)
+		}
+	}
+	return ret
+}
+
+func (layout *tableLayout) conceptRelationships(node hydratables.DefinitionNode, inherited tableAspects) []*headerNode {
+	arcrole := node.Arcrole
+	if arcrole == "" {
+		arcrole = attr.PresentationArcrole
+	}
+	network := layout.h.RelationshipNetwork(node.LinkRole, arcrole)
+	children := func(href string) []string {
+		if href == "" {
+			// the roots of the network are the children of xfi:root
+			return network.Roots()
+		}
+		ret := make([]string, 0)
+		for _, relationship := range network.Children(href) {
+			ret = append(ret, relationship.To)
+		}
+		return ret
+	}
+	sources := node.RelationshipSources
+	if len(sources) <= 0 {
+		sources = []string{""}
+	}
+	return navigate(children, sources, "", node.FormulaAxis, node.Generations, func(href string) *headerNode {
+		_, concept, err := layout.h.HashQuery(href)
+		return &headerNode{
+			href:     href,
+			label:    layout.label(href),
+			abstract: err != nil || concept == nil || concept.Abstract,
+			aspects: inherited.merge(tableAspects{
+				concept: href,
+			}),
+		}
+	})
+}
+
+func (layout *tableLayout) dimensionRelationships(node hydratables.DefinitionNode, inherited tableAspects) []*headerNode {
+	if node.Dimension == "" {
+		return nil
+	}
+	dimensionDomain := layout.h.RelationshipNetwork(node.LinkRole, attr.DimensionDomainArcrole)
+	domainMember := layout.h.RelationshipNetwork(node.LinkRole, attr.DomainMemberArcrole)
+	usable := map[string]bool{}
+	children := func(href string) []string {
+		network := domainMember
+		if href == node.Dimension {
+			network = dimensionDomain
+		}
+		ret := make([]string, 0)
+		for _, relationship := range network.Children(href) {
+			usable[relationship.To] = relationship.Usable
+			ret = append(ret, relationship.To)
+		}
+		return ret
+	}
+	sources := make([]string, 0, len(node.RelationshipSources))
+	for _, source := range node.RelationshipSources {
+		if source != "" {
+			sources = append(sources, source)
+		}
+	}
+	if len(sources) <= 0 {
+		sources = []string{node.Dimension}
+	}
+	return navigate(children, sources, node.Dimension, node.FormulaAxis, node.Generations, func(href string) *headerNode {
+		isUsable, found := usable[href]
+		return &headerNode{
+			href:     href,
+			label:    layout.label(href),
+			abstract: found && !isUsable,
+			aspects: inherited.merge(tableAspects{
+				dimensions: map[string]string{
+					node.Dimension: href,
+				},
+			}),
+		}
+	})
+}
+
+func (layout *tableLayout) aspectValues(node hydratables.DefinitionNode, inherited tableAspects) []*headerNode {
+	values := map[string]*headerNode{}
+	for i := range layout.facts {
+		f := &layout.facts[i]
+		var value string
+		var aspects tableAspects
+		switch node.Aspect {
+		case "concept":
+			value = f.fact.Href
+			aspects = tableAspects{
+				concept: value,
+			}
+		case "dimension":
+			member, found := f.members[node.Dimension]
+			if !found {
+				continue
+			}
+			value = member
+			aspects = tableAspects{
+				dimensions: map[string]string{
+					node.Dimension: member,
+				},
+			}
+		case "entityIdentifier":
+			value = f.entity
+			aspects = tableAspects{
+				entity: f.entity,
+			}
+		case "period":
+			value = f.periodKey
+			aspects = tableAspects{
+				period: &f.period,
+			}
+		default:
+			continue
+		}
+		if _, found := values[value]; found {
+			continue
+		}
+		label := textLabel(value)
+		if node.Aspect == "entityIdentifier" {
+			label = textLabel(f.identifier)
+		} else if strings.ContainsRune(value, '#') {
+			label = layout.label(value)
+		}
+		values[value] = &headerNode{
+			href:    node.Href,
+			label:   label,
+			aspects: inherited.merge(aspects),
+		}
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	ret := make([]*headerNode, 0, len(keys))
+	for _, key := range keys {
+		ret = append(ret, values[key])
+	}
+	return ret
+}
+
+// columnHeaders lays out the headers of the columns in levels, a header
+// spanning the adjacent columns under the same node
+func (layout *tableLayout) columnHeaders(columns []axisEntry) [][]TableHeader {
+	flat := make([][]*headerNode, len(columns))
+	depth := 0
+	for j, column := range columns {
+		for _, path := range column.paths {
+			flat[j] = append(flat[j], path...)
+		}
+		if len(flat[j]) > depth {
+			depth = len(flat[j])
+		}
+	}
+	ret := make([][]TableHeader, depth)
+	for level := 0; level < depth; level++ {
+		ret[level] = make([]TableHeader, 0, len(columns))
+		for j := 0; j < len(columns); j++ {
+			if level >= len(flat[j]) {
+				ret[level] = append(ret[level], TableHeader{
+					Span: 1,
+				})
+				continue
+			}
+			node := flat[j][level]
+			span := 1
+			for j+span < len(columns) && level < len(flat[j+span]) && samePrefix(flat[j], flat[j+span], level) {
+				span++
+			}
+			ret[level] = append(ret[level], TableHeader{
+				Href:       node.href,
+				Label:      node.label,
+				Span:       span,
+				IsAbstract: node.abstract,
+			})
+			j += span - 1
+		}
+	}
+	return ret
+}
+
+func samePrefix(p []*headerNode, q []*headerNode, level int) bool {
+	for i := 0; i <= level; i++ {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+	// a column is not spanned over its own descendants
+	return len(p) > level+1 && len(q) > level+1
+}
+
+// findFact finds the first fact of the cell's concept whose dimensions are
+// the cell's, a dimension at its default being absent, and whose entity and
+// period are the cell's. An entity or period the cell leaves open must be
+// the same for all of the matching facts, or the cell has none
+func (layout *tableLayout) findFact(aspects tableAspects, defaults map[string]string) *hydratables.Fact {
+	if aspects.concept == "" {
+		return nil
+	}
+	dimensions := map[string]string{}
+	for dimension, member := range aspects.dimensions {
+		if member != "" && defaults[dimension] != member {
+			dimensions[dimension] = member
+		}
+	}
+	period := ""
+	if aspects.period != nil {
+		period = periodKey(*aspects.period)
+	}
+	var ret *tableFact
+	for i := range layout.facts {
+		f := &layout.facts[i]
+		if f.fact.Href != aspects.concept || len(f.members) != len(dimensions) {
+			continue
+		}
+		if (period != "" && f.periodKey != period) || (aspects.entity != "" && f.entity != aspects.entity) {
+			continue
+		}
+		matched := true
+		for dimension, member := range dimensions {
+			if f.members[dimension] != member {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		if ret == nil {
+			ret = f
+			continue
+		}
+		if (period == "" && f.periodKey != ret.periodKey) || (aspects.entity == "" && f.entity != ret.entity) {
+			return nil
+		}
+	}
+	if ret == nil {
+		return nil
+	}
+	return ret.fact
+}
+
+// tableFacts indexes the facts of the instances with their entity, period
+// and the member of each dimension of their contexts, the href of an
+// explicit member or the value of a typed member
+func tableFacts(h *hydratables.Hydratable) []tableFact {
+	fileNames := make([]string, 0, len(h.Instances))
+	for fileName := range h.Instances {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	ret := make([]tableFact, 0)
+	for _, fileName := range fileNames {
+		instance := h.Instances[fileName]
+		for i := range instance.Facts {
+			fact := &instance.Facts[i]
+			context := getContext(&instance, fact.ContextRef)
+			if context == nil {
+				continue
+			}
+			members := contextMembers(context)
+			for _, dimensionContext := range []hydratables.DimensionContext{context.Entity.Segment, context.Scenario} {
+				for _, explicitMember := range dimensionContext.ExplicitMembers {
+					members[explicitMember.Dimension.Href] = explicitMember.Member.Href
+				}
+			}
+			ret = append(ret, tableFact{
+				fact:       fact,
+				members:    members,
+				entity:     context.Entity.Identifier.Scheme + " " + context.Entity.Identifier.CharData,
+				identifier: context.Entity.Identifier.CharData,
+				period:     context.Period,
+				periodKey:  periodKey(context.Period),
+			})
+		}
+	}
+	return ret
+}
+
+func periodKey(period hydratables.Period) string {
+	if period.Forever {
+		return "forever"
+	}
+	if period.Duration.StartDate != "" {
+		return strings.TrimSpace(period.Duration.StartDate) + "/" + strings.TrimSpace(period.Duration.EndDate)
+	}
+	return strings.TrimSpace(period.Instant.CharData)
+}
+
+// dimensionDefaults maps the dimensions of the definition base set to their
+// default members
+func dimensionDefaults(h *hydratables.Hydratable) map[string]string {
+	ret := map[string]string{}
+	for _, link := range h.BaseSets.Definition.DefinitionLinks {
+		for _, arc := range link.DefinitionArcs {
+			if arc.Arcrole == attr.DimensionDefaultArcrole {
+				ret[arc.From] = arc.To
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,311 @@
+package renderables
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"golang.org/x/text/width"
+)
+
+type TextFormat string
+
+const PlainText = TextFormat("text")
+const Markdown = TextFormat("markdown")
+
+// maxCellWidth truncates long text blocks so that a grid stays readable in a
+// terminal
+const maxCellWidth = 60
+
+// MarshalText prints the PGrid, DGrid and CGrid of a renderable as aligned
+// plain text or GitHub flavoured Markdown tables, split into pages of period
+// columns no wider than width, or unsplit when width is not positive
+func MarshalText(slug string, h *hydratables.Hydratable, format TextFormat, lang Lang, labelRole LabelRole, width int, options ...RenderOption) ([]byte, error) {
+	r, err := GetRenderable(slug, h, options...)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = WriteText(&buf, r, format, lang, labelRole, width)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteText prints a renderable as MarshalText does
+func WriteText(w io.Writer, r *Renderable, format TextFormat, lang Lang, labelRole LabelRole, width int) error {
+	if format != PlainText && format != Markdown {
+		return fmt.Errorf("invalid format %s", format)
+	}
+	t := textWriter{
+		w:      w,
+		format: format,
+		width:  width,
+	}
+	if format == Markdown {
+		t.printf("# %s\n\n%s\n", markdownText(r.RelationshipSet.Title), markdownText(r.Subject.Name))
+	} else {
+		t.printf("%s\n%s\n", r.RelationshipSet.Title, r.Subject.Name)
+	}
+	networks := []struct {
+		name     string
+		sections []gridSection
+	}{
+		{"Presentation", pGridSections(r.PGrid, lang, labelRole)},
+		{"Definition", dGridSections(r.DGrid, lang, labelRole)},
+		{"Calculation", cGridSections(r.CGrid, lang, labelRole)},
+	}
+	for _, network := range networks {
+		if len(network.sections) <= 0 {
+			continue
+		}
+		if format == Markdown {
+			t.printf("\n## %s\n", network.name)
+		} else {
+			t.printf("\n== %s ==\n", network.name)
+		}
+		for _, section := range network.sections {
+			t.writeSection(section, lang)
+		}
+	}
+	return t.err
+}
+
+type textWriter struct {
+	w      io.Writer
+	format TextFormat
+	width  int
+	err    error
+}
+
+func (t *textWriter) printf(format string, a ...interface{}) {
+	if t.err != nil {
+		return
+	}
+	_, t.err = fmt.Fprintf(t.w, format, a...)
+}
+
+func (t *textWriter) writeSection(section gridSection, lang Lang) {
+	g := section.grid
+	if section.heading != "" {
+		if t.format == Markdown {
+			t.printf("\n### %s\n", markdownText(section.heading))
+		} else {
+			t.printf("\n%s\n", section.heading)
+		}
+	}
+	indent := "  "
+	if t.format == Markdown {
+		// leading spaces are trimmed from Markdown table cells
+		indent = "\u00a0\u00a0"
+	}
+	colCount := len(g.PeriodHeaders)
+	rows := make([][]string, 0, len(g.VoidQuadrant)+len(g.rowLabels)+1)
+	for i, voidCell := range g.VoidQuadrant {
+		if voidCell == nil {
+			continue
+		}
+		row := make([]string, colCount+1)
+		row[0] = strings.Repeat(indent, voidCell.Indentation) + voidCell.Dimension.Label.Resolve(Default, lang)
+		if i < len(g.ContextualMemberGrid) {
+			for j, memberCell := range g.ContextualMemberGrid[i] {
+				if memberCell == nil || j >= colCount {
+					continue
+				}
+				if memberCell.ExplicitMember != nil {
+					row[j+1] = memberCell.ExplicitMember.Label.Resolve(Default, lang)
+				} else {
+					row[j+1] = memberCell.TypedMember
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+	header := make([]string, colCount+1)
+	for j, periodHeader := range g.PeriodHeaders {
+		text, found := periodHeader[lang]
+		if !found {
+			text = periodHeader[PureLabel]
+		}
+		header[j+1] = text
+	}
+	rows = append(rows, header)
+	headerCount := len(rows)
+	numeric := make([][]bool, 0, len(g.rowLabels))
+	for i, label := range g.rowLabels {
+		row := make([]string, colCount+1)
+		isNumeric := make([]bool, colCount+1)
+		row[0] = strings.Repeat(indent, label.indent) + label.text
+		if label.bold && t.format == Markdown {
+			row[0] = strings.Repeat(indent, label.indent) + "**" + label.text + "**"
+		}
+		if i < len(g.FactualQuadrant) {
+			for j, fact := range g.FactualQuadrant[i] {
+				if fact == nil || j >= colCount {
+					continue
+				}
+				row[j+1], isNumeric[j+1] = textCell(fact, lang)
+				if i < len(g.FootnoteGrid) && j < len(g.FootnoteGrid[i]) {
+					for _, k := range g.FootnoteGrid[i][j] {
+						if k < 1 || k > len(g.Footnotes) {
+							continue
+						}
+						row[j+1] += "[" + strconv.Itoa(k) + "]"
+					}
+				}
+			}
+		}
+		rows = append(rows, row)
+		numeric = append(numeric, isNumeric)
+	}
+	if t.format == Markdown {
+		for _, row := range rows {
+			for j := range row {
+				row[j] = markdownText(row[j])
+			}
+		}
+	}
+	widths := make([]int, colCount+1)
+	for _, row := range rows {
+		for j, cell := range row {
+			if w := displayWidth(cell); w > widths[j] {
+				widths[j] = w
+			}
+		}
+	}
+	// a Markdown table has a single header row, above the delimiter row
+	ruleAfter := headerCount - 1
+	if t.format == Markdown {
+		ruleAfter = 0
+	}
+	for _, page := range columnPages(widths, t.width) {
+		t.printf("\n")
+		cols := append([]int{0}, page...)
+		for i, row := range rows {
+			isNumeric := []bool{}
+			if i >= headerCount {
+				isNumeric = numeric[i-headerCount]
+			}
+			t.writeRow(row, cols, widths, isNumeric)
+			if i == ruleAfter {
+				t.writeRule(cols, widths)
+			}
+		}
+	}
+	if len(g.Footnotes) > 0 {
+		t.printf("\n")
+		for k, footnote := range g.Footnotes {
+			t.printf("[%d] %s\n", k+1, plainText(footnote))
+		}
+	}
+}
+
+func (t *textWriter) writeRow(row []string, cols []int, widths []int, isNumeric []bool) {
+	var sb strings.Builder
+	for n, j := range cols {
+		pad := strings.Repeat(" ", widths[j]-displayWidth(row[j]))
+		cell := row[j] + pad
+		if j < len(isNumeric) && isNumeric[j] {
+			cell = pad + row[j]
+		}
+		if t.format == Markdown {
+			sb.WriteString("| " + cell + " ")
+			continue
+		}
+		if n > 0 {
+			sb.WriteString("  ")
+		}
+		sb.WriteString(cell)
+	}
+	if t.format == Markdown {
+		sb.WriteString("|")
+	}
+	t.printf("%s\n", strings.TrimRight(sb.String(), " "))
+}
+
+func (t *textWriter) writeRule(cols []int, widths []int) {
+	var sb strings.Builder
+	for n, j := range cols {
+		w := widths[j]
+		if t.format == Markdown {
+			if w < 3 {
+				w = 3
+			}
+			rule := strings.Repeat("-", w)
+			if n > 0 {
+				rule = rule[1:] + ":"
+			}
+			sb.WriteString("| " + rule + " ")
+			continue
+		}
+		if n > 0 {
+			sb.WriteString("  ")
+		}
+		sb.WriteString(strings.Repeat("-", w))
+	}
+	if t.format == Markdown {
+		sb.WriteString("|")
+	}
+	t.printf("%s\n", sb.String())
+}
+
+// columnPages splits the period columns into pages that fit the width
+// beside the label column, with at least one column a page
+func columnPages(widths []int, maxWidth int) [][]int {
+	ret := make([][]int, 0, 1)
+	page := make([]int, 0, len(widths))
+	used := widths[0]
+	for j := 1; j < len(widths); j++ {
+		if maxWidth > 0 && len(page) > 0 && used+3+widths[j] > maxWidth {
+			ret = append(ret, page)
+			page = make([]int, 0, len(widths))
+			used = widths[0]
+		}
+		page = append(page, j)
+		used += 3 + widths[j]
+	}
+	return append(ret, page)
+}
+
+// textCell is the text of a fact in the lang, with its text blocks flattened
+// the way they are rendered, and whether it is numeric
+func textCell(fact *MultilingualFact, lang Lang) (string, bool) {
+	expression, found := (*fact)[lang]
+	if !found {
+		expression = (*fact)[PureLabel]
+	}
+	isNumeric := factNumeric(fact) != nil
+	if expression.InnerHtml == "" {
+		return expression.Head + expression.Core + expression.Tail, isNumeric
+	}
+	text := []rune(plainText(expression.InnerHtml))
+	if len(text) > maxCellWidth {
+		return string(text[:maxCellWidth-1]) + "…", false
+	}
+	return string(text), false
+}
+
+func markdownText(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
+
+// displayWidth counts the terminal columns of a text, wide for east asian
+// wide runes and nothing for combining marks
+func displayWidth(text string) int {
+	ret := 0
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r):
+		case width.LookupRune(r).Kind() == width.EastAsianWide || width.LookupRune(r).Kind() == width.EastAsianFullwidth:
+			ret += 2
+		default:
+			ret++
+		}
+	}
+	return ret
+}
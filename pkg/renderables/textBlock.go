@@ -6,7 +6,6 @@ import (
 	"strconv"
 	"strings"
 
-	"ecksbee.com/telefacts/pkg/attr"
 	"ecksbee.com/telefacts/pkg/hydratables"
 
 	"golang.org/x/net/html"
@@ -49,9 +48,7 @@ func renderTextBlock(fact *hydratables.Fact, cf ConceptFinder, mf MeasurementFin
 			Core: "error",
 		}
 	}
-	isTextBlock := concept.Type.Local == attr.TextBlockItemType
-	// concept.Type.Space == attr.NONNUM || concept.Type.Space == "http://www.xbrl.org/dtr/type/2020-01-21" //todo
-	if !isTextBlock {
+	if !concept.IsTextBlock() {
 		return nil
 	}
 	r := strings.NewReader(gohtml.UnescapeString(fact.XMLInner))
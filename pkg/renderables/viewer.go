@@ -0,0 +1,266 @@
+package renderables
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+)
+
+// ViewerFact is an entry of the index of the facts tagged in the inline
+// document, in document order
+type ViewerFact struct {
+	ID         string
+	Href       string
+	Concept    string
+	Period     string
+	Dimensions []string
+	Unit       string
+	Scale      string
+	Value      string
+}
+
+// viewerCSS highlights the tagged facts with outlines and backgrounds only,
+// neither of which moves the content of the document
+const viewerCSS = `[data-telefacts-id] { outline: 1px solid rgba(230, 120, 0, 0.9); background-color: rgba(255, 215, 0, 0.25); cursor: help; }
+[data-telefacts-id]:hover { outline: 2px solid rgba(200, 70, 0, 1); }
+`
+
+// MarshalViewer rewrites the inline document with its tagged facts
+// highlighted, each with the concept label, period, dimensions, unit and
+// scale in data attributes and a tooltip, in the lang, and the index of the
+// facts embedded as JSON in a script element of id telefacts-facts. The
+// attributes and elements are spliced into the bytes of the document, the
+// rest of its markup being left as it is
+func MarshalViewer(h *hydratables.Hydratable, lang Lang, options ...RenderOption) ([]byte, error) {
+	pass, err := annotateFacts(h, lang, NewFactRenderers(options...))
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(pass.facts)
+	if err != nil {
+		return nil, err
+	}
+	if pass.bodyEnd < 0 || (pass.headEnd < 0 && pass.htmlStart < 0) {
+		return nil, fmt.Errorf("invalid inline document")
+	}
+	style := `<` + pass.prefix + `style type="text/css">` + viewerCSS + `</` + pass.prefix + `style>`
+	if pass.headEnd < 0 {
+		pass.inserts = append(pass.inserts, viewerInsert{
+			offset: pass.htmlStart,
+			text:   `<` + pass.prefix + `head>` + style + `</` + pass.prefix + `head>`,
+		})
+	} else {
+		pass.inserts = append(pass.inserts, viewerInsert{
+			offset: pass.headEnd,
+			text:   style,
+		})
+	}
+	pass.inserts = append(pass.inserts, viewerInsert{
+		offset: pass.bodyEnd,
+		text: `<` + pass.prefix + `script type="application/json" id="telefacts-facts">` + string(data) +
+			`</` + pass.prefix + `script>`,
+	})
+	sort.SliceStable(pass.inserts, func(i, j int) bool {
+		return pass.inserts[i].offset < pass.inserts[j].offset
+	})
+	document := h.Folder.Document.Bytes
+	var buf bytes.Buffer
+	prev := int64(0)
+	for _, insert := range pass.inserts {
+		buf.Write(document[prev:insert.offset])
+		buf.WriteString(insert.text)
+		prev = insert.offset
+	}
+	buf.Write(document[prev:])
+	return buf.Bytes(), nil
+}
+
+// MarshalViewerIndex lists the facts tagged in the inline document, as
+// indexed by MarshalViewer
+func MarshalViewerIndex(h *hydratables.Hydratable, lang Lang, options ...RenderOption) ([]byte, error) {
+	pass, err := annotateFacts(h, lang, NewFactRenderers(options...))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(pass.facts)
+}
+
+// viewerInsert is markup to splice into the inline document at a byte offset
+type viewerInsert struct {
+	offset int64
+	text   string
+}
+
+// viewerPass is the outcome of a pass over the inline document: the
+// attributes of its facts, the facts in document order, and the offsets of
+// its html start tag and of its head and body end tags, -1 when missing
+type viewerPass struct {
+	inserts   []viewerInsert
+	facts     []ViewerFact
+	prefix    string
+	htmlStart int64
+	headEnd   int64
+	bodyEnd   int64
+}
+
+// annotateFacts tokenizes the inline document as XML to find the start tags
+// of the facts, whatever the prefix of their inline XBRL namespace, and the
+// end of each start tag for the attributes of the viewer
+func annotateFacts(h *hydratables.Hydratable, lang Lang, renderers *FactRenderers) (*viewerPass, error) {
+	if h.Folder == nil || h.Folder.Document == nil {
+		return nil, fmt.Errorf("no inline document")
+	}
+	facts := map[string]*hydratables.Fact{}
+	contexts := map[string]*hydratables.Context{}
+	fileNames := make([]string, 0, len(h.Instances))
+	for fileName := range h.Instances {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		instance := h.Instances[fileName]
+		for i := range instance.Facts {
+			fact := &instance.Facts[i]
+			if fact.ID == "" || facts[fact.ID] != nil {
+				continue
+			}
+			facts[fact.ID] = fact
+			contexts[fact.ID] = getContext(&instance, fact.ContextRef)
+		}
+	}
+	document := h.Folder.Document.Bytes
+	ret := &viewerPass{
+		facts:     make([]ViewerFact, 0, len(facts)),
+		htmlStart: -1,
+		headEnd:   -1,
+		bodyEnd:   -1,
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(document))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch elem := token.(type) {
+		case xml.StartElement:
+			end := decoder.InputOffset()
+			if elem.Name.Local == "html" && ret.htmlStart < 0 {
+				ret.htmlStart = end
+				if elem.Name.Space != "" {
+					ret.prefix = elem.Name.Space + ":"
+				}
+			}
+			id := xmlAttr(elem.Attr, "id")
+			fact, found := facts[id]
+			if !found || h.Folder.Document.FactElement(id) == nil {
+				continue
+			}
+			entry := viewerFact(fact, contexts[id], h, lang, renderers)
+			entry.Scale = xmlAttr(elem.Attr, "scale")
+			attrs := [][2]string{
+				{"data-telefacts-id", entry.ID},
+				{"data-telefacts-concept", entry.Concept},
+				{"data-telefacts-period", entry.Period},
+				{"data-telefacts-dimensions", strings.Join(entry.Dimensions, "; ")},
+				{"data-telefacts-unit", entry.Unit},
+				{"data-telefacts-scale", entry.Scale},
+			}
+			if xmlAttr(elem.Attr, "title") == "" {
+				attrs = append(attrs, [2]string{"title", tooltip(entry)})
+			}
+			var text bytes.Buffer
+			for _, a := range attrs {
+				text.WriteString(` ` + a[0] + `="`)
+				xml.EscapeText(&text, []byte(a[1]))
+				text.WriteString(`"`)
+			}
+			// before the > of the start tag, or the /> of an empty element
+			at := end - 1
+			if at > 0 && document[at-1] == '/' {
+				at--
+			}
+			ret.inserts = append(ret.inserts, viewerInsert{
+				offset: at,
+				text:   text.String(),
+			})
+			ret.facts = append(ret.facts, entry)
+		case xml.EndElement:
+			switch {
+			case elem.Name.Local == "head" && ret.headEnd < 0:
+				ret.headEnd = offset
+			case elem.Name.Local == "body" && ret.bodyEnd < 0:
+				ret.bodyEnd = offset
+			}
+		}
+	}
+	return ret, nil
+}
+
+func viewerFact(fact *hydratables.Fact, context *hydratables.Context, h *hydratables.Hydratable, lang Lang, renderers *FactRenderers) ViewerFact {
+	ret := ViewerFact{
+		ID:         fact.ID,
+		Href:       fact.Href,
+		Concept:    GetLabel(h, fact.Href).Resolve(Default, lang),
+		Dimensions: []string{},
+	}
+	if context != nil {
+		ret.Period = formatDate(lang, periodString(context)[PureLabel], renderers)
+		for _, dimensionContext := range []hydratables.DimensionContext{context.Entity.Segment, context.Scenario} {
+			for _, explicitMember := range dimensionContext.ExplicitMembers {
+				ret.Dimensions = append(ret.Dimensions, GetLabel(h, explicitMember.Dimension.Href).Resolve(Default, lang)+": "+
+					GetLabel(h, explicitMember.Member.Href).Resolve(Default, lang))
+			}
+			for _, typedMember := range dimensionContext.TypedMembers {
+				values := make([]string, 0, len(typedMember.TypedMembersMap))
+				for _, value := range typedMember.TypedMembersMap {
+					values = append(values, value)
+				}
+				sort.Strings(values)
+				ret.Dimensions = append(ret.Dimensions, GetLabel(h, typedMember.Dimension.Href).Resolve(Default, lang)+": "+
+					strings.Join(values, " "))
+			}
+		}
+	}
+	if fact.UnitRef != "" {
+		numerators, denominators := h.FindMeasurement(fact.UnitRef)
+		ret.Unit = measurementProduct(numerators)
+		if len(denominators) > 0 {
+			ret.Unit += "/" + measurementProduct(denominators)
+		}
+	}
+	ret.Value, _ = textCell(render(fact, h, h, []Lang{PureLabel, lang}, renderers), lang)
+	return ret
+}
+
+func tooltip(entry ViewerFact) string {
+	lines := []string{entry.Concept, entry.Period}
+	lines = append(lines, entry.Dimensions...)
+	if entry.Unit != "" {
+		lines = append(lines, entry.Unit)
+	}
+	if entry.Scale != "" {
+		lines = append(lines, "scale "+entry.Scale)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func xmlAttr(attrs []xml.Attr, local string) string {
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
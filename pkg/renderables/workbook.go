@@ -0,0 +1,426 @@
+package renderables
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+)
+
+// MarshalWorkbook writes the PGrid, DGrid and CGrid of a renderable to the
+// sheets of an XLSX workbook, labelled in the lang
+func MarshalWorkbook(slug string, h *hydratables.Hydratable, lang Lang, options ...RenderOption) ([]byte, error) {
+	r, err := GetRenderable(slug, h, options...)
+	if err != nil {
+		return nil, err
+	}
+	wb := newWorkbook()
+	sheets := []struct {
+		name     string
+		sections []gridSection
+	}{
+		{"Presentation", pGridSections(r.PGrid, lang, "")},
+		{"Definition", dGridSections(r.DGrid, lang, "")},
+		{"Calculation", cGridSections(r.CGrid, lang, "")},
+	}
+	for _, sheet := range sheets {
+		ws := wb.addSheet(sheet.name)
+		ws.writeTitle(wb, r)
+		for _, section := range sheet.sections {
+			if section.heading != "" {
+				ws.writeHeading(wb, section.heading)
+			}
+			ws.writeGrid(wb, lang, section.grid)
+		}
+	}
+	return wb.marshal()
+}
+
+type cellStyle struct {
+	numFmt string
+	indent int
+	bold   bool
+}
+
+type workbookCell struct {
+	text      string
+	number    string
+	isNumber  bool
+	styleID   int
+	isPresent bool
+}
+
+type cellComment struct {
+	row  int
+	col  int
+	text string
+}
+
+type worksheet struct {
+	name     string
+	rows     [][]workbookCell
+	comments []cellComment
+}
+
+type workbook struct {
+	sheets  []*worksheet
+	numFmts []string
+	styles  []cellStyle
+}
+
+func newWorkbook() *workbook {
+	return &workbook{
+		styles: []cellStyle{{}},
+	}
+}
+
+func (wb *workbook) addSheet(name string) *worksheet {
+	ret := &worksheet{
+		name: name,
+	}
+	wb.sheets = append(wb.sheets, ret)
+	return ret
+}
+
+func (wb *workbook) style(s cellStyle) int {
+	for i, style := range wb.styles {
+		if style == s {
+			return i
+		}
+	}
+	if s.numFmt != "" {
+		found := false
+		for _, numFmt := range wb.numFmts {
+			if numFmt == s.numFmt {
+				found = true
+				break
+			}
+		}
+		if !found {
+			wb.numFmts = append(wb.numFmts, s.numFmt)
+		}
+	}
+	wb.styles = append(wb.styles, s)
+	return len(wb.styles) - 1
+}
+
+func (wb *workbook) numFmtID(numFmt string) int {
+	for i, candidate := range wb.numFmts {
+		if candidate == numFmt {
+			return 164 + i
+		}
+	}
+	return 0
+}
+
+func (ws *worksheet) newRow() int {
+	ws.rows = append(ws.rows, []workbookCell{})
+	return len(ws.rows) - 1
+}
+
+func (ws *worksheet) setText(row int, col int, text string, styleID int) {
+	for len(ws.rows[row]) <= col {
+		ws.rows[row] = append(ws.rows[row], workbookCell{})
+	}
+	ws.rows[row][col] = workbookCell{
+		text:      text,
+		styleID:   styleID,
+		isPresent: true,
+	}
+}
+
+func (ws *worksheet) setNumber(row int, col int, number string, styleID int) {
+	for len(ws.rows[row]) <= col {
+		ws.rows[row] = append(ws.rows[row], workbookCell{})
+	}
+	ws.rows[row][col] = workbookCell{
+		number:    number,
+		isNumber:  true,
+		styleID:   styleID,
+		isPresent: true,
+	}
+}
+
+func (ws *worksheet) writeTitle(wb *workbook, r *Renderable) {
+	bold := wb.style(cellStyle{
+		bold: true,
+	})
+	row := ws.newRow()
+	ws.setText(row, 0, r.RelationshipSet.Title, bold)
+	row = ws.newRow()
+	ws.setText(row, 0, r.Subject.Name, 0)
+	ws.newRow()
+}
+
+func (ws *worksheet) writeHeading(wb *workbook, heading string) {
+	row := ws.newRow()
+	ws.setText(row, 0, heading, wb.style(cellStyle{
+		bold: true,
+	}))
+}
+
+func (ws *worksheet) writeGrid(wb *workbook, lang Lang, g grid) {
+	for i, voidCell := range g.VoidQuadrant {
+		if voidCell == nil {
+			continue
+		}
+		row := ws.newRow()
+		ws.setText(row, 0, voidCell.Dimension.Label.Resolve(Default, lang), wb.style(cellStyle{
+			indent: voidCell.Indentation,
+		}))
+		if i >= len(g.ContextualMemberGrid) {
+			continue
+		}
+		for j, memberCell := range g.ContextualMemberGrid[i] {
+			if memberCell == nil {
+				continue
+			}
+			if memberCell.ExplicitMember != nil {
+				ws.setText(row, j+1, memberCell.ExplicitMember.Label.Resolve(Default, lang), 0)
+			} else if memberCell.TypedMember != "" {
+				ws.setText(row, j+1, memberCell.TypedMember, 0)
+			}
+		}
+	}
+	bold := wb.style(cellStyle{
+		bold: true,
+	})
+	row := ws.newRow()
+	for j, periodHeader := range g.PeriodHeaders {
+		header, found := periodHeader[lang]
+		if !found {
+			header = periodHeader[PureLabel]
+		}
+		ws.setText(row, j+1, header, bold)
+	}
+	for i, label := range g.rowLabels {
+		row := ws.newRow()
+		ws.setText(row, 0, label.text, wb.style(cellStyle{
+			indent: label.indent,
+			bold:   label.bold,
+		}))
+		if i >= len(g.FactualQuadrant) {
+			continue
+		}
+		for j, fact := range g.FactualQuadrant[i] {
+			if fact == nil {
+				continue
+			}
+			ws.writeFact(wb, row, j+1, fact, lang, label.bold)
+			if i < len(g.FootnoteGrid) && j < len(g.FootnoteGrid[i]) && len(g.FootnoteGrid[i][j]) > 0 {
+				texts := make([]string, 0, len(g.FootnoteGrid[i][j]))
+				for _, k := range g.FootnoteGrid[i][j] {
+					if k < 1 || k > len(g.Footnotes) {
+						continue
+					}
+					texts = append(texts, plainText(g.Footnotes[k-1]))
+				}
+				ws.comments = append(ws.comments, cellComment{
+					row:  row,
+					col:  j + 1,
+					text: strings.Join(texts, "\n"),
+				})
+			}
+		}
+	}
+	ws.newRow()
+}
+
+func (ws *worksheet) writeFact(wb *workbook, row int, col int, fact *MultilingualFact, lang Lang, bold bool) {
+	if numeric := factNumeric(fact); numeric != nil {
+		ws.setNumber(row, col, numeric.Number, wb.style(cellStyle{
+			numFmt: numberFormat(numeric),
+			bold:   bold,
+		}))
+		return
+	}
+	text := factText(fact, lang)
+	if text == "" {
+		return
+	}
+	ws.setText(row, col, text, wb.style(cellStyle{
+		bold: bold,
+	}))
+}
+
+func escapeXML(text string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
+
+func columnName(col int) string {
+	name := ""
+	for col >= 0 {
+		name = string(rune('A'+col%26)) + name
+		col = col/26 - 1
+	}
+	return name
+}
+
+func (wb *workbook) marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := make([][2]string, 0, 4+len(wb.sheets)*4)
+	var contentTypes strings.Builder
+	contentTypes.WriteString(xml.Header)
+	contentTypes.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	contentTypes.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	contentTypes.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	contentTypes.WriteString(`<Default Extension="vml" ContentType="application/vnd.openxmlformats-officedocument.vmlDrawing"/>`)
+	contentTypes.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	contentTypes.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	var workbookXML, workbookRels strings.Builder
+	workbookXML.WriteString(xml.Header)
+	workbookXML.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(xml.Header)
+	workbookRels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i, ws := range wb.sheets {
+		n := i + 1
+		contentTypes.WriteString(fmt.Sprintf(`<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n))
+		workbookXML.WriteString(fmt.Sprintf(`<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXML(ws.name), n, n))
+		workbookRels.WriteString(fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n))
+		files = append(files, [2]string{fmt.Sprintf("xl/worksheets/sheet%d.xml", n), ws.marshal()})
+		if len(ws.comments) <= 0 {
+			continue
+		}
+		contentTypes.WriteString(fmt.Sprintf(`<Override PartName="/xl/comments%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"/>`, n))
+		files = append(files, [2]string{fmt.Sprintf("xl/worksheets/_rels/sheet%d.xml.rels", n), xml.Header +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			fmt.Sprintf(`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing" Target="../drawings/vmlDrawing%d.vml"/>`, n) +
+			fmt.Sprintf(`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments" Target="../comments%d.xml"/>`, n) +
+			`</Relationships>`})
+		files = append(files, [2]string{fmt.Sprintf("xl/comments%d.xml", n), ws.marshalComments()})
+		files = append(files, [2]string{fmt.Sprintf("xl/drawings/vmlDrawing%d.vml", n), ws.marshalVML()})
+	}
+	workbookXML.WriteString(`</sheets></workbook>`)
+	workbookRels.WriteString(fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(wb.sheets)+1))
+	workbookRels.WriteString(`</Relationships>`)
+	contentTypes.WriteString(`</Types>`)
+	files = append([][2]string{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xml.Header +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", workbookXML.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+		{"xl/styles.xml", wb.marshalStyles()},
+	}, files...)
+	for _, file := range files {
+		w, err := zw.Create(file[0])
+		if err != nil {
+			return nil, err
+		}
+		_, err = w.Write([]byte(file[1]))
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := zw.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (wb *workbook) marshalStyles() string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(wb.numFmts) > 0 {
+		sb.WriteString(fmt.Sprintf(`<numFmts count="%d">`, len(wb.numFmts)))
+		for _, numFmt := range wb.numFmts {
+			sb.WriteString(fmt.Sprintf(`<numFmt numFmtId="%d" formatCode="%s"/>`, wb.numFmtID(numFmt), escapeXML(numFmt)))
+		}
+		sb.WriteString(`</numFmts>`)
+	}
+	sb.WriteString(`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>`)
+	sb.WriteString(`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>`)
+	sb.WriteString(`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>`)
+	sb.WriteString(`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+	sb.WriteString(fmt.Sprintf(`<cellXfs count="%d">`, len(wb.styles)))
+	for _, style := range wb.styles {
+		fontID := 0
+		if style.bold {
+			fontID = 1
+		}
+		sb.WriteString(fmt.Sprintf(`<xf numFmtId="%d" fontId="%d" fillId="0" borderId="0" xfId="0"`, wb.numFmtID(style.numFmt), fontID))
+		if style.numFmt != "" {
+			sb.WriteString(` applyNumberFormat="1"`)
+		}
+		if style.bold {
+			sb.WriteString(` applyFont="1"`)
+		}
+		if style.indent > 0 {
+			sb.WriteString(fmt.Sprintf(` applyAlignment="1"><alignment horizontal="left" indent="%d"/></xf>`, style.indent))
+		} else {
+			sb.WriteString(`/>`)
+		}
+	}
+	sb.WriteString(`</cellXfs>`)
+	sb.WriteString(`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>`)
+	sb.WriteString(`</styleSheet>`)
+	return sb.String()
+}
+
+func (ws *worksheet) marshal() string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	sb.WriteString(`<cols><col min="1" max="1" width="60" customWidth="1"/><col min="2" max="64" width="24" customWidth="1"/></cols>`)
+	sb.WriteString(`<sheetData>`)
+	for i, row := range ws.rows {
+		if len(row) <= 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf(`<row r="%d">`, i+1))
+		for j, cell := range row {
+			if !cell.isPresent {
+				continue
+			}
+			ref := fmt.Sprintf("%s%d", columnName(j), i+1)
+			if cell.isNumber {
+				sb.WriteString(fmt.Sprintf(`<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.styleID, cell.number))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf(`<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, cell.styleID, escapeXML(cell.text)))
+		}
+		sb.WriteString(`</row>`)
+	}
+	sb.WriteString(`</sheetData>`)
+	if len(ws.comments) > 0 {
+		sb.WriteString(`<legacyDrawing r:id="rId1"/>`)
+	}
+	sb.WriteString(`</worksheet>`)
+	return sb.String()
+}
+
+func (ws *worksheet) marshalComments() string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<comments xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><authors><author>telefacts</author></authors><commentList>`)
+	for _, comment := range ws.comments {
+		sb.WriteString(fmt.Sprintf(`<comment ref="%s%d" authorId="0"><text><t xml:space="preserve">%s</t></text></comment>`,
+			columnName(comment.col), comment.row+1, escapeXML(comment.text)))
+	}
+	sb.WriteString(`</commentList></comments>`)
+	return sb.String()
+}
+
+func (ws *worksheet) marshalVML() string {
+	var sb strings.Builder
+	sb.WriteString(`<xml xmlns:v="urn:schemas-microsoft-com:vml" xmlns:o="urn:schemas-microsoft-com:office:office" xmlns:x="urn:schemas-microsoft-com:office:excel">`)
+	sb.WriteString(`<v:shapetype id="_x0000_t202" coordsize="21600,21600" o:spt="202" path="m,l,21600r21600,l21600,xe"><v:stroke joinstyle="miter"/><v:path gradientshapeok="t" o:connecttype="rect"/></v:shapetype>`)
+	for i, comment := range ws.comments {
+		sb.WriteString(fmt.Sprintf(`<v:shape id="_x0000_s%d" type="#_x0000_t202" style="position:absolute;visibility:hidden;width:200pt;height:60pt" fillcolor="#ffffe1" o:insetmode="auto">`, 1025+i))
+		sb.WriteString(`<v:fill color2="#ffffe1"/><v:shadow on="t" color="black" obscured="t"/><v:path o:connecttype="none"/><v:textbox style="mso-direction-alt:auto"/>`)
+		sb.WriteString(fmt.Sprintf(`<x:ClientData ObjectType="Note"><x:MoveWithCells/><x:SizeWithCells/><x:AutoFill>False</x:AutoFill><x:Row>%d</x:Row><x:Column>%d</x:Column></x:ClientData>`, comment.row, comment.col))
+		sb.WriteString(`</v:shape>`)
+	}
+	sb.WriteString(`</xml>`)
+	return sb.String()
+}
@@ -0,0 +1,321 @@
+package renderables
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+var xbrlCSVIdentifier = regexp.MustCompile(`[^A-Za-z0-9_\-]`)
+
+// MarshalXBRLCSV exports the facts of the subject hashed into the slug as an
+// xBRL-CSV report zipped with its metadata, with a table template of the
+// concepts of the presentation network, or of the primary items and
+// dimensions of the DRS, of the role hashed into the slug
+func MarshalXBRLCSV(slug string, h *hydratables.Hydratable, network string) ([]byte, error) {
+	entity, rset, err := findSlug(slug, h)
+	if err != nil {
+		return nil, err
+	}
+	var concepts, dimensions []string
+	switch network {
+	case "", "presentation":
+		concepts = networkConcepts(h.RelationshipNetwork(rset.RoleURI, attr.PresentationArcrole))
+	case "definition":
+		concepts, dimensions = drsConcepts(h, rset.RoleURI)
+	default:
+		return nil, fmt.Errorf("invalid network %s", network)
+	}
+	documentInfo, prefixes := oimDocumentInfo(h, attr.XBRLCSV)
+	qnames := map[string]string{}
+	qname := func(href string) string {
+		if _, found := qnames[href]; !found {
+			qnames[href] = factQName(h, href, prefixes)
+		}
+		return qnames[href]
+	}
+	isConcept := map[string]bool{}
+	for _, href := range concepts {
+		isConcept[href] = true
+	}
+	isDimension := map[string]bool{}
+	for _, href := range dimensions {
+		isDimension[href] = true
+	}
+	type row struct {
+		period  string
+		members map[string]string
+		cells   map[string]string
+	}
+	rows := map[string]*row{}
+	hasUnit := map[string]bool{}
+	hasFact := map[string]bool{}
+	fileNames := make([]string, 0, len(h.Instances))
+	for fileName := range h.Instances {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		instance := h.Instances[fileName]
+		for _, fact := range instance.Facts {
+			if !isConcept[fact.Href] {
+				continue
+			}
+			context := getContext(&instance, fact.ContextRef)
+			if context == nil || context.Entity.Identifier.Scheme != entity.Scheme ||
+				context.Entity.Identifier.CharData != entity.CharData {
+				continue
+			}
+			period := ""
+			if context.Period.Duration.StartDate != "" {
+				period = oimDate(context.Period.Duration.StartDate, false) + "/" + oimDate(context.Period.Duration.EndDate, true)
+			} else if context.Period.Instant.CharData != "" {
+				period = oimDate(context.Period.Instant.CharData, true)
+			}
+			members := contextMembers(context)
+			hrefs := make([]string, 0, len(members))
+			for href := range members {
+				hrefs = append(hrefs, href)
+				if !isDimension[href] {
+					isDimension[href] = true
+					dimensions = append(dimensions, href)
+				}
+			}
+			sort.Strings(hrefs)
+			key := period
+			for _, href := range hrefs {
+				key += "|" + href + "=" + members[href]
+			}
+			r, found := rows[key]
+			if !found {
+				r = &row{
+					period:  period,
+					members: members,
+					cells:   map[string]string{},
+				}
+				rows[key] = r
+			}
+			if _, found := r.cells[fact.Href]; found {
+				continue
+			}
+			hasFact[fact.Href] = true
+			if fact.IsNil {
+				r.cells[fact.Href] = "#nil"
+			} else if value := xmlText(fact.XMLInner); value == "" {
+				r.cells[fact.Href] = "#empty"
+			} else {
+				r.cells[fact.Href] = value
+			}
+			if unit := oimUnit(&instance, fact.UnitRef); unit != "" {
+				hasUnit[fact.Href] = true
+				r.cells[fact.Href+"#unit"] = unit
+			}
+			if fact.Precision != hydratables.Precisionless && fact.Precision != hydratables.Exact {
+				r.cells[fact.Href+"#decimals"] = strconv.Itoa(int(fact.Precision))
+			}
+		}
+	}
+	columnNames := map[string]bool{
+		"period": true,
+	}
+	columnName := func(name string) string {
+		name = xbrlCSVName(name)
+		ret := name
+		for i := 2; columnNames[ret]; i++ {
+			ret = name + "_" + strconv.Itoa(i)
+		}
+		columnNames[ret] = true
+		return ret
+	}
+	localName := func(qname string) string {
+		return qname[strings.LastIndexAny(qname, ":}")+1:]
+	}
+	template := serializables.XBRLCSVTableTemplate{
+		Columns: map[string]serializables.XBRLCSVColumn{
+			"period": {},
+		},
+		Dimensions: map[string]string{
+			"entity": oimEntity(&documentInfo, prefixes, entity.Scheme, entity.CharData),
+			"period": "$period",
+		},
+	}
+	header := []string{"period"}
+	for _, href := range dimensions {
+		name := columnName(localName(qname(href)))
+		template.Columns[name] = serializables.XBRLCSVColumn{}
+		template.Dimensions[qname(href)] = "$" + name
+		header = append(header, name)
+	}
+	type factColumn struct {
+		href     string
+		name     string
+		unit     string
+		decimals string
+	}
+	factColumns := make([]factColumn, 0, len(concepts))
+	for _, href := range concepts {
+		if !hasFact[href] {
+			_, concept, err := h.HashQuery(href)
+			if err != nil || concept == nil || concept.Abstract {
+				continue
+			}
+		}
+		column := factColumn{
+			href: href,
+			name: columnName(localName(qname(href))),
+		}
+		columnDimensions := map[string]string{
+			"concept": qname(href),
+		}
+		var decimals json.RawMessage
+		header = append(header, column.name)
+		if hasUnit[href] {
+			column.unit = columnName(column.name + "_unit")
+			column.decimals = columnName(column.name + "_decimals")
+			template.Columns[column.unit] = serializables.XBRLCSVColumn{}
+			template.Columns[column.decimals] = serializables.XBRLCSVColumn{}
+			columnDimensions["unit"] = "$" + column.unit
+			decimals = json.RawMessage(strconv.Quote("$" + column.decimals))
+			header = append(header, column.unit, column.decimals)
+		}
+		template.Columns[column.name] = serializables.XBRLCSVColumn{
+			Dimensions: columnDimensions,
+			Decimals:   decimals,
+		}
+		factColumns = append(factColumns, column)
+	}
+	keys := make([]string, 0, len(rows))
+	for key := range rows {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var table bytes.Buffer
+	writer := csv.NewWriter(&table)
+	writer.Write(header)
+	for _, key := range keys {
+		r := rows[key]
+		record := []string{r.period}
+		for _, href := range dimensions {
+			member := r.members[href]
+			if member == "" {
+				member = "#none"
+			}
+			record = append(record, member)
+		}
+		for _, column := range factColumns {
+			record = append(record, r.cells[column.href])
+			if column.unit != "" {
+				record = append(record, r.cells[column.href+"#unit"], r.cells[column.href+"#decimals"])
+			}
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	templateName := xbrlCSVName(rset.RoleURI[strings.LastIndex(rset.RoleURI, "/")+1:])
+	metadata := serializables.XBRLCSVFile{
+		DocumentInfo: documentInfo,
+		TableTemplates: map[string]serializables.XBRLCSVTableTemplate{
+			templateName: template,
+		},
+		Tables: map[string]serializables.XBRLCSVTable{
+			templateName: {
+				Template: templateName,
+				URL:      templateName + ".csv",
+			},
+		},
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(metadata)
+	if err != nil {
+		return nil, err
+	}
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for _, part := range []struct {
+		name string
+		data []byte
+	}{
+		{"metadata.json", buf.Bytes()},
+		{templateName + ".csv", table.Bytes()},
+	} {
+		w, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		_, err = w.Write(part.data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = zw.Close()
+	if err != nil {
+		return nil, err
+	}
+	return archive.Bytes(), nil
+}
+
+// xbrlCSVName makes a name an identifier of a column or table
+func xbrlCSVName(name string) string {
+	name = xbrlCSVIdentifier.ReplaceAllString(name, "_")
+	if name == "" || !(name[0] == '_' || name[0] >= 'A' && name[0] <= 'Z' || name[0] >= 'a' && name[0] <= 'z') {
+		name = "_" + name
+	}
+	return name
+}
+
+func findSlug(slug string, h *hydratables.Hydratable) (Entity, RelationshipSet, error) {
+	for _, schemedEntity := range sortedEntities(h) {
+		for _, rset := range sortedRelationshipSets(h) {
+			if slug == hash(stringify(&schemedEntity), rset.RoleURI, rset.Title) {
+				return schemedEntity, rset, nil
+			}
+		}
+	}
+	return Entity{}, RelationshipSet{}, fmt.Errorf("object not found")
+}
+
+// networkConcepts lists the concepts of a network from each root, depth
+// first in arc order
+func networkConcepts(network *hydratables.RelationshipNetwork) []string {
+	ret := make([]string, 0)
+	for _, root := range network.Roots() {
+		ret = append(ret, root)
+		ret = append(ret, network.Descendants(root)...)
+	}
+	return dedup(ret)
+}
+
+// drsConcepts lists the primary items of the hypercubes of a role with the
+// dimensions of the hypercubes
+func drsConcepts(h *hydratables.Hydratable, linkrole string) ([]string, []string) {
+	domainMember := h.RelationshipNetwork(linkrole, attr.DomainMemberArcrole)
+	hypercubeDimension := h.RelationshipNetwork(linkrole, attr.HypercubeDimensionArcrole)
+	concepts := make([]string, 0)
+	dimensions := make([]string, 0)
+	for _, arcrole := range []string{attr.HasInclusiveHypercubeArcrole, attr.HasExclusiveHypercubeArcrole} {
+		for _, relationship := range h.RelationshipNetwork(linkrole, arcrole).Relationships {
+			concepts = append(concepts, relationship.From)
+			concepts = append(concepts, domainMember.Descendants(relationship.From)...)
+			for _, child := range hypercubeDimension.Children(relationship.To) {
+				dimensions = append(dimensions, child.To)
+			}
+		}
+	}
+	return dedup(concepts), dedup(dimensions)
+}
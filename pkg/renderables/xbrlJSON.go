@@ -0,0 +1,287 @@
+package renderables
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+// MarshalXBRLJSON exports the facts of the instances, with their dimensions,
+// units, decimals and footnotes, as an xBRL-JSON report
+func MarshalXBRLJSON(h *hydratables.Hydratable) ([]byte, error) {
+	documentInfo, prefixes := oimDocumentInfo(h, attr.XBRLJSON)
+	report := serializables.XBRLJSONFile{
+		DocumentInfo: documentInfo,
+		Facts:        map[string]serializables.XBRLJSONFact{},
+	}
+	fileNames := make([]string, 0, len(h.Instances))
+	for fileName := range h.Instances {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	ids := map[string]bool{}
+	for _, instance := range h.Instances {
+		for _, fact := range instance.Facts {
+			ids[fact.ID] = true
+		}
+	}
+	newID := func(prefix string) string {
+		id := prefix + strconv.Itoa(len(ids))
+		for i := len(ids); ids[id]; i++ {
+			id = prefix + strconv.Itoa(i)
+		}
+		ids[id] = true
+		return id
+	}
+	qnames := map[string]string{}
+	notes := map[string]bool{}
+	for _, fileName := range fileNames {
+		instance := h.Instances[fileName]
+		for _, fact := range instance.Facts {
+			context := getContext(&instance, fact.ContextRef)
+			if context == nil {
+				continue
+			}
+			if _, found := qnames[fact.Href]; !found {
+				qnames[fact.Href] = factQName(h, fact.Href, prefixes)
+			}
+			dimensions := map[string]string{
+				"concept": qnames[fact.Href],
+				"entity":  oimEntity(&report.DocumentInfo, prefixes, context.Entity.Identifier.Scheme, context.Entity.Identifier.CharData),
+			}
+			if context.Period.Duration.StartDate != "" {
+				dimensions["period"] = oimDate(context.Period.Duration.StartDate, false) + "/" +
+					oimDate(context.Period.Duration.EndDate, true)
+			} else if context.Period.Instant.CharData != "" {
+				dimensions["period"] = oimDate(context.Period.Instant.CharData, true)
+			}
+			for href, member := range contextMembers(context) {
+				if dimension := dimensionQName(context, href); dimension != "" {
+					dimensions[dimension] = member
+				}
+			}
+			if unit := oimUnit(&instance, fact.UnitRef); unit != "" {
+				dimensions["unit"] = unit
+			}
+			jsonFact := serializables.XBRLJSONFact{
+				Dimensions: dimensions,
+			}
+			if fact.Precision != hydratables.Precisionless && fact.Precision != hydratables.Exact {
+				decimals := int(fact.Precision)
+				jsonFact.Decimals = &decimals
+			}
+			if !fact.IsNil {
+				value := xmlText(fact.XMLInner)
+				jsonFact.Value = &value
+			}
+			id := fact.ID
+			if id == "" {
+				id = newID("f")
+			}
+			for _, footnote := range h.GetFootnotes(&fact) {
+				if footnote == nil || footnote.ID == "" {
+					continue
+				}
+				if jsonFact.Links == nil {
+					jsonFact.Links = map[string]map[string][]string{
+						"footnote": {
+							"_": {},
+						},
+					}
+				}
+				jsonFact.Links["footnote"]["_"] = append(jsonFact.Links["footnote"]["_"], footnote.ID)
+				if notes[footnote.ID] {
+					continue
+				}
+				notes[footnote.ID] = true
+				value := footnote.InnerHtml
+				report.Facts[footnote.ID] = serializables.XBRLJSONFact{
+					Value: &value,
+					Dimensions: map[string]string{
+						"concept":  "xbrl:note",
+						"noteId":   footnote.ID,
+						"language": footnote.Lang,
+					},
+				}
+			}
+			report.Facts[id] = jsonFact
+		}
+	}
+	if len(notes) > 0 {
+		report.DocumentInfo.LinkTypes = map[string]string{
+			"footnote": attr.FactFootnoteArcrole,
+		}
+		report.DocumentInfo.LinkGroups = map[string]string{
+			"_": attr.ROLELINK,
+		}
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(report)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// oimDocumentInfo declares the namespaces of the instances, the xbrl and
+// xbrli prefixes and the schemas the instances refer to
+func oimDocumentInfo(h *hydratables.Hydratable, documentType string) (serializables.OIMDocumentInfo, map[string]string) {
+	ret := serializables.OIMDocumentInfo{
+		DocumentType: documentType,
+		Namespaces: map[string]string{
+			"xbrl":  attr.OIM,
+			"xbrli": attr.XBRLI,
+		},
+	}
+	fileNames := make([]string, 0, len(h.Instances))
+	for fileName := range h.Instances {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		instanceFile, found := h.Folder.Instances[fileName]
+		if !found {
+			continue
+		}
+		for _, xmlAttr := range instanceFile.XMLAttrs {
+			if xmlAttr.Name.Space != "xmlns" {
+				continue
+			}
+			if _, found := ret.Namespaces[xmlAttr.Name.Local]; !found {
+				ret.Namespaces[xmlAttr.Name.Local] = xmlAttr.Value
+			}
+		}
+		for _, schemaRef := range instanceFile.SchemaRef {
+			hrefAttr := attr.FindAttr(schemaRef.XMLAttrs, "href")
+			if hrefAttr == nil || hrefAttr.Value == "" {
+				continue
+			}
+			ret.Taxonomy = append(ret.Taxonomy, hrefAttr.Value)
+		}
+	}
+	declared := make([]string, 0, len(ret.Namespaces))
+	for prefix := range ret.Namespaces {
+		declared = append(declared, prefix)
+	}
+	sort.Strings(declared)
+	prefixes := map[string]string{}
+	for _, prefix := range declared {
+		if _, found := prefixes[ret.Namespaces[prefix]]; !found {
+			prefixes[ret.Namespaces[prefix]] = prefix
+		}
+	}
+	return ret, prefixes
+}
+
+// oimEntity is the entity of a context, declaring a prefix for the scheme
+// when the instances have none
+func oimEntity(documentInfo *serializables.OIMDocumentInfo, prefixes map[string]string, scheme string, identifier string) string {
+	prefix, found := prefixes[scheme]
+	if !found {
+		prefix = "scheme"
+		for i := 1; documentInfo.Namespaces[prefix] != ""; i++ {
+			prefix = "scheme" + strconv.Itoa(i)
+		}
+		documentInfo.Namespaces[prefix] = scheme
+		prefixes[scheme] = prefix
+	}
+	return prefix + ":" + identifier
+}
+
+func dimensionQName(context *hydratables.Context, href string) string {
+	for _, dimensionContext := range []hydratables.DimensionContext{context.Entity.Segment, context.Scenario} {
+		for _, explicitMember := range dimensionContext.ExplicitMembers {
+			if explicitMember.Dimension.Href == href {
+				return explicitMember.Dimension.Value
+			}
+		}
+		for _, typedMember := range dimensionContext.TypedMembers {
+			if typedMember.Dimension.Href == href {
+				return typedMember.Dimension.Value
+			}
+		}
+	}
+	return ""
+}
+
+// oimDate maps an xBRL-XML date to an OIM date time, an end or instant date
+// being midnight at the end of the day
+func oimDate(date string, isEnd bool) string {
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(date))
+	if err != nil {
+		return strings.TrimSpace(date)
+	}
+	if isEnd {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t.Format("2006-01-02") + "T00:00:00"
+}
+
+// oimUnit is the unit string of a unit, with xbrli:pure left out
+func oimUnit(instance *hydratables.Instance, unitRef string) string {
+	if unitRef == "" {
+		return ""
+	}
+	for _, unit := range instance.Units {
+		if unit.ID != unitRef {
+			continue
+		}
+		measures := func(unitMeasures []hydratables.UnitMeasure, isDenominator bool) string {
+			ret := make([]string, 0, len(unitMeasures))
+			for _, unitMeasure := range unitMeasures {
+				if unitMeasure.XMLName.Space == attr.XBRLI && unitMeasure.XMLName.Local == "pure" {
+					continue
+				}
+				ret = append(ret, unitMeasure.CharData)
+			}
+			sort.Strings(ret)
+			if len(ret) > 1 && (isDenominator || len(unit.Denominators) > 0) {
+				return "(" + strings.Join(ret, "*") + ")"
+			}
+			return strings.Join(ret, "*")
+		}
+		numerators := measures(unit.Numerators, false)
+		if len(unit.Denominators) > 0 {
+			return numerators + "/" + measures(unit.Denominators, true)
+		}
+		return numerators
+	}
+	return ""
+}
+
+// xmlText unescapes the character data of a fact, leaving the markup of XML
+// valued facts as it is
+func xmlText(inner string) string {
+	decoder := xml.NewDecoder(strings.NewReader("<value>" + inner + "</value>"))
+	var sb strings.Builder
+	started := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return sb.String()
+		}
+		if err != nil {
+			return inner
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if started {
+				return inner
+			}
+			started = true
+		case xml.CharData:
+			sb.Write(t)
+		}
+	}
+}
@@ -22,8 +22,10 @@ type Document struct {
 	Units                 []*xmlquery.Node
 	NonFractions          []*xmlquery.Node
 	NonNumerics           []*xmlquery.Node
+	Footnotes             []*xmlquery.Node
+	Continuations         []*xmlquery.Node
+	FootnoteRelationships []*xmlquery.Node
 	factMap               map[string](*xmlquery.Node)
-	footnoteRelationships []*xmlquery.Node
 }
 
 func DecodeIxbrlFile(xmlData []byte) *Document {
@@ -110,10 +112,33 @@ func DecodeIxbrlFile(xmlData []byte) *Document {
 	footnoteRelationshipsDone := make(chan bool)
 	go func() {
 		defer func() { footnoteRelationshipsDone <- true }()
-		footnoteRelationships, err = xmlquery.QueryAll(doc, "//*[local-name()='relationship' and namespace-uri()='"+attr.IX+"' and @arcrole='"+attr.FactFootnoteArcrole+"']")
-		if goErr != nil {
+		var relationshipsErr error
+		footnoteRelationships, relationshipsErr = xmlquery.QueryAll(doc, "//*[local-name()='relationship' and namespace-uri()='"+attr.IX+"']")
+		if relationshipsErr != nil {
 			footnoteRelationships = make([]*xmlquery.Node, 0)
-			fmt.Printf("Error: " + goErr.Error())
+			fmt.Printf("Error: " + relationshipsErr.Error())
+		}
+	}()
+	var footnotes []*xmlquery.Node
+	footnotesDone := make(chan bool)
+	go func() {
+		defer func() { footnotesDone <- true }()
+		var footnotesErr error
+		footnotes, footnotesErr = xmlquery.QueryAll(doc, "//*[local-name()='footnote' and namespace-uri()='"+attr.IX+"']")
+		if footnotesErr != nil {
+			footnotes = make([]*xmlquery.Node, 0)
+			fmt.Printf("Error: " + footnotesErr.Error())
+		}
+	}()
+	var continuations []*xmlquery.Node
+	continuationsDone := make(chan bool)
+	go func() {
+		defer func() { continuationsDone <- true }()
+		var continuationsErr error
+		continuations, continuationsErr = xmlquery.QueryAll(doc, "//*[local-name()='continuation' and namespace-uri()='"+attr.IX+"']")
+		if continuationsErr != nil {
+			continuations = make([]*xmlquery.Node, 0)
+			fmt.Printf("Error: " + continuationsErr.Error())
 		}
 	}()
 	<-htmlDone
@@ -124,6 +149,8 @@ func DecodeIxbrlFile(xmlData []byte) *Document {
 	<-nonNumericsDone
 	<-excludesDone
 	<-footnoteRelationshipsDone
+	<-footnotesDone
+	<-continuationsDone
 	if html == nil {
 		return nil
 	}
@@ -168,8 +195,19 @@ func DecodeIxbrlFile(xmlData []byte) *Document {
 		NonFractions:          nonFractions,
 		NonNumerics:           nonNumerics,
 		Excludes:              excludes,
-		footnoteRelationships: footnoteRelationships,
+		Footnotes:             footnotes,
+		Continuations:         continuations,
+		FootnoteRelationships: footnoteRelationships,
 		Units:                 units,
 		factMap:               factMap,
 	}
 }
+
+// FactElement finds the ix:nonFraction or ix:nonNumeric element of a fact by
+// its id
+func (doc *Document) FactElement(id string) *xmlquery.Node {
+	if doc == nil {
+		return nil
+	}
+	return doc.factMap[id]
+}
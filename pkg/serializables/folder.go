@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"ecksbee.com/telefacts/pkg/attr"
@@ -26,6 +28,9 @@ type Folder struct {
 	PresentationLinkbases map[string]PresentationLinkbaseFile
 	DefinitionLinkbases   map[string]DefinitionLinkbaseFile
 	CalculationLinkbases  map[string]CalculationLinkbaseFile
+	ReferenceLinkbases    map[string]ReferenceLinkbaseFile
+	TableLinkbases        map[string]TableLinkbaseFile
+	GlobalLinkbaseRefs    map[string]string
 	Images                map[string]string
 }
 
@@ -45,6 +50,9 @@ func Discover(id string) (*Folder, error) {
 		PresentationLinkbases: make(map[string]PresentationLinkbaseFile),
 		DefinitionLinkbases:   make(map[string]DefinitionLinkbaseFile),
 		CalculationLinkbases:  make(map[string]CalculationLinkbaseFile),
+		ReferenceLinkbases:    make(map[string]ReferenceLinkbaseFile),
+		TableLinkbases:        make(map[string]TableLinkbaseFile),
+		GlobalLinkbaseRefs:    make(map[string]string),
 		Images:                make(map[string]string),
 	}
 	ret.processImages(workingDir)
@@ -86,6 +94,25 @@ func Discover(id string) (*Folder, error) {
 		ret.wLock.Lock()
 		defer ret.wLock.Unlock()
 		ret.Instances[entryFileName] = *instanceFile
+	case ".json":
+		jsonFilePath := filepath.Join(workingDir, entryFileName)
+		documentInfo, err := ReadOIMDocumentInfo(jsonFilePath)
+		if err != nil {
+			return nil, err
+		}
+		ret.taxonomyRef(documentInfo.Taxonomy)
+		instanceFile, err := ReadOIMFile(jsonFilePath, ret.TypedDomain)
+		if err != nil {
+			return nil, err
+		}
+		ret.wLock.Lock()
+		defer ret.wLock.Unlock()
+		ret.Instances[entryFileName] = *instanceFile
+	case ".xsd":
+		err := ret.discoverSchema(entryFileName)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return ret, nil
 }
@@ -94,61 +121,172 @@ func (folder *Folder) schemaRef(file *InstanceFile) {
 	if file == nil {
 		return
 	}
-	schemaRefs := file.SchemaRef
+	hrefs := make([]string, 0, len(file.SchemaRef))
+	for _, item := range file.SchemaRef {
+		if item.XMLName.Space != attr.LINK {
+			continue
+		}
+		hrefAttr := attr.FindAttr(item.XMLAttrs, "href")
+		if hrefAttr == nil || hrefAttr.Value == "" {
+			continue
+		}
+		hrefs = append(hrefs, hrefAttr.Value)
+	}
+	folder.taxonomyRef(hrefs)
+}
+
+// taxonomyRef discovers the entry points of the taxonomy of a report
+func (folder *Folder) taxonomyRef(hrefs []string) {
 	var wg sync.WaitGroup
-	wg.Add(len(schemaRefs))
-	for _, iitem := range schemaRefs {
-		go func(item struct {
-			XMLName  xml.Name
-			XMLAttrs []xml.Attr "xml:\",any,attr\""
-		}) {
+	wg.Add(len(hrefs))
+	for _, href := range hrefs {
+		go func(href string) {
 			defer wg.Done()
-			if item.XMLName.Space != attr.LINK {
-				return
-			}
-			hrefAttr := attr.FindAttr(item.XMLAttrs, "href")
-			if hrefAttr == nil || hrefAttr.Value == "" {
+			if attr.IsValidUrl(href) {
+				go DiscoverGlobalSchema(href)
 				return
 			}
-			if attr.IsValidUrl(hrefAttr.Value) {
-				go DiscoverGlobalSchema(hrefAttr.Value)
-				return
-			}
-			schemaFilePath := filepath.Join(folder.Dir, hrefAttr.Value)
-			discoveredSchema, err := ReadSchemaFile(schemaFilePath)
-			if err != nil {
-				return
-			}
-			targetNS := attr.FindAttr(discoveredSchema.XMLAttrs, "targetNamespace")
-			if targetNS == nil || targetNS.Value == "" {
-				return
-			}
-			folder.wLock.Lock()
-			folder.Namespaces[targetNS.Value] = hrefAttr.Value
-			folder.wLock.Unlock()
-			var wwg sync.WaitGroup
-			wwg.Add(3)
-			go func() {
-				defer wwg.Done()
-				folder.importSchema(discoveredSchema)
-			}()
-			go func() {
-				defer wwg.Done()
-				folder.includeSchema(discoveredSchema)
-			}()
-			go func() {
-				defer wwg.Done()
-				folder.linkbaseRefSchema(discoveredSchema)
-			}()
-			wwg.Wait()
-			folder.wLock.Lock()
-			folder.Schemas[hrefAttr.Value] = *discoveredSchema
-			folder.wLock.Unlock()
-		}(iitem)
+			folder.discoverSchema(href)
+		}(href)
 	}
 	wg.Wait()
 }
 
+// TypedDomain finds the element referenced by the xbrldt:typedDomainRef of
+// a dimension of the taxonomy
+func (folder *Folder) TypedDomain(dimension xml.Name) (xml.Name, bool) {
+	folder.wLock.Lock()
+	schemaHref := folder.Namespaces[dimension.Space]
+	folder.wLock.Unlock()
+	schema := folder.lookupSchema(schemaHref)
+	if schema == nil {
+		return xml.Name{}, false
+	}
+	typedDomainRef := ""
+	for _, element := range schema.Element {
+		nameAttr := attr.FindAttr(element.XMLAttrs, "name")
+		if nameAttr == nil || nameAttr.Value != dimension.Local {
+			continue
+		}
+		typedDomainRefAttr := attr.FindAttr(element.XMLAttrs, "typedDomainRef")
+		if typedDomainRefAttr != nil && typedDomainRefAttr.Name.Space == attr.XBRLDT {
+			typedDomainRef = typedDomainRefAttr.Value
+		}
+		break
+	}
+	i := strings.IndexRune(typedDomainRef, '#')
+	if i < 0 {
+		return xml.Name{}, false
+	}
+	domainHref := schemaHref
+	if i > 0 {
+		domainHref = resolveHref(schemaHref, typedDomainRef[:i])
+	}
+	domainSchema := folder.lookupSchema(domainHref)
+	if domainSchema == nil {
+		return xml.Name{}, false
+	}
+	targetNS := attr.FindAttr(domainSchema.XMLAttrs, "targetNamespace")
+	if targetNS == nil || targetNS.Value == "" {
+		return xml.Name{}, false
+	}
+	for _, element := range domainSchema.Element {
+		idAttr := attr.FindAttr(element.XMLAttrs, "id")
+		if idAttr == nil || idAttr.Value != typedDomainRef[i+1:] {
+			continue
+		}
+		nameAttr := attr.FindAttr(element.XMLAttrs, "name")
+		if nameAttr == nil || nameAttr.Value == "" {
+			return xml.Name{}, false
+		}
+		return xml.Name{
+			Space: targetNS.Value,
+			Local: nameAttr.Value,
+		}, true
+	}
+	return xml.Name{}, false
+}
+
+// lookupSchema finds a schema of the folder, or of the global taxonomies
+func (folder *Folder) lookupSchema(href string) *SchemaFile {
+	if href == "" {
+		return nil
+	}
+	if attr.IsValidUrl(href) {
+		schema, err := DiscoverGlobalSchema(href)
+		if err != nil {
+			return nil
+		}
+		return schema
+	}
+	folder.wLock.Lock()
+	schema, found := folder.Schemas[href]
+	folder.wLock.Unlock()
+	if found {
+		return &schema
+	}
+	discovered, err := ReadSchemaFile(filepath.Join(folder.Dir, href))
+	if err != nil {
+		return nil
+	}
+	return discovered
+}
+
+// resolveHref resolves a schema location relative to the schema referencing it
+func resolveHref(base string, href string) string {
+	if attr.IsValidUrl(href) {
+		return href
+	}
+	if attr.IsValidUrl(base) {
+		baseUrl, err := url.Parse(base)
+		if err != nil {
+			return href
+		}
+		ref, err := url.Parse(href)
+		if err != nil {
+			return href
+		}
+		return baseUrl.ResolveReference(ref).String()
+	}
+	return path.Join(path.Dir(base), href)
+}
+
+// discoverSchema reads a schema of the folder along with the schemas it
+// imports or includes and the linkbases it references
+func (folder *Folder) discoverSchema(href string) error {
+	schemaFilePath := filepath.Join(folder.Dir, href)
+	discoveredSchema, err := ReadSchemaFile(schemaFilePath)
+	if err != nil {
+		return err
+	}
+	targetNS := attr.FindAttr(discoveredSchema.XMLAttrs, "targetNamespace")
+	if targetNS == nil || targetNS.Value == "" {
+		return fmt.Errorf("%s has no targetNamespace", href)
+	}
+	folder.wLock.Lock()
+	folder.Namespaces[targetNS.Value] = href
+	folder.wLock.Unlock()
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		folder.importSchema(discoveredSchema)
+	}()
+	go func() {
+		defer wg.Done()
+		folder.includeSchema(discoveredSchema)
+	}()
+	go func() {
+		defer wg.Done()
+		folder.linkbaseRefSchema(discoveredSchema)
+	}()
+	wg.Wait()
+	folder.wLock.Lock()
+	folder.Schemas[href] = *discoveredSchema
+	folder.wLock.Unlock()
+	return nil
+}
+
 func (folder *Folder) includeSchema(file *SchemaFile) {
 	if file == nil {
 		return
@@ -298,20 +436,23 @@ func (folder *Folder) linkbaseRefSchema(file *SchemaFile) {
 					if typeAttr == nil || typeAttr.Name.Space != attr.XLINK || typeAttr.Value != "simple" {
 						return
 					}
+					role := ""
 					roleAttr := attr.FindAttr(item.XMLAttrs, "role")
-					if roleAttr == nil || roleAttr.Name.Space != attr.XLINK || roleAttr.Value == "" {
-						return
+					if roleAttr != nil && roleAttr.Name.Space == attr.XLINK {
+						role = roleAttr.Value
 					}
 					hrefAttr := attr.FindAttr(item.XMLAttrs, "href")
 					if hrefAttr == nil || hrefAttr.Name.Space != attr.XLINK || hrefAttr.Value == "" {
 						return
 					}
 					if attr.IsValidUrl(hrefAttr.Value) {
-						go DiscoverGlobalFile(hrefAttr.Value)
+						folder.wLock.Lock()
+						folder.GlobalLinkbaseRefs[hrefAttr.Value] = role
+						folder.wLock.Unlock()
 						return
 					}
 					linkbaseFilePath := filepath.Join(folder.Dir, hrefAttr.Value)
-					switch roleAttr.Value {
+					switch role {
 					case attr.PresentationLinkbaseRef:
 						discoveredPre, err := ReadPresentationLinkbaseFile(linkbaseFilePath)
 						if err != nil {
@@ -348,7 +489,27 @@ func (folder *Folder) linkbaseRefSchema(file *SchemaFile) {
 						folder.LabelLinkbases[hrefAttr.Value] = *discoveredLab
 						folder.wLock.Unlock()
 						break
+					case attr.ReferenceLinkbaseRef:
+						discoveredRef, err := ReadReferenceLinkbaseFile(linkbaseFilePath)
+						if err != nil {
+							return
+						}
+						folder.wLock.Lock()
+						folder.ReferenceLinkbases[hrefAttr.Value] = *discoveredRef
+						folder.wLock.Unlock()
+						break
 					default:
+						data, err := os.ReadFile(linkbaseFilePath)
+						if err != nil || !IsGenericLinkbase(data) {
+							return
+						}
+						discoveredTable, err := DecodeTableLinkbaseFile(data)
+						if err != nil {
+							return
+						}
+						folder.wLock.Lock()
+						folder.TableLinkbases[hrefAttr.Value] = *discoveredTable
+						folder.wLock.Unlock()
 						break
 					}
 				}(iitem)
@@ -71,6 +71,30 @@ func DiscoverGlobalSchema(urlStr string) (*SchemaFile, error) {
 	return DecodeSchemaFile(bytes)
 }
 
+func DiscoverGlobalPresentationLinkbase(urlStr string) (*PresentationLinkbaseFile, error) {
+	bytes, err := DiscoverGlobalFile(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	return DecodePresentationLinkbaseFile(bytes)
+}
+
+func DiscoverGlobalDefinitionLinkbase(urlStr string) (*DefinitionLinkbaseFile, error) {
+	bytes, err := DiscoverGlobalFile(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeDefinitionLinkbaseFile(bytes)
+}
+
+func DiscoverGlobalCalculationLinkbase(urlStr string) (*CalculationLinkbaseFile, error) {
+	bytes, err := DiscoverGlobalFile(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeCalculationLinkbaseFile(bytes)
+}
+
 func DiscoverEntityNames() (map[string]map[string]string, error) {
 	filename := filepath.Join(WorkingDirectoryPath, "names.json")
 	names := make(map[string]map[string]string)
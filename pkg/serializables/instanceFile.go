@@ -59,6 +59,10 @@ type InstanceFile struct {
 				XMLAttrs []xml.Attr `xml:",any,attr"`
 				CharData string     `xml:",chardata"`
 			} `xml:"endDate"`
+			Forever []struct {
+				XMLName  xml.Name
+				XMLAttrs []xml.Attr `xml:",any,attr"`
+			} `xml:"forever"`
 		} `xml:"period"`
 		Scenario []struct {
 			XMLName        xml.Name
@@ -0,0 +1,62 @@
+package serializables
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+
+	"golang.org/x/net/html/charset"
+)
+
+type ReferenceLinkbaseFile struct {
+	XMLName  xml.Name   `xml:"linkbase"`
+	XMLAttrs []xml.Attr `xml:",any,attr"`
+	RoleRef  []struct {
+		XMLName  xml.Name
+		XMLAttrs []xml.Attr `xml:",any,attr"`
+	} `xml:"roleRef"`
+	ReferenceLink []struct {
+		XMLName  xml.Name
+		XMLAttrs []xml.Attr `xml:",any,attr"`
+		Loc      []struct {
+			XMLName  xml.Name
+			XMLAttrs []xml.Attr `xml:",any,attr"`
+		} `xml:"loc"`
+		Reference []struct {
+			XMLName  xml.Name
+			XMLAttrs []xml.Attr `xml:",any,attr"`
+			Part     []struct {
+				XMLName  xml.Name
+				CharData string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"reference"`
+		ReferenceArc []struct {
+			XMLName  xml.Name
+			XMLAttrs []xml.Attr `xml:",any,attr"`
+		} `xml:"referenceArc"`
+	} `xml:"referenceLink"`
+}
+
+func DecodeReferenceLinkbaseFile(xmlData []byte) (*ReferenceLinkbaseFile, error) {
+	reader := bytes.NewReader(xmlData)
+	decoder := xml.NewDecoder(reader)
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoded := ReferenceLinkbaseFile{}
+	err := decoder.Decode(&decoded)
+	if err != nil {
+		return nil, err
+	}
+	return &decoded, nil
+}
+
+func ReadReferenceLinkbaseFile(filepath string) (*ReferenceLinkbaseFile, error) {
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := DecodeReferenceLinkbaseFile(data)
+	if err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
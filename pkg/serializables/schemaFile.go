@@ -60,6 +60,40 @@ type SchemaFile struct {
 		XMLAttrs []xml.Attr `xml:",any,attr"`
 		CharData string     `xml:",chardata"`
 	} `xml:"element"`
+	ComplexType []struct {
+		XMLName       xml.Name
+		XMLAttrs      []xml.Attr `xml:",any,attr"`
+		SimpleContent []struct {
+			XMLName     xml.Name
+			Restriction []struct {
+				XMLName  xml.Name
+				XMLAttrs []xml.Attr `xml:",any,attr"`
+			} `xml:"restriction"`
+			Extension []struct {
+				XMLName  xml.Name
+				XMLAttrs []xml.Attr `xml:",any,attr"`
+			} `xml:"extension"`
+		} `xml:"simpleContent"`
+		ComplexContent []struct {
+			XMLName     xml.Name
+			Restriction []struct {
+				XMLName  xml.Name
+				XMLAttrs []xml.Attr `xml:",any,attr"`
+			} `xml:"restriction"`
+			Extension []struct {
+				XMLName  xml.Name
+				XMLAttrs []xml.Attr `xml:",any,attr"`
+			} `xml:"extension"`
+		} `xml:"complexContent"`
+	} `xml:"complexType"`
+	SimpleType []struct {
+		XMLName     xml.Name
+		XMLAttrs    []xml.Attr `xml:",any,attr"`
+		Restriction []struct {
+			XMLName  xml.Name
+			XMLAttrs []xml.Attr `xml:",any,attr"`
+		} `xml:"restriction"`
+	} `xml:"simpleType"`
 }
 
 func DecodeSchemaFile(xmlData []byte) (*SchemaFile, error) {
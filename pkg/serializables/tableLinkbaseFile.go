@@ -0,0 +1,91 @@
+package serializables
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"golang.org/x/net/html/charset"
+)
+
+// TableLinkbaseFile is a generic linkbase, such as a Table Linkbase with its
+// tables, breakdowns and definition nodes, or the generic labels of them
+type TableLinkbaseFile struct {
+	XMLName  xml.Name   `xml:"linkbase"`
+	XMLAttrs []xml.Attr `xml:",any,attr"`
+	RoleRef  []struct {
+		XMLName  xml.Name
+		XMLAttrs []xml.Attr `xml:",any,attr"`
+	} `xml:"roleRef"`
+	ArcroleRef []struct {
+		XMLName  xml.Name
+		XMLAttrs []xml.Attr `xml:",any,attr"`
+	} `xml:"arcroleRef"`
+	Link []struct {
+		XMLName  xml.Name
+		XMLAttrs []xml.Attr    `xml:",any,attr"`
+		Elements []LinkElement `xml:",any"`
+	} `xml:"link"`
+}
+
+// LinkElement is a locator, arc or resource of a generic link, with the
+// elements nested in it, e.g. the aspect rules of a rule node
+type LinkElement struct {
+	XMLName  xml.Name
+	XMLAttrs []xml.Attr    `xml:",any,attr"`
+	CharData string        `xml:",chardata"`
+	Children []LinkElement `xml:",any"`
+}
+
+func DecodeTableLinkbaseFile(xmlData []byte) (*TableLinkbaseFile, error) {
+	reader := bytes.NewReader(xmlData)
+	decoder := xml.NewDecoder(reader)
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoded := TableLinkbaseFile{}
+	err := decoder.Decode(&decoded)
+	if err != nil {
+		return nil, err
+	}
+	return &decoded, nil
+}
+
+// ReadTableLinkbaseFile reads a linkbase with generic links, failing on a
+// standard linkbase
+func ReadTableLinkbaseFile(filepath string) (*TableLinkbaseFile, error) {
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+	if !IsGenericLinkbase(data) {
+		return nil, fmt.Errorf("%s has no generic links", filepath)
+	}
+	return DecodeTableLinkbaseFile(data)
+}
+
+// IsGenericLinkbase tells whether the root of a linkbase holds a gen:link,
+// without decoding the rest of it
+func IsGenericLinkbase(xmlData []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(xmlData))
+	decoder.CharsetReader = charset.NewReaderLabel
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		switch elem := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 1 && (elem.Name.Space != attr.LINK || elem.Name.Local != "linkbase") {
+				return false
+			}
+			if depth == 2 && elem.Name.Space == attr.GEN && elem.Name.Local == "link" {
+				return true
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package serializables
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ecksbee.com/telefacts/pkg/attr"
+)
+
+// XBRLCSVFile is the metadata of an xBRL-CSV report, describing the CSV
+// tables of the report with table templates
+type XBRLCSVFile struct {
+	DocumentInfo   OIMDocumentInfo                 `json:"documentInfo"`
+	TableTemplates map[string]XBRLCSVTableTemplate `json:"tableTemplates"`
+	Tables         map[string]XBRLCSVTable         `json:"tables"`
+	Dimensions     map[string]string               `json:"dimensions,omitempty"`
+	Decimals       json.RawMessage                 `json:"decimals,omitempty"`
+	Parameters     map[string]string               `json:"parameters,omitempty"`
+}
+
+type XBRLCSVTableTemplate struct {
+	RowIDColumn string                   `json:"rowIdColumn,omitempty"`
+	Columns     map[string]XBRLCSVColumn `json:"columns"`
+	Dimensions  map[string]string        `json:"dimensions,omitempty"`
+	Decimals    json.RawMessage          `json:"decimals,omitempty"`
+}
+
+// XBRLCSVColumn is a fact column when it has Dimensions, even empty ones,
+// or else a property column that dimensions refer to as $column
+type XBRLCSVColumn struct {
+	Dimensions map[string]string `json:"dimensions,omitempty"`
+	Decimals   json.RawMessage   `json:"decimals,omitempty"`
+}
+
+type XBRLCSVTable struct {
+	Template   string            `json:"template,omitempty"`
+	URL        string            `json:"url"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// ReadOIMFile reads an xBRL-JSON report or the metadata of an xBRL-CSV
+// report, with its tables relative to the metadata
+func ReadOIMFile(filePath string, typedDomain TypedDomainFinder) (*InstanceFile, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	peeked := struct {
+		DocumentInfo OIMDocumentInfo `json:"documentInfo"`
+	}{}
+	err = json.Unmarshal(data, &peeked)
+	if err != nil {
+		return nil, err
+	}
+	if peeked.DocumentInfo.DocumentType == attr.XBRLCSV {
+		return DecodeXBRLCSVFile(data, func(url string) ([]byte, error) {
+			tablePath, err := oimTablePath(filepath.Dir(filePath), url)
+			if err != nil {
+				return nil, err
+			}
+			return os.ReadFile(tablePath)
+		}, typedDomain)
+	}
+	return DecodeXBRLJSONFile(data, typedDomain)
+}
+
+// oimTablePath maps the url of a table to a file of the metadata's
+// directory, refusing absolute urls and paths leaving the directory
+func oimTablePath(dir string, tableUrl string) (string, error) {
+	parsed, err := url.Parse(tableUrl)
+	if err != nil {
+		return "", err
+	}
+	if parsed.IsAbs() || parsed.Host != "" || path.IsAbs(tableUrl) ||
+		filepath.IsAbs(filepath.FromSlash(tableUrl)) || filepath.VolumeName(filepath.FromSlash(tableUrl)) != "" {
+		return "", fmt.Errorf("table url %s is not relative to the metadata", tableUrl)
+	}
+	tablePath := filepath.Join(dir, filepath.FromSlash(tableUrl))
+	rel, err := filepath.Rel(dir, tablePath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("table url %s is outside of %s", tableUrl, dir)
+	}
+	return tablePath, nil
+}
+
+// ReadOIMDocumentInfo reads the document info of an xBRL-JSON report or of
+// the metadata of an xBRL-CSV report
+func ReadOIMDocumentInfo(filePath string) (*OIMDocumentInfo, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	peeked := struct {
+		DocumentInfo OIMDocumentInfo `json:"documentInfo"`
+	}{}
+	err = json.Unmarshal(data, &peeked)
+	if err != nil {
+		return nil, err
+	}
+	return &peeked.DocumentInfo, nil
+}
+
+// DecodeXBRLCSVFile converts the metadata of an xBRL-CSV report, and the
+// tables read by readTable, into the xBRL-XML instance it is equivalent to
+func DecodeXBRLCSVFile(jsonData []byte, readTable func(url string) ([]byte, error), typedDomain TypedDomainFinder) (*InstanceFile, error) {
+	metadata := XBRLCSVFile{}
+	err := json.Unmarshal(jsonData, &metadata)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.DocumentInfo.DocumentType != attr.XBRLCSV {
+		return nil, fmt.Errorf("unsupported documentType %s", metadata.DocumentInfo.DocumentType)
+	}
+	report := XBRLJSONFile{
+		DocumentInfo: metadata.DocumentInfo,
+		Facts:        map[string]XBRLJSONFact{},
+	}
+	report.DocumentInfo.DocumentType = attr.XBRLJSON
+	tableNames := make([]string, 0, len(metadata.Tables))
+	for tableName := range metadata.Tables {
+		tableNames = append(tableNames, tableName)
+	}
+	sort.Strings(tableNames)
+	for _, tableName := range tableNames {
+		table := metadata.Tables[tableName]
+		templateName := table.Template
+		if templateName == "" {
+			templateName = tableName
+		}
+		template, found := metadata.TableTemplates[templateName]
+		if !found {
+			return nil, fmt.Errorf("table %s has no template %s", tableName, templateName)
+		}
+		data, err := readTable(table.URL)
+		if err != nil {
+			return nil, err
+		}
+		err = metadata.decodeTable(tableName, table, template, data, &report)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode table %s, %v", tableName, err)
+		}
+	}
+	xmlData, err := report.instanceXML(typedDomain)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeInstanceFile(xmlData)
+}
+
+func (metadata *XBRLCSVFile) decodeTable(tableName string, table XBRLCSVTable, template XBRLCSVTableTemplate, data []byte, report *XBRLJSONFile) error {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) <= 0 {
+		return nil
+	}
+	header := records[0]
+	columns := map[string]int{}
+	for i, column := range header {
+		columns[column] = i
+	}
+	for i, record := range records[1:] {
+		rowID := strconv.Itoa(i + 1)
+		if j, found := columns[template.RowIDColumn]; found && j < len(record) && record[j] != "" {
+			rowID = record[j]
+		}
+		resolve := func(value string) (string, bool) {
+			if !strings.HasPrefix(value, "$") || strings.HasPrefix(value, "$$") {
+				return strings.TrimPrefix(value, "$"), true
+			}
+			name := value[1:]
+			if j, found := columns[name]; found {
+				if j >= len(record) || record[j] == "" {
+					return "", false
+				}
+				return record[j], true
+			}
+			if parameter, found := table.Parameters[name]; found {
+				return parameter, true
+			}
+			if parameter, found := metadata.Parameters[name]; found {
+				return parameter, true
+			}
+			return "", false
+		}
+		for j, columnName := range header {
+			column, found := template.Columns[columnName]
+			if !found || column.Dimensions == nil || j >= len(record) || record[j] == "" {
+				continue
+			}
+			dimensions := map[string]string{}
+			for _, properties := range []map[string]string{metadata.Dimensions, template.Dimensions, column.Dimensions} {
+				for dimension, value := range properties {
+					resolved, ok := resolve(value)
+					if !ok || resolved == "#none" {
+						delete(dimensions, dimension)
+						continue
+					}
+					dimensions[dimension] = resolved
+				}
+			}
+			fact := XBRLJSONFact{
+				Dimensions: dimensions,
+			}
+			for _, decimals := range []json.RawMessage{metadata.Decimals, template.Decimals, column.Decimals} {
+				if len(decimals) <= 0 {
+					continue
+				}
+				var value interface{}
+				if json.Unmarshal(decimals, &value) != nil {
+					continue
+				}
+				resolved := ""
+				switch v := value.(type) {
+				case float64:
+					resolved = strconv.Itoa(int(v))
+				case string:
+					resolved, _ = resolve(v)
+				}
+				if d, err := strconv.Atoi(resolved); err == nil {
+					fact.Decimals = &d
+				}
+			}
+			switch record[j] {
+			case "#nil":
+			case "#empty":
+				empty := ""
+				fact.Value = &empty
+			default:
+				value := record[j]
+				fact.Value = &value
+			}
+			report.Facts[tableName+"."+rowID+"."+columnName] = fact
+		}
+	}
+	return nil
+}
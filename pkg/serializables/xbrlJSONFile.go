@@ -0,0 +1,338 @@
+package serializables
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecksbee.com/telefacts/pkg/attr"
+)
+
+// XBRLJSONFile is an xBRL-JSON report of the Open Information Model
+type XBRLJSONFile struct {
+	DocumentInfo OIMDocumentInfo         `json:"documentInfo"`
+	Facts        map[string]XBRLJSONFact `json:"facts"`
+}
+
+type OIMDocumentInfo struct {
+	DocumentType string            `json:"documentType"`
+	Namespaces   map[string]string `json:"namespaces,omitempty"`
+	LinkTypes    map[string]string `json:"linkTypes,omitempty"`
+	LinkGroups   map[string]string `json:"linkGroups,omitempty"`
+	Taxonomy     []string          `json:"taxonomy,omitempty"`
+}
+
+// XBRLJSONFact is a fact or a footnote, which is a fact of the xbrl:note
+// concept; a nil Value is a nil fact
+type XBRLJSONFact struct {
+	Value      *string                        `json:"value"`
+	Decimals   *int                           `json:"decimals,omitempty"`
+	Dimensions map[string]string              `json:"dimensions"`
+	Links      map[string]map[string][]string `json:"links,omitempty"`
+}
+
+// TypedDomainFinder finds the typed domain element of a dimension; explicit
+// dimensions have none
+type TypedDomainFinder func(dimension xml.Name) (xml.Name, bool)
+
+// DecodeXBRLJSONFile converts an xBRL-JSON report into the xBRL-XML instance
+// it is equivalent to, placing the taxonomy defined dimensions in segments.
+// The values of the typed dimensions found by typedDomain are wrapped in
+// their typed domain elements; without typedDomain, all are explicit.
+func DecodeXBRLJSONFile(jsonData []byte, typedDomain TypedDomainFinder) (*InstanceFile, error) {
+	decoded := XBRLJSONFile{}
+	err := json.Unmarshal(jsonData, &decoded)
+	if err != nil {
+		return nil, err
+	}
+	if decoded.DocumentInfo.DocumentType != attr.XBRLJSON {
+		return nil, fmt.Errorf("unsupported documentType %s", decoded.DocumentInfo.DocumentType)
+	}
+	xmlData, err := decoded.instanceXML(typedDomain)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeInstanceFile(xmlData)
+}
+
+func ReadXBRLJSONFile(filepath string, typedDomain TypedDomainFinder) (*InstanceFile, error) {
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeXBRLJSONFile(data, typedDomain)
+}
+
+func (file *XBRLJSONFile) instanceXML(typedDomain TypedDomainFinder) ([]byte, error) {
+	namespaces := map[string]string{}
+	prefixes := map[string]string{}
+	for prefix, namespace := range file.DocumentInfo.Namespaces {
+		namespaces[prefix] = namespace
+		prefixes[namespace] = prefix
+	}
+	for _, required := range []struct{ prefix, namespace string }{
+		{"xbrli", attr.XBRLI},
+		{"link", attr.LINK},
+		{"xlink", attr.XLINK},
+		{"xbrldi", attr.XBRLDI},
+		{"xsi", attr.XSI},
+	} {
+		if _, found := prefixes[required.namespace]; found {
+			continue
+		}
+		if _, found := namespaces[required.prefix]; found {
+			return nil, fmt.Errorf("prefix %s is not bound to %s", required.prefix, required.namespace)
+		}
+		namespaces[required.prefix] = required.namespace
+		prefixes[required.namespace] = required.prefix
+	}
+	xbrli := prefixes[attr.XBRLI] + ":"
+	link := prefixes[attr.LINK] + ":"
+	xlink := prefixes[attr.XLINK] + ":"
+	xbrldi := prefixes[attr.XBRLDI] + ":"
+	xsi := prefixes[attr.XSI] + ":"
+	prefix := func(namespace string) string {
+		if prefix, found := prefixes[namespace]; found {
+			return prefix
+		}
+		prefix := "ns"
+		for i := 1; namespaces[prefix] != ""; i++ {
+			prefix = "ns" + strconv.Itoa(i)
+		}
+		namespaces[prefix] = namespace
+		prefixes[namespace] = prefix
+		return prefix
+	}
+	isOIM := func(qname string, local string) bool {
+		i := strings.IndexRune(qname, ':')
+		return i > -1 && namespaces[qname[:i]] == attr.OIM && qname[i+1:] == local
+	}
+	ids := make([]string, 0, len(file.Facts))
+	for id := range file.Facts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var contexts, units, facts, footnotes, arcs strings.Builder
+	contextIDs := map[string]string{}
+	unitIDs := map[string]string{}
+	for _, id := range ids {
+		fact := file.Facts[id]
+		concept := fact.Dimensions["concept"]
+		if concept == "" {
+			continue
+		}
+		if isOIM(concept, "note") {
+			if fact.Value == nil {
+				continue
+			}
+			lang := fact.Dimensions["language"]
+			if lang == "" {
+				lang = "en"
+			}
+			footnotes.WriteString(`<` + link + `footnote ` + xlink + `type="resource" ` + xlink + `role="` + attr.ROLEFOOTNOTE +
+				`" ` + xlink + `label="` + escapeText(id) + `" id="` + escapeText(id) + `" xml:lang="` + escapeText(lang) + `">`)
+			if isXMLFragment(*fact.Value) {
+				footnotes.WriteString(*fact.Value)
+			} else {
+				footnotes.WriteString(escapeText(*fact.Value))
+			}
+			footnotes.WriteString(`</` + link + `footnote>`)
+			continue
+		}
+		entity := fact.Dimensions["entity"]
+		i := strings.IndexRune(entity, ':')
+		if i < 0 {
+			continue
+		}
+		scheme, found := namespaces[entity[:i]]
+		if !found {
+			continue
+		}
+		dimensions := make([]string, 0, len(fact.Dimensions))
+		for dimension := range fact.Dimensions {
+			if strings.IndexRune(dimension, ':') < 0 {
+				continue
+			}
+			dimensions = append(dimensions, dimension)
+		}
+		sort.Strings(dimensions)
+		contextKey := entity + "|" + fact.Dimensions["period"]
+		for _, dimension := range dimensions {
+			contextKey += "|" + dimension + "=" + fact.Dimensions[dimension]
+		}
+		contextID, found := contextIDs[contextKey]
+		if !found {
+			contextID = "c" + strconv.Itoa(len(contextIDs)+1)
+			contextIDs[contextKey] = contextID
+			contexts.WriteString(`<` + xbrli + `context id="` + contextID + `"><` + xbrli + `entity><` + xbrli +
+				`identifier scheme="` + escapeText(scheme) + `">` + escapeText(entity[i+1:]) + `</` + xbrli + `identifier>`)
+			if len(dimensions) > 0 {
+				contexts.WriteString(`<` + xbrli + `segment>`)
+				for _, dimension := range dimensions {
+					member := fact.Dimensions[dimension]
+					domain, typed := xml.Name{}, false
+					if typedDomain != nil {
+						j := strings.IndexRune(dimension, ':')
+						domain, typed = typedDomain(xml.Name{
+							Space: namespaces[dimension[:j]],
+							Local: dimension[j+1:],
+						})
+					}
+					if !typed {
+						contexts.WriteString(`<` + xbrldi + `explicitMember dimension="` + escapeText(dimension) + `">` +
+							escapeText(member) + `</` + xbrldi + `explicitMember>`)
+						continue
+					}
+					element := prefix(domain.Space) + ":" + domain.Local
+					contexts.WriteString(`<` + xbrldi + `typedMember dimension="` + escapeText(dimension) + `"><` + element + `>` +
+						escapeText(member) + `</` + element + `></` + xbrldi + `typedMember>`)
+				}
+				contexts.WriteString(`</` + xbrli + `segment>`)
+			}
+			contexts.WriteString(`</` + xbrli + `entity><` + xbrli + `period>`)
+			period := fact.Dimensions["period"]
+			if period == "" {
+				contexts.WriteString(`<` + xbrli + `forever/>`)
+			} else if j := strings.IndexRune(period, '/'); j > -1 {
+				contexts.WriteString(`<` + xbrli + `startDate>` + escapeText(xmlDate(period[:j], false)) + `</` + xbrli + `startDate>`)
+				contexts.WriteString(`<` + xbrli + `endDate>` + escapeText(xmlDate(period[j+1:], true)) + `</` + xbrli + `endDate>`)
+			} else {
+				contexts.WriteString(`<` + xbrli + `instant>` + escapeText(xmlDate(period, true)) + `</` + xbrli + `instant>`)
+			}
+			contexts.WriteString(`</` + xbrli + `period></` + xbrli + `context>`)
+		}
+		facts.WriteString(`<` + concept + ` id="` + escapeText(id) + `" contextRef="` + contextID + `"`)
+		unit := fact.Dimensions["unit"]
+		if unit == "" && fact.Decimals != nil {
+			unit = xbrli + "pure"
+		}
+		if unit != "" {
+			unitID, found := unitIDs[unit]
+			if !found {
+				unitID = "u" + strconv.Itoa(len(unitIDs)+1)
+				unitIDs[unit] = unitID
+				units.WriteString(unitXML(unitID, unit, xbrli))
+			}
+			facts.WriteString(` unitRef="` + unitID + `"`)
+			if fact.Decimals != nil {
+				facts.WriteString(` decimals="` + strconv.Itoa(*fact.Decimals) + `"`)
+			} else if fact.Value != nil {
+				facts.WriteString(` decimals="INF"`)
+			}
+		}
+		if fact.Value == nil {
+			facts.WriteString(` ` + xsi + `nil="true"/>`)
+		} else {
+			facts.WriteString(`>` + escapeText(*fact.Value) + `</` + concept + `>`)
+		}
+		for linkType, groups := range fact.Links {
+			arcrole := file.DocumentInfo.LinkTypes[linkType]
+			if arcrole == "" && linkType == "footnote" {
+				arcrole = attr.FactFootnoteArcrole
+			}
+			if arcrole != attr.FactFootnoteArcrole {
+				continue
+			}
+			for _, targets := range groups {
+				for _, target := range targets {
+					arcs.WriteString(`<` + link + `footnoteArc ` + xlink + `type="arc" ` + xlink + `arcrole="` + attr.FactFootnoteArcrole +
+						`" ` + xlink + `from="` + escapeText(id) + `" ` + xlink + `to="` + escapeText(target) + `"/>`)
+				}
+			}
+		}
+	}
+	var buf strings.Builder
+	buf.WriteString(`<?xml version="1.0" encoding="utf-8"?>`)
+	buf.WriteString(`<` + xbrli + `xbrl`)
+	declared := make([]string, 0, len(namespaces))
+	for prefix := range namespaces {
+		declared = append(declared, prefix)
+	}
+	sort.Strings(declared)
+	for _, prefix := range declared {
+		buf.WriteString(` xmlns:` + prefix + `="` + escapeText(namespaces[prefix]) + `"`)
+	}
+	buf.WriteString(`>`)
+	for _, taxonomy := range file.DocumentInfo.Taxonomy {
+		buf.WriteString(`<` + link + `schemaRef ` + xlink + `type="simple" ` + xlink + `href="` + escapeText(taxonomy) + `"/>`)
+	}
+	buf.WriteString(contexts.String())
+	buf.WriteString(units.String())
+	buf.WriteString(facts.String())
+	if footnotes.Len() > 0 {
+		buf.WriteString(`<` + link + `footnoteLink ` + xlink + `type="extended" ` + xlink + `role="` + attr.ROLELINK + `">`)
+		for _, id := range ids {
+			fact := file.Facts[id]
+			if len(fact.Links) <= 0 || isOIM(fact.Dimensions["concept"], "note") {
+				continue
+			}
+			buf.WriteString(`<` + link + `loc ` + xlink + `type="locator" ` + xlink + `href="#` + escapeText(id) +
+				`" ` + xlink + `label="` + escapeText(id) + `"/>`)
+		}
+		buf.WriteString(footnotes.String())
+		buf.WriteString(arcs.String())
+		buf.WriteString(`</` + link + `footnoteLink>`)
+	}
+	buf.WriteString(`</` + xbrli + `xbrl>`)
+	return []byte(buf.String()), nil
+}
+
+// xmlDate maps an OIM date time to an xBRL-XML date, an end or instant at
+// midnight being the end of the day before
+func xmlDate(dateTime string, isEnd bool) string {
+	date := strings.TrimSuffix(dateTime, "T00:00:00")
+	if date == dateTime {
+		return dateTime
+	}
+	if !isEnd {
+		return date
+	}
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return dateTime
+	}
+	return t.AddDate(0, 0, -1).Format("2006-01-02")
+}
+
+func unitXML(id string, unit string, xbrli string) string {
+	measures := func(measures string) string {
+		ret := ""
+		for _, measure := range strings.Split(strings.Trim(measures, "()"), "*") {
+			ret += `<` + xbrli + `measure>` + escapeText(strings.TrimSpace(measure)) + `</` + xbrli + `measure>`
+		}
+		return ret
+	}
+	i := strings.IndexRune(unit, '/')
+	if i < 0 {
+		return `<` + xbrli + `unit id="` + id + `">` + measures(unit) + `</` + xbrli + `unit>`
+	}
+	return `<` + xbrli + `unit id="` + id + `"><` + xbrli + `divide><` + xbrli + `unitNumerator>` +
+		measures(unit[:i]) + `</` + xbrli + `unitNumerator><` + xbrli + `unitDenominator>` +
+		measures(unit[i+1:]) + `</` + xbrli + `unitDenominator></` + xbrli + `divide></` + xbrli + `unit>`
+}
+
+func isXMLFragment(text string) bool {
+	decoder := xml.NewDecoder(strings.NewReader("<fragment>" + text + "</fragment>"))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+	}
+}
+
+func escapeText(text string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
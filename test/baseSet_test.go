@@ -0,0 +1,151 @@
+package telefacts_test
+
+import (
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+const prohibitingPresentation = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink">
+	<link:roleRef roleURI="http://abc.example.com/role/Revenue" xlink:type="simple" xlink:href="abc.xsd#Revenue"/>
+	<link:presentationLink xlink:type="extended" xlink:role="http://abc.example.com/role/Revenue">
+		<link:loc xlink:type="locator" xlink:label="loc_abstract" xlink:href="abc.xsd#abc_RevenueAbstract"/>
+		<link:loc xlink:type="locator" xlink:label="loc_revenue" xlink:href="abc.xsd#abc_Revenue"/>
+		<link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="loc_abstract" xlink:to="loc_revenue" order="1" use="prohibited" priority="1"/>
+	</link:presentationLink>
+</link:linkbase>`
+
+func TestHydrate_BaseSets(t *testing.T) {
+	h := hydrateLocales(t)
+	arcs := 0
+	for _, link := range h.BaseSets.Presentation.PresentationLinks {
+		arcs += len(link.PresentationArcs)
+	}
+	if arcs != 1 {
+		t.Fatalf("expected 1 effective arc; outcome %d;\n", arcs)
+	}
+	folder := localesFolder(t)
+	prohibiting, err := serializables.DecodePresentationLinkbaseFile([]byte(prohibitingPresentation))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	folder.PresentationLinkbases["abc_ext_pre.xml"] = *prohibiting
+	h, err = hydratables.Hydrate(folder)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(h.PresentationLinkbases) != 2 {
+		t.Fatalf("expected 2 presentation linkbases; outcome %d;\n", len(h.PresentationLinkbases))
+	}
+	for _, link := range h.BaseSets.Presentation.PresentationLinks {
+		for _, arc := range link.PresentationArcs {
+			t.Fatalf("expected the prohibited arc to be removed; outcome %v;\n", arc)
+		}
+	}
+	r := renderLocales(t, h)
+	for _, indentedLabel := range r.PGrid.IndentedLabels {
+		if indentedLabel.Href == "abc.xsd#abc_Revenue" {
+			t.Fatalf("expected abc_Revenue to be prohibited")
+		}
+	}
+}
+
+const globalPresentation = "http://xbrl.fasb.org/us-gaap/2019/dis/us-gaap-dis-aro-pre-2019-01-31.xml"
+
+const prohibitingGlobalPresentation = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink">
+	<link:presentationLink xlink:type="extended" xlink:role="http://fasb.org/us-gaap/role/disclosure/AssetRetirementObligations">
+		<link:loc xlink:type="locator" xlink:label="loc_abstract" xlink:href="http://xbrl.fasb.org/us-gaap/2019/elts/us-gaap-2019-01-31.xsd#us-gaap_AssetRetirementObligationDisclosureAbstract"/>
+		<link:loc xlink:type="locator" xlink:label="loc_textBlock" xlink:href="http://xbrl.fasb.org/us-gaap/2019/elts/us-gaap-2019-01-31.xsd#us-gaap_AssetRetirementObligationDisclosureTextBlock"/>
+		<link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="loc_abstract" xlink:to="loc_textBlock" order="10" use="prohibited" priority="1"/>
+	</link:presentationLink>
+</link:linkbase>`
+
+func TestHydrate_GlobalBaseSets(t *testing.T) {
+	textBlock := "http://xbrl.fasb.org/us-gaap/2019/elts/us-gaap-2019-01-31.xsd#us-gaap_AssetRetirementObligationDisclosureTextBlock"
+	globalArcs := func(h *hydratables.Hydratable) (int, bool) {
+		arcs, found := 0, false
+		for _, link := range h.BaseSets.Presentation.PresentationLinks {
+			if link.Role != "http://fasb.org/us-gaap/role/disclosure/AssetRetirementObligations" {
+				continue
+			}
+			for _, arc := range link.PresentationArcs {
+				arcs++
+				if arc.To == textBlock {
+					found = true
+				}
+			}
+		}
+		return arcs, found
+	}
+	folder := localesFolder(t)
+	folder.GlobalLinkbaseRefs = map[string]string{
+		globalPresentation: attr.PresentationLinkbaseRef,
+	}
+	h, err := hydratables.Hydrate(folder)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	arcs, found := globalArcs(h)
+	if arcs != 28 || !found {
+		t.Fatalf("expected 28 global arcs to %s; outcome %d %v;\n", textBlock, arcs, found)
+	}
+	prohibiting, err := serializables.DecodePresentationLinkbaseFile([]byte(prohibitingGlobalPresentation))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	folder.PresentationLinkbases["abc_ext_pre.xml"] = *prohibiting
+	h, err = hydratables.Hydrate(folder)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	arcs, found = globalArcs(h)
+	if arcs != 27 || found {
+		t.Fatalf("expected the global arc to %s to be prohibited; outcome %d %v;\n", textBlock, arcs, found)
+	}
+}
+
+func TestHydrate_GlobalSchemaBaseSets(t *testing.T) {
+	textBlock := "http://xbrl.fasb.org/us-gaap/2019/elts/us-gaap-2019-01-31.xsd#us-gaap_AssetRetirementObligationDisclosureTextBlock"
+	globalArcs := func(h *hydratables.Hydratable) (int, bool) {
+		arcs, found := 0, false
+		for _, link := range h.BaseSets.Presentation.PresentationLinks {
+			if link.Role != "http://fasb.org/us-gaap/role/disclosure/AssetRetirementObligations" {
+				continue
+			}
+			for _, arc := range link.PresentationArcs {
+				arcs++
+				if arc.To == textBlock {
+					found = true
+				}
+			}
+		}
+		return arcs, found
+	}
+	folder := localesFolder(t)
+	folder.Namespaces["http://fasb.org/dis/aro/2019-01-31"] = "http://xbrl.fasb.org/us-gaap/2019/dis/us-gaap-dis-aro-2019-01-31.xsd"
+	h, err := hydratables.Hydrate(folder)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	arcs, found := globalArcs(h)
+	if arcs != 28 || !found {
+		t.Fatalf("expected 28 arcs of the global schema's linkbase to %s; outcome %d %v;\n", textBlock, arcs, found)
+	}
+	prohibiting, err := serializables.DecodePresentationLinkbaseFile([]byte(prohibitingGlobalPresentation))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	folder.PresentationLinkbases["abc_ext_pre.xml"] = *prohibiting
+	h, err = hydratables.Hydrate(folder)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	arcs, found = globalArcs(h)
+	if arcs != 27 || found {
+		t.Fatalf("expected the global arc to %s to be prohibited; outcome %d %v;\n", textBlock, arcs, found)
+	}
+}
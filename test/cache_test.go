@@ -0,0 +1,86 @@
+package telefacts_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ecksbee.com/telefacts/pkg/cache"
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+	gocache "github.com/patrickmn/go-cache"
+)
+
+// writeLocalesFolder writes the locales to a working directory, with the
+// references for their discovery
+func writeLocalesFolder(t *testing.T) string {
+	wd := t.TempDir()
+	dir := filepath.Join(wd, "folders", "locales")
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	files := map[string]string{
+		"_": `{"Entry":"abc.xml"}`,
+		"abc.xsd": strings.Replace(localeSchema, `<xs:appinfo>`, `<xs:appinfo>
+			<link:linkbaseRef xmlns:xlink="http://www.w3.org/1999/xlink" xlink:type="simple" xlink:arcrole="http://www.w3.org/1999/xlink/properties/linkbase" xlink:role="http://www.xbrl.org/2003/role/presentationLinkbaseRef" xlink:href="abc_pre.xml"/>
+			<link:linkbaseRef xmlns:xlink="http://www.w3.org/1999/xlink" xlink:type="simple" xlink:arcrole="http://www.w3.org/1999/xlink/properties/linkbase" xlink:role="http://www.xbrl.org/2003/role/labelLinkbaseRef" xlink:href="abc_lab.xml"/>`, 1),
+		"abc_pre.xml": localePresentation,
+		"abc_lab.xml": localeLabel,
+		"abc.xml": strings.Replace(localeInstance, `<xbrli:context id="c1">`, `<link:schemaRef xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink" xlink:type="simple" xlink:href="abc.xsd"/>
+	<xbrli:context id="c1">`, 1),
+	}
+	for fileName, data := range files {
+		err = os.WriteFile(filepath.Join(dir, fileName), []byte(data), 0644)
+		if err != nil {
+			t.Fatalf("Error: " + err.Error())
+		}
+	}
+	return wd
+}
+
+func TestCache_RenderOptions(t *testing.T) {
+	serializables.WorkingDirectoryPath = writeLocalesFolder(t)
+	serializables.GlobalTaxonomySetPath = filepath.Join(".", "gts")
+	hydratables.InjectCache(gocache.New(gocache.NoExpiration, gocache.NoExpiration))
+	defer cache.NewCache(true)
+	appCache := cache.NewCache(false)
+	data, err := cache.MarshalCatalog("locales")
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	c := renderables.Catalog{}
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	slug := ""
+	for _, network := range c.Networks {
+		slug = network["http://abc.example.com/role/Revenue"]
+	}
+	data, err = cache.MarshalRenderable("locales", slug)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if !strings.Contains(string(data), "12.345.678,50") {
+		t.Fatalf("expected 12.345.678,50; outcome %s;\n", string(data))
+	}
+	for i := 0; i < 100; i++ {
+		if _, found := appCache.Get("locales/" + slug); found {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cache.NewCache(false, renderables.WithFormatter(renderables.Deutsch, swissFormatter))
+	data, err = cache.MarshalRenderable("locales", slug)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if !strings.Contains(string(data), "12'345'678.50") || !strings.Contains(string(data), "12 Monate bis 31.12.2023") {
+		t.Fatalf("expected the options of the cache; outcome %s;\n", string(data))
+	}
+}
@@ -0,0 +1,147 @@
+package telefacts_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ecksbee.com/telefacts/internal/web"
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/cache"
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+	gocache "github.com/patrickmn/go-cache"
+)
+
+const detailReference = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:ref="http://www.xbrl.org/2006/ref">
+	<link:referenceLink xlink:type="extended" xlink:role="http://www.xbrl.org/2003/role/link">
+		<link:loc xlink:type="locator" xlink:label="revenue" xlink:href="abc.xsd#abc_Revenue"/>
+		<link:reference xlink:type="resource" xlink:label="revenue_ref" xlink:role="http://www.xbrl.org/2003/role/disclosureRef">
+			<ref:Publisher>FASB</ref:Publisher>
+			<ref:Name>Accounting Standards Codification</ref:Name>
+			<ref:Topic>606</ref:Topic>
+		</link:reference>
+		<link:referenceArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/concept-reference" xlink:from="revenue" xlink:to="revenue_ref"/>
+	</link:referenceLink>
+</link:linkbase>`
+
+const detailDefinition = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:xbrldt="http://xbrl.org/2005/xbrldt">
+	<link:roleRef roleURI="http://abc.example.com/role/Revenue" xlink:type="simple" xlink:href="abc.xsd#Revenue"/>
+	<link:definitionLink xlink:type="extended" xlink:role="http://abc.example.com/role/Revenue">
+		<link:loc xlink:type="locator" xlink:label="abstract" xlink:href="abc.xsd#abc_RevenueAbstract"/>
+		<link:loc xlink:type="locator" xlink:label="revenue" xlink:href="abc.xsd#abc_Revenue"/>
+		<link:loc xlink:type="locator" xlink:label="table" xlink:href="abc.xsd#abc_RevenueTable"/>
+		<link:loc xlink:type="locator" xlink:label="axis" xlink:href="abc.xsd#abc_SegmentAxis"/>
+		<link:loc xlink:type="locator" xlink:label="domain" xlink:href="abc.xsd#abc_SegmentDomain"/>
+		<link:definitionArc xlink:type="arc" xlink:arcrole="http://xbrl.org/int/dim/arcrole/all" xlink:from="abstract" xlink:to="table" xbrldt:closed="true" xbrldt:contextElement="segment" order="1"/>
+		<link:definitionArc xlink:type="arc" xlink:arcrole="http://xbrl.org/int/dim/arcrole/domain-member" xlink:from="abstract" xlink:to="revenue" order="1"/>
+		<link:definitionArc xlink:type="arc" xlink:arcrole="http://xbrl.org/int/dim/arcrole/hypercube-dimension" xlink:from="table" xlink:to="axis" order="1"/>
+		<link:definitionArc xlink:type="arc" xlink:arcrole="http://xbrl.org/int/dim/arcrole/dimension-domain" xlink:from="axis" xlink:to="domain" order="1"/>
+	</link:definitionLink>
+</link:linkbase>`
+
+func TestMarshalConceptDetail(t *testing.T) {
+	folder := localesFolder(t)
+	reference, err := serializables.DecodeReferenceLinkbaseFile([]byte(detailReference))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	folder.ReferenceLinkbases = map[string]serializables.ReferenceLinkbaseFile{
+		"abc_ref.xml": *reference,
+	}
+	definition, err := serializables.DecodeDefinitionLinkbaseFile([]byte(detailDefinition))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	folder.DefinitionLinkbases = map[string]serializables.DefinitionLinkbaseFile{
+		"abc_def.xml": *definition,
+	}
+	h, err := hydratables.Hydrate(folder)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	data, err := renderables.MarshalConceptDetail("abc.xsd#abc_Revenue", h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	detail := renderables.ConceptDetail{}
+	err = json.Unmarshal(data, &detail)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if detail.Name != "Revenue" || detail.Type.Local != "monetaryItemType" || detail.Type.Space != attr.XBRLI ||
+		detail.Balance != "credit" || detail.PeriodType != "duration" || detail.Abstract {
+		t.Fatalf("expected the attributes of abc_Revenue; outcome %v;\n", detail)
+	}
+	if detail.Labels[renderables.Default][renderables.Deutsch] != "Umsatzerlöse" {
+		t.Fatalf("expected Umsatzerlöse; outcome %v;\n", detail.Labels)
+	}
+	if len(detail.References) != 1 || len(detail.References[0].Parts) != 3 ||
+		detail.References[0].Parts[0].Name != "Publisher" || detail.References[0].Parts[2].Value != "606" {
+		t.Fatalf("expected the ASC 606 reference; outcome %v;\n", detail.References)
+	}
+	if len(detail.Networks) != 2 {
+		t.Fatalf("expected presentation and definition networks; outcome %v;\n", detail.Networks)
+	}
+	for _, network := range detail.Networks {
+		if len(network.Parents) != 1 || network.Parents[0].Href != "abc.xsd#abc_RevenueAbstract" || len(network.Children) != 0 {
+			t.Fatalf("expected abc_RevenueAbstract as the only parent; outcome %v;\n", network)
+		}
+		if network.RelationshipSet.Title != "0001 - Statement - Revenue" {
+			t.Fatalf("expected the Revenue statement; outcome %v;\n", network.RelationshipSet)
+		}
+	}
+	if len(detail.Dimensions) != 1 || detail.Dimensions[0].Dimension.Href != "abc.xsd#abc_SegmentAxis" ||
+		detail.Dimensions[0].Participation != renderables.PrimaryItemParticipation {
+		t.Fatalf("expected abc_SegmentAxis; outcome %v;\n", detail.Dimensions)
+	}
+	if len(detail.Facts) != 1 || detail.Facts[0].ID != "f1" || detail.Facts[0].UnitRef != "INR" {
+		t.Fatalf("expected fact f1; outcome %v;\n", detail.Facts)
+	}
+	expression := (*detail.Facts[0].Expression)[renderables.Deutsch]
+	if expression.Core+expression.Tail != "12.345.678,50" {
+		t.Fatalf("expected 12.345.678,50; outcome %s%s;\n", expression.Core, expression.Tail)
+	}
+	data, err = renderables.MarshalConceptDetail("abc.xsd#abc_SegmentAxis", h)
+	if err == nil {
+		t.Fatalf("expected an error for a concept that is not declared; outcome %s;\n", data)
+	}
+}
+
+func TestConceptDetail_Route(t *testing.T) {
+	serializables.WorkingDirectoryPath = filepath.Join(".", "wd")
+	serializables.GlobalTaxonomySetPath = filepath.Join(".", "gts")
+	hydratables.InjectCache(gocache.New(gocache.NoExpiration, gocache.NoExpiration))
+	cache.NewCache(true)
+	href := "http://xbrl.fasb.org/srt/2019/elts/srt-2019-01-31.xsd#srt_StatementGeographicalAxis"
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/folders/test_small/concepts/"+neturl.PathEscape(href), nil)
+	web.NewRouter().ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200; outcome %d %s;\n", recorder.Code, recorder.Body.String())
+	}
+	if recorder.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("expected application/json; outcome %s;\n", recorder.Header().Get("Content-Type"))
+	}
+	detail := renderables.ConceptDetail{}
+	err := json.Unmarshal(recorder.Body.Bytes(), &detail)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if detail.Href != href || detail.Name != "StatementGeographicalAxis" || len(detail.Networks) <= 0 {
+		t.Fatalf("expected the networks of %s; outcome %s %s %d;\n", href, detail.Href, detail.Name, len(detail.Networks))
+	}
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodGet, "/folders/test_small/concepts?href="+neturl.QueryEscape(href), nil)
+	web.NewRouter().ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), `"StatementGeographicalAxis"`) {
+		t.Fatalf("expected the concept from the href query; outcome %d %s;\n", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,141 @@
+package telefacts_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/renderables"
+)
+
+func TestWritePGrid(t *testing.T) {
+	h := hydrateLocales(t)
+	r := renderLocales(t, h)
+	var buf bytes.Buffer
+	err := renderables.WritePGrid(&buf, r.PGrid, renderables.English, renderables.CSV)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(records) != len(r.PGrid.IndentedLabels)+1 {
+		t.Fatalf("expected %d rows; outcome %d", len(r.PGrid.IndentedLabels)+1, len(records))
+	}
+	if len(records[0]) != len(r.PGrid.PeriodHeaders)+1 || records[0][0] != "Concept" {
+		t.Fatalf("unexpected header %v", records[0])
+	}
+	found := false
+	for _, record := range records[1:] {
+		for _, cell := range record[1:] {
+			if cell == "12345678.5" {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("expected a plain numeric revenue cell; outcome %v", records)
+	}
+}
+
+func TestWriteFacts(t *testing.T) {
+	h := hydrateLocales(t)
+	var buf bytes.Buffer
+	err := renderables.WriteFacts(&buf, h, renderables.English, renderables.TSV)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	reader := csv.NewReader(&buf)
+	reader.Comma = renderables.TSV
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 rows; outcome %d", len(records))
+	}
+	header := []string{"Concept", "Label", "Entity Scheme", "Entity", "Period Start", "Period End", "Period Instant", "Unit", "Decimals", "Value"}
+	if len(records[0]) != len(header) {
+		t.Fatalf("expected header %v; outcome %v", header, records[0])
+	}
+	expected := map[string]string{
+		"Concept":        "abc:Revenue",
+		"Entity Scheme":  "http://www.sec.gov/CIK",
+		"Entity":         "0000000001",
+		"Period Start":   "2023-01-01",
+		"Period End":     "2023-12-31",
+		"Period Instant": "",
+		"Unit":           "iso4217:INR",
+		"Decimals":       "2",
+		"Value":          "12345678.50",
+	}
+	for i, column := range header {
+		if records[0][i] != column {
+			t.Fatalf("expected header %v; outcome %v", header, records[0])
+		}
+		if value, found := expected[column]; found && records[1][i] != value {
+			t.Fatalf("expected %s %s; outcome %s", column, value, records[1][i])
+		}
+	}
+	if records[1][1] == "" {
+		t.Fatalf("expected a label")
+	}
+}
+
+func TestWritePGrid_StringFacts(t *testing.T) {
+	h := hydrateStringFacts(t)
+	r := renderLocales(t, h)
+	var buf bytes.Buffer
+	err := renderables.WritePGrid(&buf, r.PGrid, renderables.English, renderables.CSV)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	cells := map[string]bool{}
+	for _, record := range records[1:] {
+		for _, cell := range record[1:] {
+			cells[cell] = true
+		}
+	}
+	for _, text := range []string{"0000320193", "10-K", "Q4", "12345678.5"} {
+		if !cells[text] {
+			t.Fatalf("expected cell %s; outcome %v", text, records)
+		}
+	}
+	for _, number := range []string{"320193", "10", "4"} {
+		if cells[number] {
+			t.Fatalf("unexpected numeric cell %s; outcome %v", number, records)
+		}
+	}
+}
+
+func TestWriteFacts_StringFacts(t *testing.T) {
+	h := hydrateStringFacts(t)
+	var buf bytes.Buffer
+	err := renderables.WriteFacts(&buf, h, renderables.English, renderables.CSV)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	values := map[string]string{}
+	for _, record := range records[1:] {
+		values[record[0]] = record[len(record)-1]
+	}
+	expected := map[string]string{
+		"abc:EntityCentralIndexKey": "0000320193",
+		"abc:EntityRegistrantName":  "AT&T Inc.",
+		"abc:SegmentsTextBlock":     "<table><tr><td>North</td><td>10</td></tr><tr><td>South</td><td>20</td></tr></table>",
+	}
+	for concept, value := range expected {
+		if values[concept] != value {
+			t.Fatalf("expected %s %s; outcome %s", concept, value, values[concept])
+		}
+	}
+}
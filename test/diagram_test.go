@@ -0,0 +1,133 @@
+package telefacts_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+func TestMarshalDiagram(t *testing.T) {
+	folder := localesFolder(t)
+	definition, err := serializables.DecodeDefinitionLinkbaseFile([]byte(detailDefinition))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	folder.DefinitionLinkbases = map[string]serializables.DefinitionLinkbaseFile{
+		"abc_def.xml": *definition,
+	}
+	h, err := hydratables.Hydrate(folder)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	data, err := renderables.MarshalCatalog(h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	c := renderables.Catalog{}
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	slug := ""
+	for _, network := range c.Networks {
+		slug = network["http://abc.example.com/role/Revenue"]
+	}
+	expectLines := func(data []byte, lines ...string) {
+		t.Helper()
+		for _, line := range lines {
+			if !strings.Contains(string(data), line+"\n") {
+				t.Fatalf("expected %s; outcome\n%s", line, data)
+			}
+		}
+	}
+	data, err = renderables.MarshalDiagram(slug, h, "presentation", renderables.DOT, renderables.Deutsch)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	expectLines(data,
+		`digraph "0001 - Statement - Revenue" {`,
+		`	n0 [label="abc_RevenueAbstract", shape=box, style=rounded];`,
+		`	n1 [label="Umsatzerlöse", shape=box];`,
+		`	n0 -> n1;`,
+	)
+	data, err = renderables.MarshalDiagram(slug, h, "definition", renderables.Mermaid, renderables.English)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	expectLines(data,
+		`flowchart LR`,
+		`	n2{{"abc_RevenueTable"}}`,
+		`	n3{"abc_SegmentAxis"}`,
+		`	n4(["abc_SegmentDomain"])`,
+		`	n1 -->|"all, closed, segment"| n2`,
+		`	n3 --> n4`,
+	)
+	data, err = renderables.MarshalDTSDiagram(h, renderables.DOT)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	expectLines(data,
+		`	n0 [label="abc.xml", shape=folder];`,
+		`	n1 [label="abc.xsd", shape=component];`,
+	)
+	_, err = renderables.MarshalDiagram(slug, h, "presentation", renderables.DiagramFormat("svg"), renderables.English)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestGetRenderable_DimensionDomainDefault(t *testing.T) {
+	folder := localesFolder(t)
+	withDefault := strings.Replace(detailDefinition, `</link:definitionLink>`,
+		`	<link:definitionArc xlink:type="arc" xlink:arcrole="http://xbrl.org/int/dim/arcrole/dimension-default" xlink:from="axis" xlink:to="domain" order="1"/>
+	</link:definitionLink>`, 1)
+	definition, err := serializables.DecodeDefinitionLinkbaseFile([]byte(withDefault))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	folder.DefinitionLinkbases = map[string]serializables.DefinitionLinkbaseFile{
+		"abc_def.xml": *definition,
+	}
+	h, err := hydratables.Hydrate(folder)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	data, err := renderables.MarshalCatalog(h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	c := renderables.Catalog{}
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	slug := ""
+	for _, network := range c.Networks {
+		slug = network["http://abc.example.com/role/Revenue"]
+	}
+	r, err := renderables.GetRenderable(slug, h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	domains, defaults := 0, 0
+	for _, link := range r.DGrid.DRS.Links {
+		if link.DimensionDomainConnection == nil {
+			continue
+		}
+		if link.SourceHref != "abc.xsd#abc_SegmentAxis" || link.TargetHref != "abc.xsd#abc_SegmentDomain" {
+			t.Fatalf("unexpected dimension link %v", link)
+		}
+		if link.DimensionDomainConnection.Default {
+			defaults++
+		} else {
+			domains++
+		}
+	}
+	if domains != 1 || defaults != 1 {
+		t.Fatalf("expected a dimension-domain and a dimension-default connection; outcome %d and %d", domains, defaults)
+	}
+}
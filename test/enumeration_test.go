@@ -0,0 +1,128 @@
+package telefacts_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/serializables"
+	gocache "github.com/patrickmn/go-cache"
+)
+
+const enumSchema = `<?xml version="1.0" encoding="utf-8"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:xbrli="http://www.xbrl.org/2003/instance"
+	xmlns:enum2="http://xbrl.org/2020/extensible-enumerations-2.0" xmlns:abc="http://abc.example.com/2023"
+	targetNamespace="http://abc.example.com/2023" elementFormDefault="qualified">
+	<xs:element id="abc_LocationDomain" name="LocationDomain" type="xbrli:stringItemType" substitutionGroup="xbrli:item" abstract="true" xbrli:periodType="duration"/>
+	<xs:element id="abc_NorthMember" name="NorthMember" type="xbrli:stringItemType" substitutionGroup="xbrli:item" abstract="true" xbrli:periodType="duration"/>
+	<xs:element id="abc_SouthMember" name="SouthMember" type="xbrli:stringItemType" substitutionGroup="xbrli:item" abstract="true" xbrli:periodType="duration"/>
+	<xs:element id="abc_RetiredMember" name="RetiredMember" type="xbrli:stringItemType" substitutionGroup="xbrli:item" abstract="true" xbrli:periodType="duration"/>
+	<xs:element id="abc_Location" name="Location" type="enum2:enumerationSetItemType" substitutionGroup="xbrli:item" xbrli:periodType="duration"
+		enum2:domain="abc:LocationDomain" enum2:linkrole="http://abc.example.com/role/Locations" enum2:headUsable="false"/>
+</xs:schema>`
+
+const enumDefinition = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink">
+	<link:roleRef roleURI="http://abc.example.com/role/Locations" xlink:type="simple" xlink:href="abc.xsd#Locations"/>
+	<link:definitionLink xlink:type="extended" xlink:role="http://abc.example.com/role/Locations">
+		<link:loc xlink:type="locator" xlink:label="domain" xlink:href="abc.xsd#abc_LocationDomain"/>
+		<link:loc xlink:type="locator" xlink:label="north" xlink:href="abc.xsd#abc_NorthMember"/>
+		<link:loc xlink:type="locator" xlink:label="south" xlink:href="abc.xsd#abc_SouthMember"/>
+		<link:loc xlink:type="locator" xlink:label="retired" xlink:href="abc.xsd#abc_RetiredMember"/>
+		<link:definitionArc xlink:type="arc" xlink:arcrole="http://xbrl.org/int/dim/arcrole/domain-member" xlink:from="domain" xlink:to="north" order="1"/>
+		<link:definitionArc xlink:type="arc" xlink:arcrole="http://xbrl.org/int/dim/arcrole/domain-member" xlink:from="north" xlink:to="south" order="2"/>
+		<link:definitionArc xlink:type="arc" xlink:arcrole="http://xbrl.org/int/dim/arcrole/domain-member" xlink:from="domain" xlink:to="retired" order="3" xbrldt:usable="false" xmlns:xbrldt="http://xbrl.org/2005/xbrldt"/>
+	</link:definitionLink>
+</link:linkbase>`
+
+const enumInstance = `<?xml version="1.0" encoding="utf-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:abc="http://abc.example.com/2023">
+	<xbrli:context id="c1">
+		<xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:startDate>2023-01-01</xbrli:startDate><xbrli:endDate>2023-12-31</xbrli:endDate></xbrli:period>
+	</xbrli:context>
+	<xbrli:context id="c2">
+		<xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:startDate>2022-01-01</xbrli:startDate><xbrli:endDate>2022-12-31</xbrli:endDate></xbrli:period>
+	</xbrli:context>
+	<abc:Location id="f1" contextRef="c1">http://abc.example.com/2023#NorthMember http://abc.example.com/2023#SouthMember</abc:Location>
+	<abc:Location id="f2" contextRef="c2">http://abc.example.com/2023#RetiredMember</abc:Location>
+</xbrli:xbrl>`
+
+func enumFolder(t *testing.T) *serializables.Folder {
+	hydratables.InjectCache(gocache.New(gocache.NoExpiration, gocache.NoExpiration))
+	serializables.WorkingDirectoryPath = filepath.Join(".", "wd")
+	serializables.GlobalTaxonomySetPath = filepath.Join(".", "gts")
+	schema, err := serializables.DecodeSchemaFile([]byte(enumSchema))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	definition, err := serializables.DecodeDefinitionLinkbaseFile([]byte(enumDefinition))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	instance, err := serializables.DecodeInstanceFile([]byte(enumInstance))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	return &serializables.Folder{
+		EntryFileName: "abc.xml",
+		Namespaces: map[string]string{
+			"http://abc.example.com/2023": "abc.xsd",
+		},
+		Instances: map[string]serializables.InstanceFile{
+			"abc.xml": *instance,
+		},
+		Schemas: map[string]serializables.SchemaFile{
+			"abc.xsd": *schema,
+		},
+		DefinitionLinkbases: map[string]serializables.DefinitionLinkbaseFile{
+			"abc_def.xml": *definition,
+		},
+	}
+}
+
+func TestHydrate_Enumerations(t *testing.T) {
+	h, err := hydratables.Hydrate(enumFolder(t))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	_, concept, err := h.HashQuery("abc.xsd#abc_Location")
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if !concept.IsEnumeration() || concept.EnumDomain.Local != "LocationDomain" || concept.EnumHeadUsable {
+		t.Fatalf("expected an enumeration concept; outcome %v;\n", concept)
+	}
+	members, found := h.EnumerationMembers(concept)
+	if !found || len(members) != 2 {
+		t.Fatalf("expected 2 allowed members; outcome %v;\n", members)
+	}
+	fact := h.FindFact("abc.xsd#abc_Location", "c1")
+	if fact == nil || fact.ValueError != nil || len(fact.Enumerations) != 2 {
+		t.Fatalf("expected a valid enumeration fact; outcome %v;\n", fact)
+	}
+	if fact.Enumerations[0] != "abc.xsd#abc_NorthMember" {
+		t.Fatalf("expected abc.xsd#abc_NorthMember; outcome %s;\n", fact.Enumerations[0])
+	}
+	fact = h.FindFact("abc.xsd#abc_Location", "c2")
+	if fact == nil || fact.ValueError == nil {
+		t.Fatalf("expected an unusable enumeration member to be reported")
+	}
+}
+
+func TestHydrate_Enumerations_MissingNetwork(t *testing.T) {
+	f := enumFolder(t)
+	f.DefinitionLinkbases = map[string]serializables.DefinitionLinkbaseFile{}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	fact := h.FindFact("abc.xsd#abc_Location", "c1")
+	if fact == nil || fact.ValueError == nil {
+		t.Fatalf("expected an enumeration fact without its network to be reported; outcome %v;\n", fact)
+	}
+	if len(fact.Enumerations) != 2 {
+		t.Fatalf("expected the members of the fact; outcome %v;\n", fact.Enumerations)
+	}
+}
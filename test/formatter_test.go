@@ -0,0 +1,242 @@
+package telefacts_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+	gocache "github.com/patrickmn/go-cache"
+)
+
+const localeSchema = `<?xml version="1.0" encoding="utf-8"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:xbrli="http://www.xbrl.org/2003/instance"
+	xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:abc="http://abc.example.com/2023"
+	targetNamespace="http://abc.example.com/2023" elementFormDefault="qualified">
+	<xs:annotation>
+		<xs:appinfo>
+			<link:roleType roleURI="http://abc.example.com/role/Revenue" id="Revenue">
+				<link:definition>0001 - Statement - Revenue</link:definition>
+				<link:usedOn>link:presentationLink</link:usedOn>
+			</link:roleType>
+		</xs:appinfo>
+	</xs:annotation>
+	<xs:element id="abc_Revenue" name="Revenue" type="xbrli:monetaryItemType" substitutionGroup="xbrli:item" xbrli:periodType="duration" xbrli:balance="credit"/>
+	<xs:element id="abc_RevenueAbstract" name="RevenueAbstract" type="xbrli:stringItemType" substitutionGroup="xbrli:item" abstract="true" xbrli:periodType="duration"/>
+</xs:schema>`
+
+const localePresentation = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink">
+	<link:roleRef roleURI="http://abc.example.com/role/Revenue" xlink:type="simple" xlink:href="abc.xsd#Revenue"/>
+	<link:presentationLink xlink:type="extended" xlink:role="http://abc.example.com/role/Revenue">
+		<link:loc xlink:type="locator" xlink:label="abstract" xlink:href="abc.xsd#abc_RevenueAbstract"/>
+		<link:loc xlink:type="locator" xlink:label="revenue" xlink:href="abc.xsd#abc_Revenue"/>
+		<link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="abstract" xlink:to="revenue" order="1"/>
+	</link:presentationLink>
+</link:linkbase>`
+
+const localeLabel = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink">
+	<link:labelLink xlink:type="extended" xlink:role="http://www.xbrl.org/2003/role/link">
+		<link:loc xlink:type="locator" xlink:label="revenue" xlink:href="abc.xsd#abc_Revenue"/>
+		<link:label xlink:type="resource" xlink:label="revenue_lbl" xlink:role="http://www.xbrl.org/2003/role/label" xml:lang="en-US">Revenue</link:label>
+		<link:label xlink:type="resource" xlink:label="revenue_lbl" xlink:role="http://www.xbrl.org/2003/role/label" xml:lang="de">Umsatzerlöse</link:label>
+		<link:label xlink:type="resource" xlink:label="revenue_lbl" xlink:role="http://www.xbrl.org/2003/role/label" xml:lang="fr">Chiffre d'affaires</link:label>
+		<link:label xlink:type="resource" xlink:label="revenue_lbl" xlink:role="http://www.xbrl.org/2003/role/label" xml:lang="hi">राजस्व</link:label>
+		<link:labelArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/concept-label" xlink:from="revenue" xlink:to="revenue_lbl"/>
+	</link:labelLink>
+</link:linkbase>`
+
+const localeInstance = `<?xml version="1.0" encoding="utf-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:abc="http://abc.example.com/2023" xmlns:iso4217="http://www.xbrl.org/2003/iso4217">
+	<xbrli:context id="c1">
+		<xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:startDate>2023-01-01</xbrli:startDate><xbrli:endDate>2023-12-31</xbrli:endDate></xbrli:period>
+	</xbrli:context>
+	<xbrli:unit id="INR"><xbrli:measure>iso4217:INR</xbrli:measure></xbrli:unit>
+	<abc:Revenue id="f1" contextRef="c1" unitRef="INR" decimals="2">12345678.50</abc:Revenue>
+</xbrli:xbrl>`
+
+func localesFolder(t *testing.T) *serializables.Folder {
+	hydratables.InjectCache(gocache.New(gocache.NoExpiration, gocache.NoExpiration))
+	serializables.WorkingDirectoryPath = filepath.Join(".", "wd")
+	serializables.GlobalTaxonomySetPath = filepath.Join(".", "gts")
+	schema, err := serializables.DecodeSchemaFile([]byte(localeSchema))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	presentation, err := serializables.DecodePresentationLinkbaseFile([]byte(localePresentation))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	label, err := serializables.DecodeLabelLinkbaseFile([]byte(localeLabel))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	instance, err := serializables.DecodeInstanceFile([]byte(localeInstance))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	return &serializables.Folder{
+		EntryFileName: "abc.xml",
+		Namespaces: map[string]string{
+			"http://abc.example.com/2023": "abc.xsd",
+		},
+		Instances: map[string]serializables.InstanceFile{
+			"abc.xml": *instance,
+		},
+		Schemas: map[string]serializables.SchemaFile{
+			"abc.xsd": *schema,
+		},
+		PresentationLinkbases: map[string]serializables.PresentationLinkbaseFile{
+			"abc_pre.xml": *presentation,
+		},
+		LabelLinkbases: map[string]serializables.LabelLinkbaseFile{
+			"abc_lab.xml": *label,
+		},
+	}
+}
+
+func hydrateLocales(t *testing.T) *hydratables.Hydratable {
+	h, err := hydratables.Hydrate(localesFolder(t))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	return h
+}
+
+func renderLocales(t *testing.T, h *hydratables.Hydratable, options ...renderables.RenderOption) renderables.Renderable {
+	data, err := renderables.MarshalCatalog(h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	c := renderables.Catalog{}
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	slug := ""
+	for _, network := range c.Networks {
+		slug = network["http://abc.example.com/role/Revenue"]
+	}
+	data, err = renderables.MarshalRenderable(slug, h, options...)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	r := renderables.Renderable{}
+	err = json.Unmarshal(data, &r)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	return r
+}
+
+func TestMarshalRenderable_Locales(t *testing.T) {
+	r := renderLocales(t, hydrateLocales(t))
+	if len(r.PGrid.PeriodHeaders) != 1 || len(r.PGrid.FactualQuadrant) != 2 {
+		t.Fatalf("expected 1 period and 2 rows; outcome %v;\n", r.PGrid)
+	}
+	expectedPeriods := map[renderables.Lang]string{
+		renderables.English:  "12 months ended December 31, 2023",
+		renderables.Deutsch:  "12 Monate bis zum 31. Dezember 2023",
+		renderables.Français: "12 mois clos le 31 décembre 2023",
+	}
+	for lang, expected := range expectedPeriods {
+		if r.PGrid.PeriodHeaders[0][lang] != expected {
+			t.Fatalf("expected %s; outcome %s;\n", expected, r.PGrid.PeriodHeaders[0][lang])
+		}
+	}
+	fact := r.PGrid.FactualQuadrant[1][0]
+	if fact == nil {
+		t.Fatalf("expected a fact")
+	}
+	expectedValues := map[renderables.Lang]string{
+		renderables.English:  "12,345,678.50",
+		renderables.Deutsch:  "12.345.678,50",
+		renderables.Français: "12\u202f345\u202f678,50",
+		renderables.Hindi:    "1,23,45,678.50",
+	}
+	for lang, expected := range expectedValues {
+		expression := (*fact)[lang]
+		if expression.Core+expression.Tail != expected {
+			t.Fatalf("expected %s; outcome %s%s;\n", expected, expression.Core, expression.Tail)
+		}
+	}
+}
+
+func TestMarshalRenderable_FactRenderer(t *testing.T) {
+	monetary := xml.Name{
+		Space: attr.XBRLI,
+		Local: "monetaryItemType",
+	}
+	house := renderables.FactRendererFunc(func(fact *hydratables.Fact, cf renderables.ConceptFinder, mf renderables.MeasurementFinder) *renderables.FactExpression {
+		return &renderables.FactExpression{
+			Head: "INR ",
+			Core: fact.XMLInner,
+		}
+	})
+	r := renderLocales(t, hydrateLocales(t), renderables.WithFactRenderer(renderables.English, monetary, house))
+	fact := r.PGrid.FactualQuadrant[1][0]
+	if fact == nil {
+		t.Fatalf("expected a fact")
+	}
+	expression := (*fact)[renderables.English]
+	if expression.Head != "INR " || expression.Core != "12345678.50" {
+		t.Fatalf("expected INR 12345678.50; outcome %s%s;\n", expression.Head, expression.Core)
+	}
+	expression = (*fact)[renderables.Deutsch]
+	if expression.Core+expression.Tail != "12.345.678,50" {
+		t.Fatalf("expected 12.345.678,50; outcome %s%s;\n", expression.Core, expression.Tail)
+	}
+	expression = (*fact)[renderables.PureLabel]
+	if expression.Core+expression.Tail != "12,345,678.50" {
+		t.Fatalf("expected 12,345,678.50; outcome %s%s;\n", expression.Core, expression.Tail)
+	}
+}
+
+var swissFormatter = renderables.Formatter{
+	GroupSeparator:   "'",
+	DecimalSeparator: ".",
+	Forever:          "unbefristet",
+	FormatDate: func(date time.Time, hasClock bool) (string, error) {
+		return date.Format("02.01.2006"), nil
+	},
+	AsOf: func(date string) string {
+		return "per " + date
+	},
+	MonthsEnded: func(months int, date string) string {
+		return strconv.Itoa(months) + " Monate bis " + date
+	},
+}
+
+func TestMarshalRenderable_Formatter(t *testing.T) {
+	h := hydrateLocales(t)
+	r := renderLocales(t, h, renderables.WithFormatter(renderables.Deutsch, swissFormatter))
+	if r.PGrid.PeriodHeaders[0][renderables.Deutsch] != "12 Monate bis 31.12.2023" {
+		t.Fatalf("expected 12 Monate bis 31.12.2023; outcome %s;\n", r.PGrid.PeriodHeaders[0][renderables.Deutsch])
+	}
+	fact := r.PGrid.FactualQuadrant[1][0]
+	if fact == nil {
+		t.Fatalf("expected a fact")
+	}
+	expression := (*fact)[renderables.Deutsch]
+	if expression.Core+expression.Tail != "12'345'678.50" {
+		t.Fatalf("expected 12'345'678.50; outcome %s%s;\n", expression.Core, expression.Tail)
+	}
+	expression = (*fact)[renderables.PureLabel]
+	if expression.Core+expression.Tail != "12,345,678.50" {
+		t.Fatalf("expected 12,345,678.50; outcome %s%s;\n", expression.Core, expression.Tail)
+	}
+	r = renderLocales(t, h)
+	expression = (*r.PGrid.FactualQuadrant[1][0])[renderables.Deutsch]
+	if expression.Core+expression.Tail != "12.345.678,50" || r.PGrid.PeriodHeaders[0][renderables.Deutsch] != "12 Monate bis zum 31. Dezember 2023" {
+		t.Fatalf("expected the default formatter without options; outcome %s%s %s;\n", expression.Core, expression.Tail,
+			r.PGrid.PeriodHeaders[0][renderables.Deutsch])
+	}
+}
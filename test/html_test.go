@@ -0,0 +1,98 @@
+package telefacts_test
+
+import (
+	"strings"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+func TestMarshalHTML(t *testing.T) {
+	h := hydrateLocales(t)
+	catalog := renderables.GetCatalog(h)
+	slug := ""
+	for _, network := range catalog.Networks {
+		slug = network["http://abc.example.com/role/Revenue"]
+	}
+	data, err := renderables.MarshalHTML(slug, h, renderables.Deutsch, "")
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	page := string(data)
+	for _, expected := range []string{
+		`<html lang="de">`,
+		"0001 - Statement - Revenue",
+		"Umsatzerlöse",
+		"12.345.678,50",
+		`href="?labelRole=Default&amp;lang=de"`,
+		`href="?lang=fr"`,
+	} {
+		if !strings.Contains(page, expected) {
+			t.Fatalf("expected %s; outcome %s", expected, page)
+		}
+	}
+	data, err = renderables.MarshalCatalogHTML(h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if !strings.Contains(string(data), `href="`+slug+`.html"`) {
+		t.Fatalf("expected a link to %s.html; outcome %s", slug, string(data))
+	}
+}
+
+func marshalRevenueHTML(t *testing.T, h *hydratables.Hydratable) string {
+	catalog := renderables.GetCatalog(h)
+	slug := ""
+	for _, network := range catalog.Networks {
+		slug = network["http://abc.example.com/role/Revenue"]
+	}
+	data, err := renderables.MarshalHTML(slug, h, renderables.English, "")
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	return string(data)
+}
+
+func TestMarshalHTML_TextBlocks(t *testing.T) {
+	page := marshalRevenueHTML(t, hydrateStringFacts(t))
+	for _, expected := range []string{
+		`<td><table><tr><td>North </td><td>10 </td></tr><tr><td>South </td><td>20 </td></tr></table></td>`,
+		`<td>0000320193</td>`,
+		`<td>AT&amp;T Inc.</td>`,
+		`<td class="numeric">`,
+	} {
+		if !strings.Contains(page, expected) {
+			t.Fatalf("expected %s; outcome %s", expected, page)
+		}
+	}
+	for _, unexpected := range []string{`&lt;table&gt;`, `North | 10`, `<td class="numeric">0000320193`} {
+		if strings.Contains(page, unexpected) {
+			t.Fatalf("unexpected %s; outcome %s", unexpected, page)
+		}
+	}
+}
+
+func TestMarshalHTML_Footnotes(t *testing.T) {
+	f := localesFolder(t)
+	document := strings.Replace(ixFootnotesDocument, `> for the merger</ix:continuation>`,
+		`> for the <b onclick="alert(1)">merger</b><script>alert(2)</script></ix:continuation>`, 1)
+	f.Document = serializables.DecodeIxbrlFile([]byte(document))
+	if f.Document == nil {
+		t.Fatalf("expected an inline document")
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	page := marshalRevenueHTML(t, h)
+	if !strings.Contains(page, `-1">Restated for the <b>merger</b></li>`) {
+		t.Fatalf("expected the footnote markup; outcome %s", page)
+	}
+	for _, unexpected := range []string{"alert", "&lt;b&gt;"} {
+		if strings.Contains(page, unexpected) {
+			t.Fatalf("unexpected %s; outcome %s", unexpected, page)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package telefacts_test
+
+import (
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/renderables"
+)
+
+func TestNewLang(t *testing.T) {
+	expected := map[string]renderables.Lang{
+		"en":    renderables.English,
+		"en-US": renderables.English,
+		"EN-us": renderables.English,
+		"es":    renderables.Español,
+		"de":    renderables.Deutsch,
+		"fr":    renderables.Français,
+		"hi":    renderables.Hindi,
+	}
+	for tag, lang := range expected {
+		if renderables.NewLang(tag) != lang {
+			t.Fatalf("expected %s; outcome %s;\n", lang, renderables.NewLang(tag))
+		}
+	}
+	frCA := renderables.NewLang("fr-CA")
+	if frCA == renderables.Français {
+		t.Fatalf("expected fr-CA to be distinct from fr")
+	}
+	fallbacks := frCA.Fallbacks()
+	if len(fallbacks) != 3 || fallbacks[1] != renderables.Français || fallbacks[2] != renderables.English {
+		t.Fatalf("expected fr-CA, fr, en; outcome %v;\n", fallbacks)
+	}
+	labelPack := renderables.LabelPack{
+		renderables.Default: renderables.LanguagePack{
+			renderables.BriefLabel: "#abc_Revenues",
+			renderables.English:    "Revenues",
+			renderables.Français:   "Chiffre d'affaires",
+		},
+		renderables.Terse: renderables.LanguagePack{
+			renderables.English: "Sales",
+		},
+	}
+	if label := labelPack.Resolve(renderables.Default, frCA); label != "Chiffre d'affaires" {
+		t.Fatalf("expected Chiffre d'affaires; outcome %s;\n", label)
+	}
+	if label := labelPack.Resolve(renderables.Terse, renderables.NewLang("pt-BR")); label != "Sales" {
+		t.Fatalf("expected Sales; outcome %s;\n", label)
+	}
+	if label := labelPack.Resolve(renderables.Verbose, renderables.Deutsch); label != "Revenues" {
+		t.Fatalf("expected Revenues; outcome %s;\n", label)
+	}
+	delete(labelPack[renderables.Default], renderables.English)
+	if label := labelPack.Resolve(renderables.Default, renderables.Deutsch); label != "abc_Revenues" {
+		t.Fatalf("expected abc_Revenues; outcome %s;\n", label)
+	}
+}
@@ -0,0 +1,76 @@
+package telefacts_test
+
+import (
+	"reflect"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+const networkPresentation = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink">
+	<link:roleRef roleURI="http://abc.example.com/role/Revenue" xlink:type="simple" xlink:href="abc.xsd#Revenue"/>
+	<link:presentationLink xlink:type="extended" xlink:role="http://abc.example.com/role/Revenue">
+		<link:loc xlink:type="locator" xlink:label="abstract" xlink:href="abc.xsd#abc_RevenueAbstract"/>
+		<link:loc xlink:type="locator" xlink:label="product" xlink:href="abc.xsd#abc_ProductRevenue"/>
+		<link:loc xlink:type="locator" xlink:label="service" xlink:href="abc.xsd#abc_ServiceRevenue"/>
+		<link:loc xlink:type="locator" xlink:label="revenue" xlink:href="abc.xsd#abc_Revenue"/>
+		<link:loc xlink:type="locator" xlink:label="license" xlink:href="abc.xsd#abc_LicenseRevenue"/>
+		<link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="abstract" xlink:to="revenue" order="3" preferredLabel="http://www.xbrl.org/2003/role/totalLabel"/>
+		<link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="abstract" xlink:to="product" order="1"/>
+		<link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="abstract" xlink:to="service" order="2"/>
+		<link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="service" xlink:to="license" order="1"/>
+	</link:presentationLink>
+</link:linkbase>`
+
+func TestRelationshipNetwork(t *testing.T) {
+	folder := localesFolder(t)
+	presentation, err := serializables.DecodePresentationLinkbaseFile([]byte(networkPresentation))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	folder.PresentationLinkbases = map[string]serializables.PresentationLinkbaseFile{
+		"abc_pre.xml": *presentation,
+	}
+	definition, err := serializables.DecodeDefinitionLinkbaseFile([]byte(detailDefinition))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	folder.DefinitionLinkbases = map[string]serializables.DefinitionLinkbaseFile{
+		"abc_def.xml": *definition,
+	}
+	h, err := hydratables.Hydrate(folder)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	role := "http://abc.example.com/role/Revenue"
+	network := h.RelationshipNetwork(role, attr.PresentationArcrole)
+	expect := func(expected []string, outcome []string) {
+		t.Helper()
+		if !reflect.DeepEqual(expected, outcome) {
+			t.Fatalf("expected %v; outcome %v;\n", expected, outcome)
+		}
+	}
+	expect([]string{"abc.xsd#abc_RevenueAbstract"}, network.Roots())
+	children := []string{}
+	for _, child := range network.Children("abc.xsd#abc_RevenueAbstract") {
+		children = append(children, child.To)
+	}
+	expect([]string{"abc.xsd#abc_ProductRevenue", "abc.xsd#abc_ServiceRevenue", "abc.xsd#abc_Revenue"}, children)
+	parents := network.Parents("abc.xsd#abc_Revenue")
+	if len(parents) != 1 || parents[0].PreferredLabel != attr.TotalLabel || parents[0].Order != 3 {
+		t.Fatalf("expected a total label relationship; outcome %v;\n", parents)
+	}
+	expect([]string{"abc.xsd#abc_ServiceRevenue", "abc.xsd#abc_RevenueAbstract"}, network.Ancestors("abc.xsd#abc_LicenseRevenue"))
+	expect([]string{"abc.xsd#abc_ProductRevenue", "abc.xsd#abc_ServiceRevenue", "abc.xsd#abc_LicenseRevenue", "abc.xsd#abc_Revenue"},
+		network.Descendants("abc.xsd#abc_RevenueAbstract"))
+	expect([]string{"abc.xsd#abc_ProductRevenue", "abc.xsd#abc_Revenue"}, network.Siblings("abc.xsd#abc_ServiceRevenue"))
+	expect([]string{"abc.xsd#abc_LicenseRevenue", "abc.xsd#abc_ServiceRevenue", "abc.xsd#abc_RevenueAbstract"},
+		network.PathToRoot("abc.xsd#abc_LicenseRevenue"))
+	dimensions := h.RelationshipNetwork(role, attr.HypercubeDimensionArcrole)
+	expect([]string{"abc.xsd#abc_SegmentAxis"}, dimensions.Descendants("abc.xsd#abc_RevenueTable"))
+	expect([]string{role}, h.LinkRoles("abc.xsd#abc_Revenue", ""))
+	expect([]string{}, h.LinkRoles("abc.xsd#abc_Revenue", attr.CalculationArcrole))
+}
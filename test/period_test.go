@@ -0,0 +1,95 @@
+package telefacts_test
+
+import (
+	"testing"
+	"time"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+func TestParsePeriodDate(t *testing.T) {
+	end, isDate, err := hydratables.ParsePeriodDate("2020-12-31", true)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if !isDate || !end.Equal(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected 2021-01-01T00:00:00Z; outcome %v;\n", end)
+	}
+	start, isDate, err := hydratables.ParsePeriodDate("2020-01-01", false)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if !isDate || !start.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected 2020-01-01T00:00:00Z; outcome %v;\n", start)
+	}
+	dateTime, isDate, err := hydratables.ParsePeriodDate(" 2021-01-01T00:00:00 ", true)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if isDate || !dateTime.Equal(end) {
+		t.Fatalf("expected 2021-01-01T00:00:00Z; outcome %v;\n", dateTime)
+	}
+	zoned, _, err := hydratables.ParsePeriodDate("2020-06-30T17:30:00-04:00", true)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if !zoned.Equal(time.Date(2020, 6, 30, 21, 30, 0, 0, time.UTC)) {
+		t.Fatalf("expected 2020-06-30T21:30:00Z; outcome %v;\n", zoned)
+	}
+	_, _, err = hydratables.ParsePeriodDate("2020-13-01", true)
+	if err == nil {
+		t.Fatalf("expected an invalid period date")
+	}
+	period := hydratables.Period{
+		Forever: true,
+	}
+	if period.IsInstant() || period.IsDuration() {
+		t.Fatalf("expected a forever period")
+	}
+}
+
+const foreverInstance = `<?xml version="1.0" encoding="utf-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:abc="http://abc.example.com/2023" xmlns:iso4217="http://www.xbrl.org/2003/iso4217">
+	<xbrli:context id="c0">
+		<xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:forever/></xbrli:period>
+	</xbrli:context>
+	<xbrli:unit id="INR"><xbrli:measure>iso4217:INR</xbrli:measure></xbrli:unit>
+	<abc:Revenue id="f0" contextRef="c0" unitRef="INR" decimals="2">100.00</abc:Revenue>
+</xbrli:xbrl>`
+
+func TestMarshalRenderable_Forever(t *testing.T) {
+	folder := localesFolder(t)
+	instance, err := serializables.DecodeInstanceFile([]byte(foreverInstance))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	folder.Instances["abc.xml"] = *instance
+	h, err := hydratables.Hydrate(folder)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	contexts := h.Instances["abc.xml"].Contexts
+	if len(contexts) != 1 || !contexts[0].Period.Forever || contexts[0].Period.IsInstant() || contexts[0].Period.IsDuration() {
+		t.Fatalf("expected a forever context; outcome %v;\n", contexts)
+	}
+	r := renderLocales(t, h)
+	if len(r.PGrid.PeriodHeaders) != 1 || len(r.PGrid.FactualQuadrant) != 2 {
+		t.Fatalf("expected 1 period and 2 rows; outcome %v;\n", r.PGrid)
+	}
+	expectedPeriods := map[renderables.Lang]string{
+		renderables.English: "forever",
+		renderables.Deutsch: "unbefristet",
+	}
+	for lang, expected := range expectedPeriods {
+		if r.PGrid.PeriodHeaders[0][lang] != expected {
+			t.Fatalf("expected %s; outcome %s;\n", expected, r.PGrid.PeriodHeaders[0][lang])
+		}
+	}
+	fact := r.PGrid.FactualQuadrant[1][0]
+	if fact == nil || (*fact)[renderables.English].Core+(*fact)[renderables.English].Tail != "100.00" {
+		t.Fatalf("expected 100.00 in the forever column; outcome %v;\n", fact)
+	}
+}
@@ -0,0 +1,73 @@
+package telefacts_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+func TestProvenanceGrid(t *testing.T) {
+	r := renderLocales(t, hydrateLocales(t))
+	provenance := r.PGrid.ProvenanceGrid[1][0]
+	if provenance == nil {
+		t.Fatalf("expected the provenance of a fact")
+	}
+	expected := renderables.CellProvenance{
+		FactID:     "f1",
+		ContextID:  "c1",
+		UnitID:     "INR",
+		SourceFile: "abc.xml",
+	}
+	if *provenance != expected {
+		t.Fatalf("expected %v; outcome %v;\n", expected, *provenance)
+	}
+	if r.PGrid.ProvenanceGrid[0][0] != nil {
+		t.Fatalf("expected no provenance of the abstract row")
+	}
+}
+
+func TestMarshalSourceElement(t *testing.T) {
+	f := localesFolder(t)
+	f.EntryFileName = "abc.xhtml"
+	f.Document = serializables.DecodeIxbrlFile([]byte(ixFootnotesDocument))
+	if f.Document == nil {
+		t.Fatalf("expected an inline document")
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	r := renderLocales(t, h)
+	provenance := r.PGrid.ProvenanceGrid[1][0]
+	if provenance == nil || provenance.ElementID != "f1" || provenance.SourceFile != "abc.xhtml" {
+		t.Fatalf("expected the inline element f1; outcome %v;\n", provenance)
+	}
+	data, err := renderables.MarshalSourceElement(provenance.ElementID, h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	e := renderables.SourceElement{}
+	err = json.Unmarshal(data, &e)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if e.Href != "abc.xsd#abc_Revenue" || e.ContextID != "c1" || e.UnitID != "INR" {
+		t.Fatalf("expected the fact of f1; outcome %v;\n", e)
+	}
+	if e.XPath != "/html/body[1]/p[1]/ix:nonFraction[1]" {
+		t.Fatalf("expected /html/body[1]/p[1]/ix:nonFraction[1]; outcome %s;\n", e.XPath)
+	}
+	if e.Line != 19 {
+		t.Fatalf("expected line 19; outcome %d;\n", e.Line)
+	}
+	if e.HTML == "" {
+		t.Fatalf("expected the HTML of the element")
+	}
+	_, err = renderables.MarshalSourceElement("missing", h)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
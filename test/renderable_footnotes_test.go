@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"ecksbee.com/telefacts/pkg/hydratables"
@@ -57,3 +58,81 @@ func TestMarshalRenderable_Erroneous_Footnote(t *testing.T) {
 		t.Fatalf("expected Weighted average exercise price.; outcome %s;\n", r.PGrid.Footnotes[0])
 	}
 }
+
+const ixFootnotesDocument = `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+	xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:link="http://www.xbrl.org/2003/linkbase"
+	xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+	xmlns:abc="http://abc.example.com/2023">
+<body>
+	<div style="display:none">
+		<ix:header>
+			<ix:references><link:schemaRef xlink:type="simple" xlink:href="abc.xsd"/></ix:references>
+			<ix:resources>
+				<xbrli:context id="c1">
+					<xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+					<xbrli:period><xbrli:startDate>2023-01-01</xbrli:startDate><xbrli:endDate>2023-12-31</xbrli:endDate></xbrli:period>
+				</xbrli:context>
+				<xbrli:unit id="INR"><xbrli:measure>iso4217:INR</xbrli:measure></xbrli:unit>
+			</ix:resources>
+			<ix:relationship fromRefs="f1" toRefs="fn1"/>
+		</ix:header>
+	</div>
+	<p><ix:nonFraction id="f1" name="abc:Revenue" contextRef="c1" unitRef="INR" decimals="2">12,345,678.50</ix:nonFraction></p>
+	<p><ix:footnote id="fn1" xml:lang="en" continuedAt="k1">Restated</ix:footnote></p>
+	<p><ix:continuation id="k1"> for the merger</ix:continuation></p>
+</body>
+</html>`
+
+func TestMarshalRenderable_IxFootnotes(t *testing.T) {
+	f := localesFolder(t)
+	f.Document = serializables.DecodeIxbrlFile([]byte(ixFootnotesDocument))
+	if f.Document == nil {
+		t.Fatalf("expected an inline document")
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	fact := h.FindFact("abc.xsd#abc_Revenue", "c1")
+	footnotes := h.GetFootnotes(fact)
+	if len(footnotes) != 1 {
+		t.Fatalf("expected 1 footnote; outcome %d;\n", len(footnotes))
+	}
+	if footnotes[0].InnerHtml != "Restated for the merger" {
+		t.Fatalf("expected Restated for the merger; outcome %s;\n", footnotes[0].InnerHtml)
+	}
+	r := renderLocales(t, h)
+	if len(r.PGrid.Footnotes) != 1 || len(r.PGrid.FootnoteGrid[1][0]) != 1 {
+		t.Fatalf("expected the footnote in the grid; outcome %v %v;\n", r.PGrid.Footnotes, r.PGrid.FootnoteGrid)
+	}
+	data, err := renderables.MarshalExpressable("abc:Revenue", "c1", h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	e := renderables.Expressable{}
+	err = json.Unmarshal(data, &e)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(e.Footnotes) != 1 || e.Footnotes[0] != "Restated for the merger" {
+		t.Fatalf("expected 1 expressed footnote; outcome %v;\n", e.Footnotes)
+	}
+}
+
+func TestHydrate_IxFootnotes_MidWordContinuation(t *testing.T) {
+	f := localesFolder(t)
+	document := strings.Replace(ixFootnotesDocument, `>Restated</ix:footnote>`, `>Resta</ix:footnote>`, 1)
+	document = strings.Replace(document, `> for the merger</ix:continuation>`, `>ted for the <b>merger</b></ix:continuation>`, 1)
+	f.Document = serializables.DecodeIxbrlFile([]byte(document))
+	if f.Document == nil {
+		t.Fatalf("expected an inline document")
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	footnotes := h.GetFootnotes(h.FindFact("abc.xsd#abc_Revenue", "c1"))
+	if len(footnotes) != 1 || footnotes[0].InnerHtml != "Restated for the <b>merger</b>" {
+		t.Fatalf("expected Restated for the <b>merger</b>; outcome %v;\n", footnotes)
+	}
+}
@@ -0,0 +1,70 @@
+package telefacts_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+	gocache "github.com/patrickmn/go-cache"
+)
+
+func TestMarshalRenderable_PreferredLabel(t *testing.T) {
+	hcache := gocache.New(gocache.NoExpiration, gocache.NoExpiration)
+	serializables.WorkingDirectoryPath = filepath.Join(".", "wd")
+	serializables.GlobalTaxonomySetPath = filepath.Join(".", "gts")
+	hydratables.InjectCache(hcache)
+	f, err := serializables.Discover("multiple_hypercube")
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	data, err := renderables.MarshalCatalog(h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	c := renderables.Catalog{}
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	slug := ""
+	for _, network := range c.Networks {
+		slug = network["http://www.workiva.com/role/CONSOLIDATEDSTATEMENTSOFCASHFLOWS"]
+	}
+	data, err = renderables.MarshalRenderable(slug, h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	r := renderables.Renderable{}
+	err = json.Unmarshal(data, &r)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	for i, indentedLabel := range r.PGrid.IndentedLabels {
+		if indentedLabel.Href != "wk-20221231.xsd#wk_IncreaseDecreaseInCapitalizedContractCost" {
+			continue
+		}
+		if indentedLabel.PreferredLabel != renderables.NegatedTerse {
+			t.Fatalf("expected %s; outcome %s;\n", renderables.NegatedTerse, indentedLabel.PreferredLabel)
+		}
+		negated := 0
+		for _, fact := range r.PGrid.FactualQuadrant[i] {
+			expression := (*fact)[renderables.PureLabel]
+			if strings.Contains(expression.Head, "(") {
+				negated++
+			}
+		}
+		if negated != 3 {
+			t.Fatalf("expected 3 negated facts; outcome %d;\n", negated)
+		}
+		return
+	}
+	t.Fatalf("expected wk_IncreaseDecreaseInCapitalizedContractCost to be presented")
+}
@@ -83,8 +83,8 @@ func TestMarshalRenderable_Gold_BalanceSheet(t *testing.T) {
 		t.Fatalf("Error: " + err.Error())
 	}
 
-	if len(r.LabelRoles) != 3 {
-		t.Fatalf("expected 3 LabelRole; outcome %d;\n", len(r.LabelRoles))
+	if len(r.LabelRoles) != 6 {
+		t.Fatalf("expected 6 LabelRole; outcome %d;\n", len(r.LabelRoles))
 	}
 
 	if len(r.Lang) != 3 {
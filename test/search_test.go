@@ -0,0 +1,53 @@
+package telefacts_test
+
+import (
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/renderables"
+)
+
+func TestConceptIndex_Search(t *testing.T) {
+	index := renderables.NewConceptIndex(hydrateLocales(t))
+	hits := index.Search(renderables.ConceptQuery{
+		Text: "revenue",
+	})
+	if len(hits) != 2 || hits[0].Href != "abc.xsd#abc_Revenue" {
+		t.Fatalf("expected abc_Revenue to rank first of 2; outcome %v;\n", hits)
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Fatalf("expected a labelled match to outrank a prefix of the name; outcome %d, %d;\n", hits[0].Score, hits[1].Score)
+	}
+	hits = index.Search(renderables.ConceptQuery{
+		Text: "umsatz",
+	})
+	if len(hits) != 1 || hits[0].Name != "Revenue" {
+		t.Fatalf("expected a prefix match of the German label; outcome %v;\n", hits)
+	}
+	hits = index.Search(renderables.ConceptQuery{
+		Text: "statement",
+	})
+	if len(hits) != 2 {
+		t.Fatalf("expected the concepts presented in the Revenue statement; outcome %v;\n", hits)
+	}
+	hits = index.Search(renderables.ConceptQuery{
+		Text: "revenue",
+		Type: "xbrli:monetaryItemType",
+	})
+	if len(hits) != 1 || hits[0].Href != "abc.xsd#abc_Revenue" {
+		t.Fatalf("expected only the monetary concept; outcome %v;\n", hits)
+	}
+	hits = index.Search(renderables.ConceptQuery{
+		Balance:    "debit",
+		PeriodType: "duration",
+	})
+	if len(hits) != 0 {
+		t.Fatalf("expected no debit concepts; outcome %v;\n", hits)
+	}
+	hits = index.Search(renderables.ConceptQuery{
+		Text:  "revenue abstract",
+		Limit: 1,
+	})
+	if len(hits) != 1 || hits[0].Href != "abc.xsd#abc_RevenueAbstract" {
+		t.Fatalf("expected abc_RevenueAbstract; outcome %v;\n", hits)
+	}
+}
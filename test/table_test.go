@@ -0,0 +1,277 @@
+package telefacts_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+const localeTableLinkbase = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink"
+	xmlns:gen="http://xbrl.org/2008/generic" xmlns:label="http://xbrl.org/2008/label"
+	xmlns:table="http://xbrl.org/2014/table" xmlns:formula="http://xbrl.org/2008/formula">
+	<gen:link xlink:type="extended" xlink:role="http://www.xbrl.org/2003/role/link">
+		<table:table xlink:type="resource" xlink:label="table" id="revenueTable" parentChildOrder="parent-first"/>
+		<table:breakdown xlink:type="resource" xlink:label="x"/>
+		<table:breakdown xlink:type="resource" xlink:label="y"/>
+		<table:ruleNode xlink:type="resource" xlink:label="fy2023">
+			<formula:period>
+				<formula:duration start="xs:date('2023-01-01')" end="xs:date('2023-12-31')"/>
+			</formula:period>
+		</table:ruleNode>
+		<table:conceptRelationshipNode xlink:type="resource" xlink:label="concepts">
+			<table:relationshipSource xmlns:xfi="http://www.xbrl.org/2008/function/instance">xfi:root</table:relationshipSource>
+			<table:linkrole>http://abc.example.com/role/Revenue</table:linkrole>
+			<table:formulaAxis>descendant</table:formulaAxis>
+		</table:conceptRelationshipNode>
+		<label:label xlink:type="resource" xlink:label="table_lbl" xlink:role="http://www.xbrl.org/2008/role/label" xml:lang="en">Revenue by year</label:label>
+		<label:label xlink:type="resource" xlink:label="fy2023_lbl" xlink:role="http://www.xbrl.org/2008/role/label" xml:lang="en">FY 2023</label:label>
+		<table:tableBreakdownArc xlink:type="arc" xlink:arcrole="http://xbrl.org/arcrole/2014/table-breakdown" xlink:from="table" xlink:to="x" axis="x" order="1"/>
+		<table:tableBreakdownArc xlink:type="arc" xlink:arcrole="http://xbrl.org/arcrole/2014/table-breakdown" xlink:from="table" xlink:to="y" axis="y" order="2"/>
+		<table:breakdownTreeArc xlink:type="arc" xlink:arcrole="http://xbrl.org/arcrole/2014/breakdown-tree" xlink:from="x" xlink:to="fy2023"/>
+		<table:breakdownTreeArc xlink:type="arc" xlink:arcrole="http://xbrl.org/arcrole/2014/breakdown-tree" xlink:from="y" xlink:to="concepts"/>
+		<gen:arc xlink:type="arc" xlink:arcrole="http://xbrl.org/arcrole/2008/element-label" xlink:from="table" xlink:to="table_lbl"/>
+		<gen:arc xlink:type="arc" xlink:arcrole="http://xbrl.org/arcrole/2008/element-label" xlink:from="fy2023" xlink:to="fy2023_lbl"/>
+	</gen:link>
+</link:linkbase>`
+
+func hydrateLocaleTable(t *testing.T) *hydratables.Hydratable {
+	folder := localesFolder(t)
+	table, err := serializables.DecodeTableLinkbaseFile([]byte(localeTableLinkbase))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	folder.TableLinkbases = map[string]serializables.TableLinkbaseFile{
+		"abc_table.xml": *table,
+	}
+	h, err := hydratables.Hydrate(folder)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	return h
+}
+
+func TestTables(t *testing.T) {
+	h := hydrateLocaleTable(t)
+	tables := h.Tables()
+	if len(tables) != 1 {
+		t.Fatalf("expected 1 table; outcome %d;\n", len(tables))
+	}
+	table := tables[0]
+	if table.Href != "abc_table.xml#revenueTable" {
+		t.Fatalf("expected abc_table.xml#revenueTable; outcome %s;\n", table.Href)
+	}
+	if len(table.Breakdowns["x"]) != 1 || len(table.Breakdowns["y"]) != 1 {
+		t.Fatalf("expected an x and a y breakdown; outcome %v;\n", table.Breakdowns)
+	}
+	rule := table.Breakdowns["x"][0].Nodes[0]
+	if rule.Kind != "ruleNode" || rule.Period == nil || rule.Period.Duration.EndDate != "2023-12-31" {
+		t.Fatalf("expected a rule node of FY 2023; outcome %v;\n", rule)
+	}
+	concepts := table.Breakdowns["y"][0].Nodes[0]
+	if len(concepts.RelationshipSources) != 1 || concepts.RelationshipSources[0] != "" {
+		t.Fatalf("expected xfi:root; outcome %v;\n", concepts.RelationshipSources)
+	}
+}
+
+func TestMarshalTGrids(t *testing.T) {
+	h := hydrateLocaleTable(t)
+	data, err := renderables.MarshalTables(h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	summaries := []renderables.TableSummary{}
+	err = json.Unmarshal(data, &summaries)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("expected 1 table; outcome %d;\n", len(summaries))
+	}
+	if summaries[0].Label.Resolve(renderables.Default, renderables.English) != "Revenue by year" {
+		t.Fatalf("expected Revenue by year; outcome %v;\n", summaries[0].Label)
+	}
+	grids, err := renderables.GetTGrids(summaries[0].Slug, h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(grids) != 1 {
+		t.Fatalf("expected 1 grid; outcome %d;\n", len(grids))
+	}
+	grid := grids[0]
+	if len(grid.ColumnHeaders) != 1 || len(grid.ColumnHeaders[0]) != 1 {
+		t.Fatalf("expected 1 column; outcome %v;\n", grid.ColumnHeaders)
+	}
+	if grid.ColumnHeaders[0][0].Label.Resolve(renderables.Default, renderables.English) != "FY 2023" {
+		t.Fatalf("expected FY 2023; outcome %v;\n", grid.ColumnHeaders[0][0].Label)
+	}
+	if len(grid.RowHeaders) != 2 || !grid.RowHeaders[0][0].IsAbstract ||
+		grid.RowHeaders[1][0].Href != "abc.xsd#abc_Revenue" || grid.RowHeaders[1][0].Indentation != 1 {
+		t.Fatalf("expected the abstract and Revenue rows; outcome %v;\n", grid.RowHeaders)
+	}
+	if grid.FactualQuadrant[0][0] != nil {
+		t.Fatalf("expected no fact in the abstract row")
+	}
+	fact := grid.FactualQuadrant[1][0]
+	if fact == nil {
+		t.Fatalf("expected a fact")
+	}
+	if (*fact)[renderables.Deutsch].Core != "12.345.678,50" {
+		t.Fatalf("expected 12.345.678,50; outcome %v;\n", *fact)
+	}
+	_, err = renderables.GetTGrids("missing", h)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestDiscover_GenericLinkbaseRefs(t *testing.T) {
+	wd := serializables.WorkingDirectoryPath
+	defer func() {
+		serializables.WorkingDirectoryPath = wd
+	}()
+	serializables.WorkingDirectoryPath = t.TempDir()
+	folderDir := filepath.Join(serializables.WorkingDirectoryPath, "folders", "generic")
+	err := os.MkdirAll(folderDir, 0755)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	schema := strings.Replace(localeSchema, `<xs:appinfo>`, `<xs:appinfo>
+			<link:linkbaseRef xlink:type="simple" xlink:arcrole="http://www.w3.org/1999/xlink/properties/linkbase" xlink:href="abc_table.xml"/>
+			<link:linkbaseRef xlink:type="simple" xlink:arcrole="http://www.w3.org/1999/xlink/properties/linkbase" xlink:href="abc_pre.xml"/>
+			<link:linkbaseRef xlink:type="simple" xlink:arcrole="http://www.w3.org/1999/xlink/properties/linkbase" xlink:role="http://abc.example.com/role/otherLinkbaseRef" xlink:href="abc_other.xml"/>`, 1)
+	schema = strings.Replace(schema, `xmlns:link=`, `xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:link=`, 1)
+	for name, content := range map[string]string{
+		"_":             `{"Entry":"abc.xsd"}`,
+		"abc.xsd":       schema,
+		"abc_table.xml": localeTableLinkbase,
+		"abc_pre.xml":   localePresentation,
+		"abc_other.xml": `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:other="http://abc.example.com/other">
+	<other:link xlink:type="extended" xlink:role="http://www.xbrl.org/2003/role/link"/>
+</link:linkbase>`,
+	} {
+		err = os.WriteFile(filepath.Join(folderDir, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("Error: " + err.Error())
+		}
+	}
+	f, err := serializables.Discover("generic")
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(f.TableLinkbases) != 1 {
+		t.Fatalf("expected 1 generic linkbase; outcome %d", len(f.TableLinkbases))
+	}
+	if _, found := f.TableLinkbases["abc_table.xml"]; !found {
+		t.Fatalf("expected abc_table.xml; outcome %v", f.TableLinkbases)
+	}
+}
+
+const entitiesInstance = `<?xml version="1.0" encoding="utf-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:abc="http://abc.example.com/2023" xmlns:iso4217="http://www.xbrl.org/2003/iso4217">
+	<xbrli:context id="c1">
+		<xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:startDate>2023-01-01</xbrli:startDate><xbrli:endDate>2023-12-31</xbrli:endDate></xbrli:period>
+	</xbrli:context>
+	<xbrli:context id="c2">
+		<xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:startDate>2022-01-01</xbrli:startDate><xbrli:endDate>2022-12-31</xbrli:endDate></xbrli:period>
+	</xbrli:context>
+	<xbrli:context id="c3">
+		<xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000002</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:startDate>2023-01-01</xbrli:startDate><xbrli:endDate>2023-12-31</xbrli:endDate></xbrli:period>
+	</xbrli:context>
+	<xbrli:unit id="INR"><xbrli:measure>iso4217:INR</xbrli:measure></xbrli:unit>
+	<abc:Revenue id="f1" contextRef="c1" unitRef="INR" decimals="0">100</abc:Revenue>
+	<abc:Revenue id="f2" contextRef="c2" unitRef="INR" decimals="0">90</abc:Revenue>
+	<abc:Revenue id="f3" contextRef="c3" unitRef="INR" decimals="0">200</abc:Revenue>
+</xbrli:xbrl>`
+
+const entitiesTableLinkbase = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink"
+	xmlns:gen="http://xbrl.org/2008/generic" xmlns:table="http://xbrl.org/2014/table">
+	<gen:link xlink:type="extended" xlink:role="http://www.xbrl.org/2003/role/link">
+		<table:table xlink:type="resource" xlink:label="table" id="entitiesTable" parentChildOrder="parent-first"/>
+		<table:breakdown xlink:type="resource" xlink:label="x"/>
+		<table:breakdown xlink:type="resource" xlink:label="y"/>
+		<table:aspectNode xlink:type="resource" xlink:label="entities">
+			<table:entityIdentifierAspect/>
+		</table:aspectNode>
+		<table:conceptRelationshipNode xlink:type="resource" xlink:label="concepts">
+			<table:relationshipSource xmlns:xfi="http://www.xbrl.org/2008/function/instance">xfi:root</table:relationshipSource>
+			<table:linkrole>http://abc.example.com/role/Revenue</table:linkrole>
+			<table:formulaAxis>descendant</table:formulaAxis>
+		</table:conceptRelationshipNode>
+		<table:tableBreakdownArc xlink:type="arc" xlink:arcrole="http://xbrl.org/arcrole/2014/table-breakdown" xlink:from="table" xlink:to="x" axis="x" order="1"/>
+		<table:tableBreakdownArc xlink:type="arc" xlink:arcrole="http://xbrl.org/arcrole/2014/table-breakdown" xlink:from="table" xlink:to="y" axis="y" order="2"/>
+		<table:breakdownTreeArc xlink:type="arc" xlink:arcrole="http://xbrl.org/arcrole/2014/breakdown-tree" xlink:from="x" xlink:to="entities"/>
+		<table:breakdownTreeArc xlink:type="arc" xlink:arcrole="http://xbrl.org/arcrole/2014/breakdown-tree" xlink:from="y" xlink:to="concepts"/>
+	</gen:link>
+</link:linkbase>`
+
+func TestGetTGrids_OpenAspects(t *testing.T) {
+	folder := localesFolder(t)
+	instance, err := serializables.DecodeInstanceFile([]byte(entitiesInstance))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	folder.Instances = map[string]serializables.InstanceFile{
+		"abc.xml": *instance,
+	}
+	folder.TableLinkbases = map[string]serializables.TableLinkbaseFile{}
+	for fileName, linkbase := range map[string]string{
+		"abc_table.xml":    localeTableLinkbase,
+		"abc_entities.xml": entitiesTableLinkbase,
+	} {
+		table, err := serializables.DecodeTableLinkbaseFile([]byte(linkbase))
+		if err != nil {
+			t.Fatalf("Error: " + err.Error())
+		}
+		folder.TableLinkbases[fileName] = *table
+	}
+	h, err := hydratables.Hydrate(folder)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	data, err := renderables.MarshalTables(h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	summaries := []renderables.TableSummary{}
+	err = json.Unmarshal(data, &summaries)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	grids := map[string]renderables.TGrid{}
+	for _, summary := range summaries {
+		tgrids, err := renderables.GetTGrids(summary.Slug, h)
+		if err != nil || len(tgrids) != 1 {
+			t.Fatalf("expected 1 grid of %s; outcome %v", summary.Href, err)
+		}
+		grids[summary.Href] = tgrids[0]
+	}
+	byYear := grids["abc_table.xml#revenueTable"]
+	if len(byYear.FactualQuadrant) != 2 || byYear.FactualQuadrant[1][0] != nil {
+		t.Fatalf("expected no fact for FY 2023 across two entities; outcome %v", byYear.FactualQuadrant)
+	}
+	byEntity := grids["abc_entities.xml#entitiesTable"]
+	if len(byEntity.ColumnHeaders) != 1 || len(byEntity.ColumnHeaders[0]) != 2 ||
+		byEntity.ColumnHeaders[0][0].Label.Resolve(renderables.Default, renderables.English) != "0000000001" ||
+		byEntity.ColumnHeaders[0][1].Label.Resolve(renderables.Default, renderables.English) != "0000000002" {
+		t.Fatalf("expected a column of each entity; outcome %v", byEntity.ColumnHeaders)
+	}
+	if byEntity.FactualQuadrant[1][0] != nil {
+		t.Fatalf("expected no fact for the first entity across two periods; outcome %v", *byEntity.FactualQuadrant[1][0])
+	}
+	fact := byEntity.FactualQuadrant[1][1]
+	if fact == nil || (*fact)[renderables.English].Core != "200" {
+		t.Fatalf("expected 200 for the second entity; outcome %v", fact)
+	}
+}
@@ -0,0 +1,89 @@
+package telefacts_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+	gocache "github.com/patrickmn/go-cache"
+)
+
+func TestMarshalRenderable_TaxonomyOnly(t *testing.T) {
+	hydratables.InjectCache(gocache.New(gocache.NoExpiration, gocache.NoExpiration))
+	wd := t.TempDir()
+	serializables.WorkingDirectoryPath = wd
+	serializables.GlobalTaxonomySetPath = filepath.Join(".", "gts")
+	defer func() {
+		serializables.WorkingDirectoryPath = filepath.Join(".", "wd")
+	}()
+	dir := filepath.Join(wd, "folders", "taxonomy_only")
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	schema := strings.Replace(localeSchema, "</xs:appinfo>",
+		`<link:linkbaseRef xlink:type="simple" xlink:href="abc_pre.xml" xlink:role="http://www.xbrl.org/2003/role/presentationLinkbaseRef" xlink:arcrole="http://www.w3.org/1999/xlink/properties/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink"/>
+			<link:linkbaseRef xlink:type="simple" xlink:href="abc_lab.xml" xlink:role="http://www.xbrl.org/2003/role/labelLinkbaseRef" xlink:arcrole="http://www.w3.org/1999/xlink/properties/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink"/>
+		</xs:appinfo>`, 1)
+	files := map[string]string{
+		"_":           `{"Entry":"abc.xsd"}`,
+		"abc.xsd":     schema,
+		"abc_pre.xml": localePresentation,
+		"abc_lab.xml": localeLabel,
+	}
+	for name, content := range files {
+		err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("Error: " + err.Error())
+		}
+	}
+	f, err := serializables.Discover("taxonomy_only")
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(f.Instances) != 0 || len(f.Schemas) != 1 || len(f.PresentationLinkbases) != 1 {
+		t.Fatalf("expected a schema and its linkbases without instances; outcome %v;\n", f)
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	data, err := renderables.MarshalCatalog(h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	c := renderables.Catalog{}
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(c.Subjects) != 1 || c.Subjects[0].Name != "abc.xsd" {
+		t.Fatalf("expected the taxonomy subject; outcome %v;\n", c.Subjects)
+	}
+	slug := c.Networks["/"]["http://abc.example.com/role/Revenue"]
+	data, err = renderables.MarshalRenderable(slug, h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	r := renderables.Renderable{}
+	err = json.Unmarshal(data, &r)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(r.PGrid.IndentedLabels) != 2 {
+		t.Fatalf("expected 2 indented labels; outcome %d;\n", len(r.PGrid.IndentedLabels))
+	}
+	if len(r.PGrid.PeriodHeaders) != 0 {
+		t.Fatalf("expected no period headers; outcome %v;\n", r.PGrid.PeriodHeaders)
+	}
+	for _, row := range r.PGrid.FactualQuadrant {
+		if len(row) != 0 {
+			t.Fatalf("expected an empty factual quadrant; outcome %v;\n", row)
+		}
+	}
+}
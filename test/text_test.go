@@ -0,0 +1,47 @@
+package telefacts_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/renderables"
+)
+
+func TestWriteText(t *testing.T) {
+	h := hydrateLocales(t)
+	r := renderLocales(t, h)
+	var buf bytes.Buffer
+	err := renderables.WriteText(&buf, &r, renderables.PlainText, renderables.Deutsch, "", 80)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	found := false
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.HasPrefix(line, "  Umsatzerlöse") && strings.HasSuffix(line, "12.345.678,50") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected an indented, right aligned revenue row; outcome %s", buf.String())
+	}
+	buf.Reset()
+	err = renderables.WriteText(&buf, &r, renderables.Markdown, renderables.English, "", 0)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	lines := strings.Split(buf.String(), "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "| ---") {
+			continue
+		}
+		if i < 1 || !strings.HasPrefix(lines[i-1], "| ") || !strings.HasSuffix(line, ": |") {
+			t.Fatalf("unexpected table %s", buf.String())
+		}
+		if !strings.Contains(buf.String(), "Revenue") {
+			t.Fatalf("expected a Revenue row; outcome %s", buf.String())
+		}
+		return
+	}
+	t.Fatalf("expected a Markdown table; outcome %s", buf.String())
+}
@@ -0,0 +1,106 @@
+package telefacts_test
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/serializables"
+	gocache "github.com/patrickmn/go-cache"
+)
+
+const typesSchema = `<?xml version="1.0" encoding="utf-8"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:xbrli="http://www.xbrl.org/2003/instance"
+	xmlns:dtr-types="http://www.xbrl.org/dtr/type/numeric" xmlns:nonnum="http://www.xbrl.org/dtr/type/non-numeric"
+	xmlns:abc="http://abc.example.com/2023" targetNamespace="http://abc.example.com/2023" elementFormDefault="qualified">
+	<xs:complexType name="reserveItemType">
+		<xs:simpleContent>
+			<xs:restriction base="xbrli:monetaryItemType"/>
+		</xs:simpleContent>
+	</xs:complexType>
+	<xs:complexType name="noteItemType">
+		<xs:simpleContent>
+			<xs:restriction base="nonnum:textBlockItemType"/>
+		</xs:simpleContent>
+	</xs:complexType>
+	<xs:simpleType name="code">
+		<xs:restriction base="xs:token"/>
+	</xs:simpleType>
+	<xs:element id="abc_Reserve" name="Reserve" type="abc:reserveItemType" substitutionGroup="xbrli:item" xbrli:periodType="instant"/>
+	<xs:element id="abc_Note" name="Note" type="abc:noteItemType" substitutionGroup="xbrli:item" xbrli:periodType="duration"/>
+	<xs:element id="abc_EarningsPerShare" name="EarningsPerShare" type="dtr-types:perShareItemType" substitutionGroup="xbrli:item" xbrli:periodType="duration"/>
+</xs:schema>`
+
+const typesInstance = `<?xml version="1.0" encoding="utf-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:abc="http://abc.example.com/2023" xmlns:iso4217="http://www.xbrl.org/2003/iso4217">
+	<xbrli:context id="c1">
+		<xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:instant>2023-12-31</xbrli:instant></xbrli:period>
+	</xbrli:context>
+	<xbrli:unit id="USD"><xbrli:measure>iso4217:USD</xbrli:measure></xbrli:unit>
+	<abc:Reserve id="f1" contextRef="c1" unitRef="USD" decimals="0">1500</abc:Reserve>
+</xbrli:xbrl>`
+
+func TestHydrate_TypeHierarchy(t *testing.T) {
+	hydratables.InjectCache(gocache.New(gocache.NoExpiration, gocache.NoExpiration))
+	serializables.WorkingDirectoryPath = filepath.Join(".", "wd")
+	serializables.GlobalTaxonomySetPath = filepath.Join(".", "gts")
+	schema, err := serializables.DecodeSchemaFile([]byte(typesSchema))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	instance, err := serializables.DecodeInstanceFile([]byte(typesInstance))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	f := &serializables.Folder{
+		EntryFileName: "abc.xml",
+		Namespaces: map[string]string{
+			"http://abc.example.com/2023": "abc.xsd",
+		},
+		Instances: map[string]serializables.InstanceFile{
+			"abc.xml": *instance,
+		},
+		Schemas: map[string]serializables.SchemaFile{
+			"abc.xsd": *schema,
+		},
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(h.Schemas["abc.xsd"].Types) != 3 {
+		t.Fatalf("expected 3 type definitions; outcome %v;\n", h.Schemas["abc.xsd"].Types)
+	}
+	_, reserve, err := h.HashQuery("abc.xsd#abc_Reserve")
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if !reserve.IsMonetary() || !reserve.IsNumeric() || reserve.IsTextBlock() {
+		t.Fatalf("expected a monetary concept; outcome %v;\n", reserve.TypeHierarchy)
+	}
+	monetary := xml.Name{Space: attr.XBRLI, Local: attr.MonetaryItemType}
+	if reserve.BaseXBRLType() != monetary {
+		t.Fatalf("expected %v; outcome %v;\n", monetary, reserve.BaseXBRLType())
+	}
+	_, note, err := h.HashQuery("abc.xsd#abc_Note")
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if !note.IsTextBlock() || note.IsNumeric() {
+		t.Fatalf("expected a text block concept; outcome %v;\n", note.TypeHierarchy)
+	}
+	_, eps, err := h.HashQuery("abc.xsd#abc_EarningsPerShare")
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if !eps.IsNumeric() || eps.IsMonetary() || eps.BaseXBRLType().Local != "decimalItemType" {
+		t.Fatalf("expected a decimal per share concept; outcome %v;\n", eps.TypeHierarchy)
+	}
+	fact := h.FindFact("abc.xsd#abc_Reserve", "c1")
+	if fact == nil || fact.Value == nil || fact.Value.Kind != hydratables.DecimalValue {
+		t.Fatalf("expected a decimal fact value; outcome %v;\n", fact)
+	}
+}
@@ -0,0 +1,46 @@
+package telefacts_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/hydratables"
+)
+
+func measure(space string, local string, prefix string) hydratables.UnitMeasure {
+	return hydratables.UnitMeasure{
+		XMLName: xml.Name{
+			Space: space,
+			Local: local,
+		},
+		CharData: prefix + ":" + local,
+	}
+}
+
+func TestUnit_Algebra(t *testing.T) {
+	usd := measure(attr.ISO4217, "USD", "iso4217")
+	shares := measure(attr.XBRLI, "shares", "xbrli")
+	pure := measure(attr.XBRLI, "pure", "xbrli")
+	a := hydratables.NewUnit("u1", []hydratables.UnitMeasure{usd}, []hydratables.UnitMeasure{shares})
+	b := hydratables.NewUnit("u2", []hydratables.UnitMeasure{usd}, []hydratables.UnitMeasure{shares})
+	if !a.Equal(b) {
+		t.Fatalf("expected %s to equal %s", a, b)
+	}
+	if a.String() != "iso4217:USD × xbrli:shares⁻¹" {
+		t.Fatalf("expected iso4217:USD × xbrli:shares⁻¹; outcome %s;\n", a)
+	}
+	c := hydratables.NewUnit("u3", []hydratables.UnitMeasure{shares, usd, pure}, []hydratables.UnitMeasure{shares, shares})
+	simplified := c.Simplify()
+	if !simplified.Equal(a) {
+		t.Fatalf("expected %s; outcome %s;\n", a, simplified)
+	}
+	d := hydratables.NewUnit("u4", []hydratables.UnitMeasure{usd}, []hydratables.UnitMeasure{usd})
+	if !d.IsPure() {
+		t.Fatalf("expected %s to simplify to pure", d)
+	}
+	e := hydratables.NewUnit("u5", []hydratables.UnitMeasure{usd, usd}, nil)
+	if e.String() != "iso4217:USD²" {
+		t.Fatalf("expected iso4217:USD²; outcome %s;\n", e)
+	}
+}
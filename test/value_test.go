@@ -0,0 +1,111 @@
+package telefacts_test
+
+import (
+	"encoding/xml"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ecksbee.com/telefacts/pkg/attr"
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/serializables"
+	gocache "github.com/patrickmn/go-cache"
+)
+
+func TestParseValue(t *testing.T) {
+	monetary := xml.Name{Space: attr.XBRLI, Local: "monetaryItemType"}
+	value, err := hydratables.ParseValue(" -1234.50 ", monetary, nil)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if value.Kind != hydratables.DecimalValue || value.Decimal.Cmp(big.NewRat(-2469, 2)) != 0 {
+		t.Fatalf("expected -1234.5; outcome %v;\n", value.Decimal)
+	}
+	_, err = hydratables.ParseValue("1,234", monetary, nil)
+	if err == nil {
+		t.Fatalf("expected an invalid decimal")
+	}
+	_, err = hydratables.ParseValue("-1", xml.Name{Space: attr.XBRLI, Local: "nonNegativeIntegerItemType"}, nil)
+	if err == nil {
+		t.Fatalf("expected an out of range nonNegativeInteger")
+	}
+	value, err = hydratables.ParseValue("2020-09-30", xml.Name{Space: attr.XBRLI, Local: "dateItemType"}, nil)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if value.Kind != hydratables.DateValue || !value.Date.Equal(time.Date(2020, 9, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected 2020-09-30; outcome %v;\n", value.Date)
+	}
+	value, err = hydratables.ParseValue("2020", xml.Name{Space: attr.XBRLI, Local: "gYearItemType"}, nil)
+	if err != nil || value.GYear != 2020 {
+		t.Fatalf("expected gYear 2020; outcome %v;\n", value)
+	}
+	value, err = hydratables.ParseValue("1", xml.Name{Space: attr.XBRLI, Local: "booleanItemType"}, nil)
+	if err != nil || !value.Boolean {
+		t.Fatalf("expected true; outcome %v;\n", value)
+	}
+	_, err = hydratables.ParseValue("yes", xml.Name{Space: attr.XBRLI, Local: "booleanItemType"}, nil)
+	if err == nil {
+		t.Fatalf("expected an invalid boolean")
+	}
+	namespaces := map[string]string{"us-gaap": "http://fasb.org/us-gaap/2020-01-31"}
+	value, err = hydratables.ParseValue("us-gaap:Revenues", xml.Name{Space: attr.XBRLI, Local: "QNameItemType"}, namespaces)
+	if err != nil || value.QName.Space != namespaces["us-gaap"] || value.QName.Local != "Revenues" {
+		t.Fatalf("expected us-gaap:Revenues; outcome %v;\n", value)
+	}
+	value, err = hydratables.ParseValue("P3Y9M18DT1.5S", xml.Name{Space: attr.XBRLI, Local: "durationItemType"}, nil)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if value.Duration.Years != 3 || value.Duration.Months != 9 || value.Duration.Days != 18 ||
+		value.Duration.Seconds.Cmp(big.NewRat(3, 2)) != 0 {
+		t.Fatalf("expected P3Y9M18DT1.5S; outcome %v;\n", value.Duration)
+	}
+	_, err = hydratables.ParseValue("P1YT", xml.Name{Space: attr.XBRLI, Local: "durationItemType"}, nil)
+	if err == nil {
+		t.Fatalf("expected an invalid duration")
+	}
+	value, err = hydratables.ParseValue("http://example.com/a#A http://example.com/a#B",
+		xml.Name{Space: attr.ENUM2, Local: attr.EnumerationSetItemType}, nil)
+	if err != nil || len(value.EnumerationSet) != 2 {
+		t.Fatalf("expected 2 enumerations; outcome %v;\n", value)
+	}
+}
+
+func TestHydrate_TypedValues(t *testing.T) {
+	hydratables.InjectCache(gocache.New(gocache.NoExpiration, gocache.NoExpiration))
+	serializables.WorkingDirectoryPath = filepath.Join(".", "wd")
+	serializables.GlobalTaxonomySetPath = filepath.Join(".", "gts")
+	f, err := serializables.Discover("test_small")
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	expected := map[string]hydratables.ValueKind{
+		"https://xbrl.sec.gov/dei/2018/dei-2018-01-31.xsd#dei_DocumentPeriodEndDate":   hydratables.DateValue,
+		"https://xbrl.sec.gov/dei/2018/dei-2018-01-31.xsd#dei_DocumentFiscalYearFocus": hydratables.GYearValue,
+		"https://xbrl.sec.gov/dei/2018/dei-2018-01-31.xsd#dei_AmendmentFlag":           hydratables.BooleanValue,
+	}
+	for _, ins := range h.Instances {
+		for _, fact := range ins.Facts {
+			if fact.ValueError != nil {
+				t.Fatalf("expected a valid %s; outcome %s;\n", fact.Href, fact.ValueError.Error())
+			}
+			kind, found := expected[fact.Href]
+			if !found {
+				continue
+			}
+			if fact.Value == nil || fact.Value.Kind != kind {
+				t.Fatalf("expected %s to be of kind %d; outcome %v;\n", fact.Href, kind, fact.Value)
+			}
+			delete(expected, fact.Href)
+		}
+	}
+	if len(expected) > 0 {
+		t.Fatalf("expected typed facts %v", expected)
+	}
+}
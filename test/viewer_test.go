@@ -0,0 +1,154 @@
+package telefacts_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+func TestMarshalViewer(t *testing.T) {
+	f := localesFolder(t)
+	f.EntryFileName = "abc.xhtml"
+	f.Document = serializables.DecodeIxbrlFile([]byte(ixFootnotesDocument))
+	if f.Document == nil {
+		t.Fatalf("expected an inline document")
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	data, err := renderables.MarshalViewer(h, renderables.Deutsch)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	document := string(data)
+	for _, expected := range []string{
+		`data-telefacts-id="f1"`,
+		`data-telefacts-concept="Umsatzerlöse"`,
+		`data-telefacts-period="12 Monate bis zum 31. Dezember 2023"`,
+		`>12,345,678.50</ix:nonFraction>`,
+		`<script type="application/json" id="telefacts-facts">`,
+		`[data-telefacts-id] {`,
+	} {
+		if !strings.Contains(document, expected) {
+			t.Fatalf("expected %s; outcome\n%s", expected, document)
+		}
+	}
+	data, err = renderables.MarshalViewerIndex(h, renderables.English)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	index := []renderables.ViewerFact{}
+	err = json.Unmarshal(data, &index)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(index) != 1 {
+		t.Fatalf("expected 1 fact; outcome %d;\n", len(index))
+	}
+	if index[0].Href != "abc.xsd#abc_Revenue" || index[0].Concept != "Revenue" ||
+		index[0].Period != "12 months ended December 31, 2023" || index[0].Unit == "" {
+		t.Fatalf("expected the Revenue of 2023; outcome %v;\n", index[0])
+	}
+	if !strings.Contains(index[0].Value, "12,345,678.50") {
+		t.Fatalf("expected 12,345,678.50; outcome %s;\n", index[0].Value)
+	}
+	_, err = renderables.MarshalViewer(hydrateLocales(t), renderables.English)
+	if err == nil {
+		t.Fatalf("expected an error without an inline document")
+	}
+}
+
+const viewerNilDocument = `<?xml version="1.0" encoding="utf-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+	xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:link="http://www.xbrl.org/2003/linkbase"
+	xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+	xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:abc="http://abc.example.com/2023">
+<head><TITLE>Revenue</TITLE></head>
+<body>
+	<div style="display:none">
+		<ix:header>
+			<ix:references><link:schemaRef xlink:type="simple" xlink:href="abc.xsd"/></ix:references>
+			<ix:resources>
+				<xbrli:context id="c1">
+					<xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+					<xbrli:period><xbrli:startDate>2023-01-01</xbrli:startDate><xbrli:endDate>2023-12-31</xbrli:endDate></xbrli:period>
+				</xbrli:context>
+				<xbrli:unit id="INR"><xbrli:measure>iso4217:INR</xbrli:measure></xbrli:unit>
+			</ix:resources>
+		</ix:header>
+	</div>
+	<table><tr><td/><td>Revenue <ix:nonFraction id="f1" name="abc:Revenue" contextRef="c1" unitRef="INR" decimals="2" scale="0">12,345,678.50</ix:nonFraction></td></tr></table>
+	<p>Restated <ix:nonFraction id="f2" name="abc:Revenue" contextRef="c1" unitRef="INR" xsi:nil="true"/> was not reported.</p>
+	<div class="spacer"/>
+	<p>After the spacer &amp; the table</p>
+</body>
+</html>`
+
+const viewerNilInstance = `<?xml version="1.0" encoding="utf-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:abc="http://abc.example.com/2023"
+	xmlns:iso4217="http://www.xbrl.org/2003/iso4217" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+	<xbrli:context id="c1">
+		<xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+		<xbrli:period><xbrli:startDate>2023-01-01</xbrli:startDate><xbrli:endDate>2023-12-31</xbrli:endDate></xbrli:period>
+	</xbrli:context>
+	<xbrli:unit id="INR"><xbrli:measure>iso4217:INR</xbrli:measure></xbrli:unit>
+	<abc:Revenue id="f1" contextRef="c1" unitRef="INR" decimals="2">12345678.50</abc:Revenue>
+	<abc:Revenue id="f2" contextRef="c1" unitRef="INR" xsi:nil="true"/>
+</xbrli:xbrl>`
+
+func TestMarshalViewer_Markup(t *testing.T) {
+	f := localesFolder(t)
+	f.EntryFileName = "abc.xhtml"
+	f.Document = serializables.DecodeIxbrlFile([]byte(viewerNilDocument))
+	if f.Document == nil {
+		t.Fatalf("expected an inline document")
+	}
+	instance, err := serializables.DecodeInstanceFile([]byte(viewerNilInstance))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	f.Instances = map[string]serializables.InstanceFile{
+		"abc.xhtml.xml": *instance,
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	data, err := renderables.MarshalViewer(h, renderables.English)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	document := string(data)
+	for _, expected := range []string{
+		`<ix:nonFraction id="f2" name="abc:Revenue" contextRef="c1" unitRef="INR" xsi:nil="true" data-telefacts-id="f2"`,
+		`scale="0" data-telefacts-id="f1"`,
+		`data-telefacts-scale="0"`,
+		`"/> was not reported.</p>`,
+		`<style type="text/css">[data-telefacts-id] {`,
+		`</style></head>`,
+		`</script></body>`,
+	} {
+		if !strings.Contains(document, expected) {
+			t.Fatalf("expected %s; outcome\n%s", expected, document)
+		}
+	}
+	inserted := regexp.MustCompile(` (data-telefacts-[a-z]+|title)="[^"]*"|<style type="text/css">[^<]*</style>|<script type="application/json" id="telefacts-facts">[^<]*</script>`)
+	if stripped := inserted.ReplaceAllString(document, ""); stripped != viewerNilDocument {
+		t.Fatalf("expected the markup of the document unchanged; outcome\n%s", stripped)
+	}
+	index := []renderables.ViewerFact{}
+	script := document[strings.Index(document, `id="telefacts-facts">`)+len(`id="telefacts-facts">`) : strings.Index(document, `</script>`)]
+	err = json.Unmarshal([]byte(script), &index)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(index) != 2 || index[0].ID != "f1" || index[1].ID != "f2" {
+		t.Fatalf("expected f1 and f2 in document order; outcome %v", index)
+	}
+}
@@ -0,0 +1,188 @@
+package telefacts_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+func TestMarshalWorkbook(t *testing.T) {
+	f := localesFolder(t)
+	f.Document = serializables.DecodeIxbrlFile([]byte(ixFootnotesDocument))
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	data, err := renderables.MarshalCatalog(h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	c := renderables.Catalog{}
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	slug := ""
+	for _, network := range c.Networks {
+		slug = network["http://abc.example.com/role/Revenue"]
+	}
+	data, err = renderables.MarshalWorkbook(slug, h, renderables.Deutsch)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	parts := map[string]string{}
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("Error: " + err.Error())
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("Error: " + err.Error())
+		}
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("expected %s to be well formed; outcome %v;\n", file.Name, err)
+			}
+		}
+		parts[file.Name] = string(content)
+	}
+	for _, name := range []string{"Presentation", "Definition", "Calculation"} {
+		if !strings.Contains(parts["xl/workbook.xml"], `name="`+name+`"`) {
+			t.Fatalf("expected a %s sheet; outcome %s;\n", name, parts["xl/workbook.xml"])
+		}
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	if !strings.Contains(sheet, "12 Monate bis zum 31. Dezember 2023") || !strings.Contains(sheet, "Umsatzerlöse") {
+		t.Fatalf("expected German headers and labels; outcome %s;\n", sheet)
+	}
+	if !strings.Contains(sheet, `<c r="B6" s="`) || !strings.Contains(sheet, "<v>12345678.5</v>") {
+		t.Fatalf("expected a numeric cell at B6; outcome %s;\n", sheet)
+	}
+	if !strings.Contains(parts["xl/styles.xml"], `#,##0.00;(`) {
+		t.Fatalf("expected a format with 2 decimals; outcome %s;\n", parts["xl/styles.xml"])
+	}
+	comments := parts["xl/comments1.xml"]
+	if !strings.Contains(comments, `ref="B6"`) || !strings.Contains(comments, "Restated for the merger") {
+		t.Fatalf("expected the footnote as a comment on B6; outcome %s;\n", comments)
+	}
+	if _, found := parts["xl/drawings/vmlDrawing1.vml"]; !found {
+		t.Fatalf("expected the comment drawing")
+	}
+}
+
+const (
+	stringFactsSchema = `	<xs:element id="abc_EntityCentralIndexKey" name="EntityCentralIndexKey" type="xbrli:stringItemType" substitutionGroup="xbrli:item" xbrli:periodType="duration"/>
+	<xs:element id="abc_DocumentType" name="DocumentType" type="xbrli:stringItemType" substitutionGroup="xbrli:item" xbrli:periodType="duration"/>
+	<xs:element id="abc_FiscalPeriodFocus" name="FiscalPeriodFocus" type="xbrli:stringItemType" substitutionGroup="xbrli:item" xbrli:periodType="duration"/>
+	<xs:element id="abc_EntityRegistrantName" name="EntityRegistrantName" type="xbrli:stringItemType" substitutionGroup="xbrli:item" xbrli:periodType="duration"/>
+	<xs:element id="abc_SegmentsTextBlock" name="SegmentsTextBlock" type="nonnum:textBlockItemType" substitutionGroup="xbrli:item" xbrli:periodType="duration"/>
+</xs:schema>`
+	stringFactsPresentation = `	<link:loc xlink:type="locator" xlink:label="cik" xlink:href="abc.xsd#abc_EntityCentralIndexKey"/>
+		<link:loc xlink:type="locator" xlink:label="type" xlink:href="abc.xsd#abc_DocumentType"/>
+		<link:loc xlink:type="locator" xlink:label="focus" xlink:href="abc.xsd#abc_FiscalPeriodFocus"/>
+		<link:loc xlink:type="locator" xlink:label="name" xlink:href="abc.xsd#abc_EntityRegistrantName"/>
+		<link:loc xlink:type="locator" xlink:label="segments" xlink:href="abc.xsd#abc_SegmentsTextBlock"/>
+		<link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="abstract" xlink:to="cik" order="2"/>
+		<link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="abstract" xlink:to="type" order="3"/>
+		<link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="abstract" xlink:to="focus" order="4"/>
+		<link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="abstract" xlink:to="name" order="5"/>
+		<link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="abstract" xlink:to="segments" order="6"/>
+	</link:presentationLink>`
+	stringFactsInstance = `	<abc:EntityCentralIndexKey id="f2" contextRef="c1">0000320193</abc:EntityCentralIndexKey>
+	<abc:DocumentType id="f3" contextRef="c1">10-K</abc:DocumentType>
+	<abc:FiscalPeriodFocus id="f4" contextRef="c1">Q4</abc:FiscalPeriodFocus>
+	<abc:EntityRegistrantName id="f5" contextRef="c1">AT&amp;T Inc.</abc:EntityRegistrantName>
+	<abc:SegmentsTextBlock id="f6" contextRef="c1">&lt;table&gt;&lt;tr&gt;&lt;td&gt;North&lt;/td&gt;&lt;td&gt;10&lt;/td&gt;&lt;/tr&gt;&lt;tr&gt;&lt;td&gt;South&lt;/td&gt;&lt;td&gt;20&lt;/td&gt;&lt;/tr&gt;&lt;/table&gt;</abc:SegmentsTextBlock>
+</xbrli:xbrl>`
+)
+
+// hydrateStringFacts hydrates the locales with string facts of digits, an
+// escaped ampersand and a text block of a table
+func hydrateStringFacts(t *testing.T) *hydratables.Hydratable {
+	f := localesFolder(t)
+	schema, err := serializables.DecodeSchemaFile([]byte(strings.Replace(strings.Replace(localeSchema, `</xs:schema>`, stringFactsSchema, 1),
+		`xmlns:xbrli=`, `xmlns:nonnum="http://www.xbrl.org/dtr/type/non-numeric" xmlns:xbrli=`, 1)))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	f.Schemas["abc.xsd"] = *schema
+	presentation, err := serializables.DecodePresentationLinkbaseFile([]byte(strings.Replace(localePresentation, `</link:presentationLink>`, stringFactsPresentation, 1)))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	f.PresentationLinkbases["abc_pre.xml"] = *presentation
+	instance, err := serializables.DecodeInstanceFile([]byte(strings.Replace(localeInstance, `</xbrli:xbrl>`, stringFactsInstance, 1)))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	f.Instances["abc.xml"] = *instance
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	return h
+}
+
+func TestMarshalWorkbook_StringFacts(t *testing.T) {
+	h := hydrateStringFacts(t)
+	slug := ""
+	for _, network := range renderables.GetCatalog(h).Networks {
+		slug = network["http://abc.example.com/role/Revenue"]
+	}
+	data, err := renderables.MarshalWorkbook(slug, h, renderables.English)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	sheet := ""
+	for _, file := range zr.File {
+		if file.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("Error: " + err.Error())
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("Error: " + err.Error())
+		}
+		sheet = string(content)
+	}
+	for _, text := range []string{"0000320193", "10-K", "Q4"} {
+		if !strings.Contains(sheet, `<t xml:space="preserve">`+text+`</t>`) {
+			t.Fatalf("expected %s as text; outcome %s;\n", text, sheet)
+		}
+	}
+	for _, number := range []string{"<v>320193</v>", "<v>10</v>", "<v>4</v>"} {
+		if strings.Contains(sheet, number) {
+			t.Fatalf("expected no number %s; outcome %s;\n", number, sheet)
+		}
+	}
+	if !strings.Contains(sheet, "<v>12345678.5</v>") {
+		t.Fatalf("expected the revenue as a number; outcome %s;\n", sheet)
+	}
+}
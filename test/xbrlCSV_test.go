@@ -0,0 +1,220 @@
+package telefacts_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+const localeMetadata = `{
+	"documentInfo": {
+		"documentType": "https://xbrl.org/2021/xbrl-csv",
+		"namespaces": {
+			"abc": "http://abc.example.com/2023",
+			"iso4217": "http://www.xbrl.org/2003/iso4217",
+			"cik": "http://www.sec.gov/CIK"
+		},
+		"taxonomy": ["abc.xsd"]
+	},
+	"tableTemplates": {
+		"revenue": {
+			"columns": {
+				"year": {},
+				"Revenue": {
+					"dimensions": {
+						"concept": "abc:Revenue",
+						"unit": "iso4217:INR"
+					},
+					"decimals": 2
+				}
+			},
+			"dimensions": {
+				"entity": "$entity",
+				"period": "$year"
+			}
+		}
+	},
+	"tables": {
+		"revenue": {
+			"url": "revenue.csv"
+		}
+	},
+	"parameters": {
+		"entity": "cik:0000000001"
+	}
+}`
+
+const localeTable = "year,Revenue\n" +
+	"2023-01-01T00:00:00/2024-01-01T00:00:00,12345678.50\n" +
+	"2022-01-01T00:00:00/2023-01-01T00:00:00,\n"
+
+func TestDecodeXBRLCSVFile(t *testing.T) {
+	instance, err := serializables.DecodeXBRLCSVFile([]byte(localeMetadata), func(url string) ([]byte, error) {
+		if url != "revenue.csv" {
+			return nil, fmt.Errorf("unexpected url %s", url)
+		}
+		return []byte(localeTable), nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	f := localesFolder(t)
+	f.EntryFileName = "metadata.json"
+	f.Instances = map[string]serializables.InstanceFile{
+		"metadata.json": *instance,
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	hydrated := h.Instances["metadata.json"]
+	if len(hydrated.Facts) != 1 {
+		t.Fatalf("expected 1 fact; outcome %d", len(hydrated.Facts))
+	}
+	fact := hydrated.Facts[0]
+	if fact.ID != "revenue.1.Revenue" || fact.XMLInner != "12345678.50" || fact.Precision != 2 {
+		t.Fatalf("unexpected fact %v", fact)
+	}
+	context := hydrated.Contexts[0]
+	if context.Entity.Identifier.CharData != "0000000001" || context.Period.Duration.EndDate != "2023-12-31" {
+		t.Fatalf("unexpected context %v", context)
+	}
+	r := renderLocales(t, h)
+	if len(r.PGrid.FactualQuadrant) <= 0 {
+		t.Fatalf("expected a rendered fact")
+	}
+}
+
+func TestMarshalXBRLCSV(t *testing.T) {
+	h := hydrateLocales(t)
+	data, err := renderables.MarshalCatalog(h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	c := renderables.Catalog{}
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	slug := ""
+	for _, network := range c.Networks {
+		slug = network["http://abc.example.com/role/Revenue"]
+	}
+	data, err = renderables.MarshalXBRLCSV(slug, h, "presentation")
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	parts := map[string][]byte{}
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("Error: " + err.Error())
+		}
+		parts[file.Name], err = io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("Error: " + err.Error())
+		}
+	}
+	metadata := serializables.XBRLCSVFile{}
+	err = json.Unmarshal(parts["metadata.json"], &metadata)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	template, found := metadata.TableTemplates["Revenue"]
+	if !found {
+		t.Fatalf("expected a Revenue template; outcome %s", string(parts["metadata.json"]))
+	}
+	if template.Columns["Revenue"].Dimensions["concept"] != "abc:Revenue" {
+		t.Fatalf("unexpected columns %v", template.Columns)
+	}
+	instance, err := serializables.DecodeXBRLCSVFile(parts["metadata.json"], func(url string) ([]byte, error) {
+		table, found := parts[url]
+		if !found {
+			return nil, fmt.Errorf("%s not found", url)
+		}
+		return table, nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	f := localesFolder(t)
+	f.Instances = map[string]serializables.InstanceFile{
+		"abc.xml": *instance,
+	}
+	roundTrip, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	hydrated := roundTrip.Instances["abc.xml"]
+	if len(hydrated.Facts) != 1 {
+		t.Fatalf("expected 1 fact; outcome %s", string(parts["Revenue.csv"]))
+	}
+	fact := hydrated.Facts[0]
+	if fact.XMLInner != "12345678.50" || fact.Precision != 2 || fact.UnitRef == "" {
+		t.Fatalf("unexpected fact %v", fact)
+	}
+	if hydrated.Units[0].Numerators[0].CharData != "iso4217:INR" {
+		t.Fatalf("unexpected unit %v", hydrated.Units)
+	}
+	if hydrated.Contexts[0].Period.Duration.StartDate != "2023-01-01" || hydrated.Contexts[0].Period.Duration.EndDate != "2023-12-31" {
+		t.Fatalf("unexpected period %v", hydrated.Contexts[0].Period)
+	}
+}
+
+func TestReadOIMFile_TableUrls(t *testing.T) {
+	dir := t.TempDir()
+	folderDir := filepath.Join(dir, "folder")
+	err := os.Mkdir(folderDir, 0755)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	for _, name := range []string{
+		filepath.Join(folderDir, "revenue.csv"),
+		filepath.Join(dir, "secret.csv"),
+	} {
+		err = os.WriteFile(name, []byte(localeTable), 0644)
+		if err != nil {
+			t.Fatalf("Error: " + err.Error())
+		}
+	}
+	metadataPath := filepath.Join(folderDir, "metadata.json")
+	for _, tableUrl := range []string{
+		"revenue.csv",
+		"./sub/../revenue.csv",
+		"../secret.csv",
+		"sub/../../secret.csv",
+		"/etc/passwd",
+		filepath.ToSlash(filepath.Join(dir, "secret.csv")),
+		"http://example.com/revenue.csv",
+		"file:///etc/passwd",
+	} {
+		metadata := strings.Replace(localeMetadata, `"url": "revenue.csv"`, `"url": "`+tableUrl+`"`, 1)
+		err = os.WriteFile(metadataPath, []byte(metadata), 0644)
+		if err != nil {
+			t.Fatalf("Error: " + err.Error())
+		}
+		_, err = serializables.ReadOIMFile(metadataPath, nil)
+		isLocal := tableUrl == "revenue.csv" || tableUrl == "./sub/../revenue.csv"
+		if isLocal && err != nil {
+			t.Fatalf("expected %s to be read; outcome %v", tableUrl, err)
+		}
+		if !isLocal && (err == nil || !strings.HasPrefix(err.Error(), "table url")) {
+			t.Fatalf("expected %s to be refused; outcome %v", tableUrl, err)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package telefacts_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"ecksbee.com/telefacts/pkg/hydratables"
+	"ecksbee.com/telefacts/pkg/renderables"
+	"ecksbee.com/telefacts/pkg/serializables"
+)
+
+const localeReport = `{
+	"documentInfo": {
+		"documentType": "https://xbrl.org/2021/xbrl-json",
+		"namespaces": {
+			"xbrl": "https://xbrl.org/2021",
+			"abc": "http://abc.example.com/2023",
+			"iso4217": "http://www.xbrl.org/2003/iso4217",
+			"cik": "http://www.sec.gov/CIK"
+		},
+		"linkTypes": {
+			"footnote": "http://www.xbrl.org/2003/arcrole/fact-footnote"
+		},
+		"linkGroups": {
+			"_": "http://www.xbrl.org/2003/role/link"
+		},
+		"taxonomy": ["abc.xsd"]
+	},
+	"facts": {
+		"f1": {
+			"value": "12345678.50",
+			"decimals": 2,
+			"dimensions": {
+				"concept": "abc:Revenue",
+				"entity": "cik:0000000001",
+				"period": "2023-01-01T00:00:00/2024-01-01T00:00:00",
+				"unit": "iso4217:INR"
+			},
+			"links": {
+				"footnote": {
+					"_": ["fn1"]
+				}
+			}
+		},
+		"fn1": {
+			"value": "Restated & audited",
+			"dimensions": {
+				"concept": "xbrl:note",
+				"noteId": "fn1",
+				"language": "en"
+			}
+		}
+	}
+}`
+
+func TestDecodeXBRLJSONFile(t *testing.T) {
+	instance, err := serializables.DecodeXBRLJSONFile([]byte(localeReport), nil)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	f := localesFolder(t)
+	f.EntryFileName = "abc.json"
+	f.Instances = map[string]serializables.InstanceFile{
+		"abc.json": *instance,
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	facts := h.Instances["abc.json"].Facts
+	if len(facts) != 1 {
+		t.Fatalf("expected 1 fact; outcome %d", len(facts))
+	}
+	fact := facts[0]
+	if fact.ID != "f1" || fact.XMLInner != "12345678.50" || fact.Precision != 2 {
+		t.Fatalf("unexpected fact %v", fact)
+	}
+	hydrated := h.Instances["abc.json"]
+	context := hydrated.Contexts[0]
+	if context.Entity.Identifier.Scheme != "http://www.sec.gov/CIK" || context.Entity.Identifier.CharData != "0000000001" {
+		t.Fatalf("unexpected entity %v", context.Entity.Identifier)
+	}
+	if context.Period.Duration.StartDate != "2023-01-01" || context.Period.Duration.EndDate != "2023-12-31" {
+		t.Fatalf("unexpected period %v", context.Period)
+	}
+	if len(hydrated.Units) != 1 || hydrated.Units[0].Numerators[0].CharData != "iso4217:INR" {
+		t.Fatalf("unexpected units %v", hydrated.Units)
+	}
+	footnotes := h.GetFootnotes(&fact)
+	if len(footnotes) != 1 || footnotes[0].ID != "fn1" || footnotes[0].Lang != "en" || footnotes[0].InnerHtml != "Restated &amp; audited" {
+		t.Fatalf("unexpected footnotes %v", footnotes)
+	}
+}
+
+func TestMarshalXBRLJSON(t *testing.T) {
+	instance, err := serializables.DecodeXBRLJSONFile([]byte(localeReport), nil)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	f := localesFolder(t)
+	f.EntryFileName = "abc.json"
+	f.Instances = map[string]serializables.InstanceFile{
+		"abc.json": *instance,
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	data, err := renderables.MarshalXBRLJSON(h)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	expected := serializables.XBRLJSONFile{}
+	err = json.Unmarshal([]byte(localeReport), &expected)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	report := serializables.XBRLJSONFile{}
+	err = json.Unmarshal(data, &report)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(report.DocumentInfo.Taxonomy) != 1 || report.DocumentInfo.Taxonomy[0] != "abc.xsd" {
+		t.Fatalf("unexpected taxonomy %v", report.DocumentInfo.Taxonomy)
+	}
+	fact, found := report.Facts["f1"]
+	if !found {
+		t.Fatalf("expected fact f1; outcome %s", string(data))
+	}
+	for dimension, value := range expected.Facts["f1"].Dimensions {
+		if fact.Dimensions[dimension] != value {
+			t.Fatalf("expected %s %s; outcome %s", dimension, value, fact.Dimensions[dimension])
+		}
+	}
+	if fact.Value == nil || *fact.Value != "12345678.50" || fact.Decimals == nil || *fact.Decimals != 2 {
+		t.Fatalf("unexpected fact %s", string(data))
+	}
+	if len(fact.Links["footnote"]["_"]) != 1 || fact.Links["footnote"]["_"][0] != "fn1" {
+		t.Fatalf("unexpected links %v", fact.Links)
+	}
+	note, found := report.Facts["fn1"]
+	if !found || note.Dimensions["concept"] != "xbrl:note" || note.Dimensions["language"] != "en" {
+		t.Fatalf("unexpected footnote %s", string(data))
+	}
+	roundTrip, err := serializables.DecodeXBRLJSONFile(data, nil)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	if len(roundTrip.Facts) != 1 || len(roundTrip.FootnoteLink) != 1 || len(roundTrip.FootnoteLink[0].Footnote) != 1 {
+		t.Fatalf("unexpected round trip %v", roundTrip)
+	}
+	if roundTrip.FootnoteLink[0].Footnote[0].XMLInner != "Restated &amp; audited" {
+		t.Fatalf("unexpected round trip footnote %s", roundTrip.FootnoteLink[0].Footnote[0].XMLInner)
+	}
+}
+
+const typedSchema = `<?xml version="1.0" encoding="utf-8"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:xbrli="http://www.xbrl.org/2003/instance"
+	xmlns:xbrldt="http://xbrl.org/2005/xbrldt" xmlns:abc="http://abc.example.com/2023"
+	targetNamespace="http://abc.example.com/2023" elementFormDefault="qualified">
+	<xs:element id="abc_Revenue" name="Revenue" type="xbrli:monetaryItemType" substitutionGroup="xbrli:item" xbrli:periodType="duration" xbrli:balance="credit"/>
+	<xs:element id="abc_OrderAxis" name="OrderAxis" type="xbrli:stringItemType" substitutionGroup="xbrldt:dimensionItem" abstract="true" xbrli:periodType="duration" xbrldt:typedDomainRef="#abc_OrderDomain"/>
+	<xs:element id="abc_OrderDomain" name="OrderDomain" type="xs:integer"/>
+	<xs:element id="abc_RegionAxis" name="RegionAxis" type="xbrli:stringItemType" substitutionGroup="xbrldt:dimensionItem" abstract="true" xbrli:periodType="duration"/>
+	<xs:element id="abc_NorthMember" name="NorthMember" type="xbrli:stringItemType" substitutionGroup="xbrli:item" abstract="true" xbrli:periodType="duration"/>
+</xs:schema>`
+
+const typedReport = `{
+	"documentInfo": {
+		"documentType": "https://xbrl.org/2021/xbrl-json",
+		"namespaces": {
+			"abc": "http://abc.example.com/2023",
+			"iso4217": "http://www.xbrl.org/2003/iso4217",
+			"cik": "http://www.sec.gov/CIK"
+		},
+		"taxonomy": ["abc.xsd"]
+	},
+	"facts": {
+		"f1": {
+			"value": "100",
+			"decimals": 0,
+			"dimensions": {
+				"concept": "abc:Revenue",
+				"entity": "cik:0000000001",
+				"period": "2023-01-01T00:00:00/2024-01-01T00:00:00",
+				"unit": "iso4217:INR",
+				"abc:OrderAxis": "42",
+				"abc:RegionAxis": "abc:NorthMember"
+			}
+		}
+	}
+}`
+
+func TestDecodeXBRLJSONFile_TypedDimension(t *testing.T) {
+	schema, err := serializables.DecodeSchemaFile([]byte(typedSchema))
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	f := localesFolder(t)
+	f.Schemas["abc.xsd"] = *schema
+	instance, err := serializables.DecodeXBRLJSONFile([]byte(typedReport), f.TypedDomain)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	segment := instance.Context[0].Entity[0].Segment[0]
+	if len(segment.TypedMember) != 1 || segment.TypedMember[0].XMLInner != "<abc:OrderDomain>42</abc:OrderDomain>" {
+		t.Fatalf("unexpected typed members %v", segment.TypedMember)
+	}
+	if len(segment.ExplicitMember) != 1 || segment.ExplicitMember[0].CharData != "abc:NorthMember" {
+		t.Fatalf("unexpected explicit members %v", segment.ExplicitMember)
+	}
+	f.EntryFileName = "abc.json"
+	f.Instances = map[string]serializables.InstanceFile{
+		"abc.json": *instance,
+	}
+	h, err := hydratables.Hydrate(f)
+	if err != nil {
+		t.Fatalf("Error: " + err.Error())
+	}
+	typedMembers := h.Instances["abc.json"].Contexts[0].Entity.Segment.TypedMembers
+	if len(typedMembers) != 1 || typedMembers[0].Dimension.TypedDomainHref != "abc.xsd#abc_OrderDomain" ||
+		typedMembers[0].TypedMembersMap["abc.xsd#abc_OrderDomain"] != "42" {
+		t.Fatalf("unexpected typed members %v", typedMembers)
+	}
+}